@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
@@ -12,51 +13,49 @@ import (
 	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/visualisation"
 )
 
-func measureExecutionTime(algorithm func() []algorithms.Solution) ([]algorithms.Solution, time.Duration) {
+func measureExecutionTime[W algorithms.Weight](algorithm func() []algorithms.Solution[W]) ([]algorithms.Solution[W], time.Duration) {
 	start := time.Now()
 	solutions := algorithm()
 	elapsed := time.Since(start)
 	return solutions, elapsed
 }
 
-func processInstance(instanceName string, nodes []data.Node) {
+func processInstance[W algorithms.Weight](instanceName string, nodes []data.Node, policy data.DistancePolicy) {
 	fmt.Printf("Instance %s Statistics:\n", instanceName)
 
-	distanceMatrix := data.CalculateDistanceMatrix(nodes)
+	distanceMatrix, nodeCosts, err := data.Weights[W](nodes, policy)
+	if err != nil {
+		log.Fatalf("Error computing distances: %v", err)
+	}
 	startNodeIndices := utils.GenerateStartNodeIndices(len(nodes))
 	numSolutions := len(startNodeIndices)
 
-	nodeCosts := make([]int, len(nodes))
-	for i, node := range nodes {
-		nodeCosts[i] = node.Cost
-	}
-
 	// Apply algorithms
-	solutionSets := make(map[string][]algorithms.Solution)
+	solutionSets := make(map[string][]algorithms.Solution[W])
 	executionTimes := make(map[string]time.Duration)
 
-	var solutions []algorithms.Solution
+	var solutions []algorithms.Solution[W]
 	var elapsed time.Duration
 
-	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution {
+	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution[W] {
 		return algorithms.RandomSolution(distanceMatrix, nodeCosts, startNodeIndices)
 	})
 	solutionSets["Random_Solution"] = solutions
 	executionTimes["Random_Solution"] = elapsed
 
-	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution {
+	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution[W] {
 		return algorithms.NearestNeighborEnd(distanceMatrix, nodeCosts, startNodeIndices)
 	})
 	solutionSets["Nearest_Neighbor_End_Only"] = solutions
 	executionTimes["Nearest_Neighbor_End_Only"] = elapsed
 
-	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution {
+	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution[W] {
 		return algorithms.NearestNeighborAny(distanceMatrix, nodeCosts, startNodeIndices)
 	})
 	solutionSets["Nearest_Neighbor_Any_Position"] = solutions
 	executionTimes["Nearest_Neighbor_Any_Position"] = elapsed
 
-	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution {
+	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution[W] {
 		return algorithms.GreedyCycle(distanceMatrix, nodeCosts, startNodeIndices)
 	})
 	solutionSets["Greedy_Cycle"] = solutions
@@ -66,8 +65,8 @@ func processInstance(instanceName string, nodes []data.Node) {
 		if len(solutions) > 0 {
 			min, max, avg := utils.CalculateStatistics(solutions)
 			avgTime := float64(executionTimes[name].Nanoseconds()) / float64(numSolutions) / 1e6
-			// fmt.Printf("%s: min = %d, max = %d, average = %.2f, avg_time = %.4f ms\n", name, min, max, avg, avgTime)
-			fmt.Printf("%s: %.2f(%d,%d), avg_time = %.4f ms\n", name, avg, min, max, avgTime)
+			// fmt.Printf("%s: min = %v, max = %v, average = %.2f, avg_time = %.4f ms\n", name, min, max, avg, avgTime)
+			fmt.Printf("%s: %.2f(%v,%v), avg_time = %.4f ms\n", name, avg, min, max, avgTime)
 
 			bestSolution := algorithms.FindBestSolution(solutions)
 			fmt.Printf("Best path: %v\n", bestSolution.Path)
@@ -89,6 +88,19 @@ func processInstance(instanceName string, nodes []data.Node) {
 }
 
 func main() {
+	rounding := flag.String("rounding", "round", "distance and cost rounding: round, ceil, floor or exact")
+	scale := flag.Float64("scale", 1, "fixed-point factor applied to distances and costs before rounding")
+	flag.Parse()
+
+	policy, err := data.ParseDistancePolicy(*rounding, *scale)
+	if err != nil {
+		log.Fatalf("Invalid distance policy: %v", err)
+	}
+	run := processInstance[int]
+	if policy.Rounding == data.RoundExact {
+		run = processInstance[float64]
+	}
+
 	rand.Seed(time.Now().UnixNano())
 	// Read nodes from CSV files
 	nodesA, err := data.ReadNodes("./instances/TSPA.csv")
@@ -101,7 +113,7 @@ func main() {
 		log.Fatalf("Error reading TSPB.csv: %v", err)
 	}
 
-	processInstance("A", nodesA)
+	run("A", nodesA, policy)
 	fmt.Println()
-	processInstance("B", nodesB)
+	run("B", nodesB, policy)
 }
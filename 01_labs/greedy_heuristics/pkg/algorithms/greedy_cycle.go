@@ -5,13 +5,13 @@ import (
 )
 
 // GreedyCycle generates solutions using a greedy cycle algorithm.
-func GreedyCycle(distanceMatrix [][]int, nodeCosts []int, startNodeIndices []int) []Solution {
+func GreedyCycle[W Weight](distanceMatrix [][]W, nodeCosts []W, startNodeIndices []int) []Solution[W] {
 	n := len(nodeCosts)
 	if n == 0 {
 		return nil
 	}
 	k := (n + 1) / 2 // 50% of nodes rounded up
-	var solutions []Solution
+	var solutions []Solution[W]
 
 	for _, startNodeIndex := range startNodeIndices {
 		path := []int{startNodeIndex}
@@ -25,7 +25,7 @@ func GreedyCycle(distanceMatrix [][]int, nodeCosts []int, startNodeIndices []int
 		// Second node: choose the nearest neighbor to the start node
 		if len(unvisited) > 0 {
 			bestNodeIndex := -1
-			minScore := math.MaxInt32
+			minScore := W(math.MaxInt32)
 			for nodeIndex := range unvisited {
 				score := 2*distanceMatrix[startNodeIndex][nodeIndex] + nodeCosts[nodeIndex]
 				if score < minScore {
@@ -43,7 +43,7 @@ func GreedyCycle(distanceMatrix [][]int, nodeCosts []int, startNodeIndices []int
 		for len(path) < k && len(unvisited) > 0 {
 			bestNodeIndex := -1
 			bestPosition := -1
-			minIncreaseScore := math.MaxInt32
+			minIncreaseScore := W(math.MaxInt32)
 
 			for nodeIndex := range unvisited {
 				for i := 0; i < len(path); i++ {
@@ -67,20 +67,20 @@ func GreedyCycle(distanceMatrix [][]int, nodeCosts []int, startNodeIndices []int
 			}
 		}
 
-		totalDistance := 0
+		var totalDistance W
 		if len(path) > 1 {
 			for i := 0; i < len(path); i++ {
 				totalDistance += distanceMatrix[path[i]][path[(i+1)%len(path)]]
 			}
 		}
 
-		totalCost := 0
+		var totalCost W
 		for _, idx := range path {
 			totalCost += nodeCosts[idx]
 		}
 
 		objective := totalDistance + totalCost
-		solutions = append(solutions, Solution{Path: path, Objective: objective})
+		solutions = append(solutions, Solution[W]{Path: path, Objective: objective})
 	}
 
 	return solutions
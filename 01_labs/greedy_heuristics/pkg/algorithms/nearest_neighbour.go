@@ -4,13 +4,13 @@ import (
 	"math"
 )
 
-func NearestNeighborEnd(distanceMatrix [][]int, nodeCosts []int, startNodeIndices []int) []Solution {
+func NearestNeighborEnd[W Weight](distanceMatrix [][]W, nodeCosts []W, startNodeIndices []int) []Solution[W] {
 	n := len(nodeCosts)
 	if n == 0 {
 		return nil
 	}
 	k := (n + 1) / 2
-	var solutions []Solution
+	var solutions []Solution[W]
 
 	for _, startNodeIndex := range startNodeIndices {
 		path := []int{startNodeIndex}
@@ -24,7 +24,7 @@ func NearestNeighborEnd(distanceMatrix [][]int, nodeCosts []int, startNodeIndice
 		for len(path) < k {
 			lastNodeIndex := path[len(path)-1]
 			bestNodeIndex := -1
-			minScore := math.MaxInt32
+			minScore := W(math.MaxInt32)
 
 			for nodeIndex := range unvisited {
 				score := distanceMatrix[lastNodeIndex][nodeIndex] + nodeCosts[nodeIndex]
@@ -42,7 +42,7 @@ func NearestNeighborEnd(distanceMatrix [][]int, nodeCosts []int, startNodeIndice
 			}
 		}
 
-		totalDistance := 0
+		var totalDistance W
 		if len(path) > 1 {
 			for j := 0; j < len(path); j++ {
 				next := (j + 1) % len(path)
@@ -50,24 +50,24 @@ func NearestNeighborEnd(distanceMatrix [][]int, nodeCosts []int, startNodeIndice
 			}
 		}
 
-		totalCost := 0
+		var totalCost W
 		for _, idx := range path {
 			totalCost += nodeCosts[idx]
 		}
 
 		objective := totalDistance + totalCost
-		solutions = append(solutions, Solution{path, objective})
+		solutions = append(solutions, Solution[W]{path, objective})
 	}
 	return solutions
 }
 
-func NearestNeighborAny(distanceMatrix [][]int, nodeCosts []int, startNodeIndices []int) []Solution {
+func NearestNeighborAny[W Weight](distanceMatrix [][]W, nodeCosts []W, startNodeIndices []int) []Solution[W] {
 	n := len(nodeCosts)
 	if n == 0 {
 		return nil
 	}
 	k := (n + 1) / 2
-	var solutions []Solution
+	var solutions []Solution[W]
 
 	for _, startNodeIndex := range startNodeIndices {
 		path := []int{startNodeIndex}
@@ -78,14 +78,14 @@ func NearestNeighborAny(distanceMatrix [][]int, nodeCosts []int, startNodeIndice
 			}
 		}
 
-		totalDistance := 0
+		var totalDistance W
 		for len(path) < k {
-			minIncrease := math.MaxInt32
+			minIncrease := W(math.MaxInt32)
 			bestNodeIndex := -1
 			bestPosition := -1
 
 			for nodeIndex := range unvisited {
-				localMinIncrease := math.MaxInt32
+				localMinIncrease := W(math.MaxInt32)
 				localBestPos := -1
 
 				if len(path) == 1 {
@@ -153,13 +153,13 @@ func NearestNeighborAny(distanceMatrix [][]int, nodeCosts []int, startNodeIndice
 			}
 		}
 
-		totalCost := 0
+		var totalCost W
 		for _, idx := range path {
 			totalCost += nodeCosts[idx]
 		}
 
 		objective := totalDistance + totalCost
-		solutions = append(solutions, Solution{path, objective})
+		solutions = append(solutions, Solution[W]{path, objective})
 	}
 	return solutions
 }
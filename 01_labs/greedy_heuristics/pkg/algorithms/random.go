@@ -5,13 +5,13 @@ import (
 )
 
 // RandomSolution generates random solutions starting from a given set of start node indices.
-func RandomSolution(distanceMatrix [][]int, nodeCosts []int, startNodeIndices []int) []Solution {
+func RandomSolution[W Weight](distanceMatrix [][]W, nodeCosts []W, startNodeIndices []int) []Solution[W] {
 	n := len(nodeCosts)
 	if n == 0 {
 		return nil
 	}
 	k := (n + 1) / 2 // 50% rounded up
-	var solutions []Solution
+	var solutions []Solution[W]
 
 	for _, startNodeIndex := range startNodeIndices {
 		path := []int{startNodeIndex}
@@ -42,7 +42,7 @@ func RandomSolution(distanceMatrix [][]int, nodeCosts []int, startNodeIndices []
 			path[i+1], path[j+1] = path[j+1], path[i+1]
 		})
 
-		totalDistance := 0
+		var totalDistance W
 		if k > 1 {
 			for j := 0; j < k; j++ {
 				next := (j + 1) % k
@@ -50,13 +50,13 @@ func RandomSolution(distanceMatrix [][]int, nodeCosts []int, startNodeIndices []
 			}
 		}
 
-		totalCost := 0
+		var totalCost W
 		for _, idx := range path {
 			totalCost += nodeCosts[idx]
 		}
 
 		objective := totalDistance + totalCost
-		solutions = append(solutions, Solution{path, objective})
+		solutions = append(solutions, Solution[W]{path, objective})
 	}
 	return solutions
 }
@@ -1,40 +1,134 @@
 package data
 
 import (
+	"bytes"
 	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
 	"os"
 	"strconv"
+	"strings"
 )
 
+// ReadNodes reads a CSV file with columns x, y and cost into a slice of Node
+// values. The delimiter may be a semicolon, a comma or a tab; it is detected
+// from the first data line. A header row is skipped, as are empty lines and
+// lines starting with '#'. Coordinates and costs may be given as floats.
+// Negative values and nodes sharing the location of an earlier node are
+// rejected; every error names the offending line.
 func ReadNodes(filename string) ([]Node, error) {
-	file, err := os.Open(filename)
+	content, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
-
-	reader := csv.NewReader(file)
-	reader.Comma = ';'
-	records, err := reader.ReadAll()
+	nodes, err := parseNodes(content)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%s: %w", filename, err)
 	}
+	log.Printf("Read %d nodes from %s", len(nodes), filename)
+	return nodes, nil
+}
+
+// parseNodes parses the content of an instance file, see ReadNodes.
+func parseNodes(content []byte) ([]Node, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = detectDelimiter(content)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	type location struct{ x, y float64 }
+	firstLine := make(map[location]int)
 
 	var nodes []Node
-	for _, record := range records {
-		x, err := strconv.Atoi(record[0])
-		if err != nil {
-			return nil, err
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
 		}
-		y, err := strconv.Atoi(record[1])
 		if err != nil {
 			return nil, err
 		}
-		cost, err := strconv.Atoi(record[2])
+		line, _ := reader.FieldPos(0)
+
+		// trailing delimiters leave empty fields
+		for len(record) > 3 && strings.TrimSpace(record[len(record)-1]) == "" {
+			record = record[:len(record)-1]
+		}
+		if len(record) != 3 {
+			return nil, fmt.Errorf("line %d: expected 3 fields (x, y, cost), got %d", line, len(record))
+		}
+
+		values, err := parseRecord(record)
 		if err != nil {
-			return nil, err
+			if first && isHeader(record) {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		node := Node{values[0], values[1], values[2]}
+		loc := location{node.X, node.Y}
+		if prev, ok := firstLine[loc]; ok {
+			return nil, fmt.Errorf("line %d: node at (%g, %g) duplicates the node on line %d", line, node.X, node.Y, prev)
 		}
-		nodes = append(nodes, Node{x, y, cost})
+		firstLine[loc] = line
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 0 {
+		return nil, errors.New("no nodes")
 	}
 	return nodes, nil
 }
+
+// detectDelimiter returns the delimiter of the first non-empty, non-comment
+// line: a semicolon, a tab or a comma, in that order of preference.
+func detectDelimiter(content []byte) rune {
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, delim := range []rune{';', '\t', ','} {
+			if strings.ContainsRune(line, delim) {
+				return delim
+			}
+		}
+		break
+	}
+	return ';'
+}
+
+// parseRecord parses x, y and cost.
+func parseRecord(record []string) ([3]float64, error) {
+	var values [3]float64
+	for i, name := range [3]string{"x", "y", "cost"} {
+		field := strings.TrimSpace(record[i])
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return values, fmt.Errorf("invalid %s %q", name, field)
+		}
+		if v < 0 {
+			return values, fmt.Errorf("negative %s %s", name, field)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// isHeader reports whether no field of the record is a number, as in a
+// header row like "x;y;cost".
+func isHeader(record []string) bool {
+	for _, field := range record {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(field), 64); err == nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,244 @@
+package data
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseNodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Node
+		wantErr string // substring of the expected error
+	}{
+		{
+			name:    "semicolon",
+			content: "1;2;3\n4;5;6\n",
+			want:    []Node{{1, 2, 3}, {4, 5, 6}},
+		},
+		{
+			name:    "comma",
+			content: "1,2,3\n4,5,6",
+			want:    []Node{{1, 2, 3}, {4, 5, 6}},
+		},
+		{
+			name:    "tab",
+			content: "1\t2\t3\r\n4\t5\t6\r\n",
+			want:    []Node{{1, 2, 3}, {4, 5, 6}},
+		},
+		{
+			name:    "spaces after delimiters",
+			content: "1; 2;  3\n",
+			want:    []Node{{1, 2, 3}},
+		},
+		{
+			name:    "floats",
+			content: "1.5;2e1;0.25\n",
+			want:    []Node{{1.5, 20, 0.25}},
+		},
+		{
+			name:    "header",
+			content: "x;y;cost\n1;2;3\n",
+			want:    []Node{{1, 2, 3}},
+		},
+		{
+			name:    "BOM and header",
+			content: "\xef\xbb\xbfx,y,cost\n1,2,3\n",
+			want:    []Node{{1, 2, 3}},
+		},
+		{
+			name:    "BOM without header",
+			content: "\xef\xbb\xbf1;2;3\n",
+			want:    []Node{{1, 2, 3}},
+		},
+		{
+			name:    "comments and empty lines",
+			content: "# instance\n\n1;2;3\n\n# end\n4;5;6\n",
+			want:    []Node{{1, 2, 3}, {4, 5, 6}},
+		},
+		{
+			name:    "trailing delimiter",
+			content: "1;2;3;\n4;5;6;;\n",
+			want:    []Node{{1, 2, 3}, {4, 5, 6}},
+		},
+		{
+			name:    "short line",
+			content: "1;2;3\n4;5\n",
+			wantErr: "line 2: expected 3 fields (x, y, cost), got 2",
+		},
+		{
+			name:    "long line",
+			content: "1;2;3;4\n",
+			wantErr: "line 1: expected 3 fields (x, y, cost), got 4",
+		},
+		{
+			name:    "negative coordinate",
+			content: "1;2;3\n4;-5;6\n",
+			wantErr: "line 2: negative y -5",
+		},
+		{
+			name:    "negative cost",
+			content: "1;2;-3\n",
+			wantErr: "line 1: negative cost -3",
+		},
+		{
+			name:    "invalid number",
+			content: "1;2;3\n4;five;6\n",
+			wantErr: `line 2: invalid y "five"`,
+		},
+		{
+			name:    "NaN",
+			content: "NaN;2;3\n",
+			wantErr: `line 1: invalid x "NaN"`,
+		},
+		{
+			name:    "infinity",
+			content: "1;2;Inf\n",
+			wantErr: `line 1: invalid cost "Inf"`,
+		},
+		{
+			name:    "header after the first line",
+			content: "1;2;3\nx;y;cost\n",
+			wantErr: `line 2: invalid x "x"`,
+		},
+		{
+			name:    "duplicate location",
+			content: "1;2;3\n4;5;6\n1;2;7\n",
+			wantErr: "line 3: node at (1, 2) duplicates the node on line 1",
+		},
+		{
+			name:    "duplicate location written differently",
+			content: "1;2;3\n1.0;2e0;3\n",
+			wantErr: "line 2: node at (1, 2) duplicates the node on line 1",
+		},
+		{
+			name:    "line numbers count comments and empty lines",
+			content: "# a\n\n1;2;3\n# b\n4;5\n",
+			wantErr: "line 5: expected 3 fields",
+		},
+		{
+			name:    "empty",
+			content: "",
+			wantErr: "no nodes",
+		},
+		{
+			name:    "header only",
+			content: "x;y;cost\n",
+			wantErr: "no nodes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := parseNodes([]byte(tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(nodes) != len(tt.want) {
+				t.Fatalf("got %v, want %v", nodes, tt.want)
+			}
+			for i := range nodes {
+				if nodes[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", nodes, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadNodes(t *testing.T) {
+	nodes, err := ReadNodes("../../instances/TSPA.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 200 {
+		t.Errorf("read %d nodes, want 200", len(nodes))
+	}
+
+	if _, err := ReadNodes("missing.csv"); err == nil {
+		t.Error("no error for a missing file")
+	}
+
+	file := t.TempDir() + "/bad.csv"
+	if err := os.WriteFile(file, []byte("1;2;3\n4;5\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadNodes(file); err == nil || !strings.HasPrefix(err.Error(), file+": line 2:") {
+		t.Errorf("error %v, want it to name the file and the line", err)
+	}
+}
+
+// FuzzParseNodes checks that parseNodes never panics and that every accepted
+// instance holds finite, non-negative values at distinct locations and reads
+// back the same when written out again.
+func FuzzParseNodes(f *testing.F) {
+	for _, seed := range []string{
+		"1;2;3\n4;5;6\n",
+		"\xef\xbb\xbfx,y,cost\n1,2,3\n",
+		"1\t2\t3\r\n4\t5\t6\r\n",
+		"# c\n\n1.5;2e1;0.25;\n",
+		"1;2\n",
+		"1;-2;3\n",
+		"1;2;3\n1;2;3\n",
+		"\"1\";2;3\n",
+	} {
+		f.Add([]byte(seed))
+	}
+	if content, err := os.ReadFile("../../instances/TSPA.csv"); err == nil {
+		f.Add(content)
+	}
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		nodes, err := parseNodes(content)
+		if err != nil {
+			return
+		}
+		if len(nodes) == 0 {
+			t.Fatal("no nodes and no error")
+		}
+		type location struct{ x, y float64 }
+		seen := make(map[location]bool, len(nodes))
+		var out strings.Builder
+		for _, node := range nodes {
+			for _, v := range []float64{node.X, node.Y, node.Cost} {
+				if math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+					t.Fatalf("accepted value %v in %v", v, node)
+				}
+			}
+			loc := location{node.X, node.Y}
+			if seen[loc] {
+				t.Fatalf("accepted duplicate location %v", loc)
+			}
+			seen[loc] = true
+			for i, v := range []float64{node.X, node.Y, node.Cost} {
+				if i > 0 {
+					out.WriteByte(';')
+				}
+				out.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+			}
+			out.WriteByte('\n')
+		}
+
+		again, err := parseNodes([]byte(out.String()))
+		if err != nil {
+			t.Fatalf("written out %v fails to parse: %v", nodes, err)
+		}
+		if len(again) != len(nodes) {
+			t.Fatalf("read back %d nodes, want %d", len(again), len(nodes))
+		}
+		for i := range nodes {
+			if again[i] != nodes[i] {
+				t.Fatalf("node %d read back as %v, want %v", i, again[i], nodes[i])
+			}
+		}
+	})
+}
@@ -1,10 +1,116 @@
 package data
 
 import (
+	"errors"
+	"fmt"
+	"log"
 	"math"
+	"strings"
 )
 
+// Rounding selects how Euclidean distances and node costs are turned into
+// the integers used by the algorithms.
+type Rounding int
+
+const (
+	// RoundNearest rounds to the nearest integer (the default).
+	RoundNearest Rounding = iota
+	// RoundCeil rounds up.
+	RoundCeil
+	// RoundFloor rounds down.
+	RoundFloor
+	// RoundExact keeps exact float64 values. It has no integer form and is
+	// only accepted by CalculateExactDistanceMatrix, NodeCostsExact and
+	// Weights with float64 weights.
+	RoundExact
+)
+
+func (r Rounding) String() string {
+	switch r {
+	case RoundCeil:
+		return "ceil"
+	case RoundFloor:
+		return "floor"
+	case RoundExact:
+		return "exact"
+	default:
+		return "round"
+	}
+}
+
+// DistancePolicy describes how distances and node costs are computed. Scale
+// is a fixed-point factor applied to both distances and costs before
+// rounding, so that objectives are expressed in units of 1/Scale (0 = 1).
+type DistancePolicy struct {
+	Rounding Rounding
+	Scale    float64
+}
+
+// ParseDistancePolicy builds a policy from a rounding name (round, ceil,
+// floor or exact) and a scaling factor.
+func ParseDistancePolicy(rounding string, scale float64) (DistancePolicy, error) {
+	if scale < 0 {
+		return DistancePolicy{}, fmt.Errorf("negative scale %g", scale)
+	}
+	for _, r := range []Rounding{RoundNearest, RoundCeil, RoundFloor, RoundExact} {
+		if strings.EqualFold(rounding, r.String()) {
+			return DistancePolicy{Rounding: r, Scale: scale}, nil
+		}
+	}
+	return DistancePolicy{}, fmt.Errorf("unknown distance rounding %q (want round, ceil, floor or exact)", rounding)
+}
+
+// Value applies the policy to a distance or a cost. It panics for
+// RoundExact, which has no integer value.
+func (p DistancePolicy) Value(v float64) int {
+	v = p.scaled(v)
+	switch p.Rounding {
+	case RoundCeil:
+		return int(math.Ceil(v))
+	case RoundFloor:
+		return int(math.Floor(v))
+	case RoundNearest:
+		return int(math.Round(v))
+	default:
+		panic(fmt.Sprintf("distance rounding %v has no integer value", p.Rounding))
+	}
+}
+
+// scaled applies the fixed-point factor of the policy.
+func (p DistancePolicy) scaled(v float64) float64 {
+	if p.Scale > 0 {
+		return v * p.Scale
+	}
+	return v
+}
+
+// checkInteger reports an error if the policy has no integer form.
+func (p DistancePolicy) checkInteger() error {
+	if p.Rounding == RoundExact {
+		return errors.New("exact distances have no integer form, use CalculateExactDistanceMatrix and NodeCostsExact")
+	}
+	return nil
+}
+
+// distance returns the Euclidean distance between two nodes.
+func distance(a, b Node) float64 {
+	return math.Sqrt(math.Pow(b.X-a.X, 2) + math.Pow(b.Y-a.Y, 2))
+}
+
+// CalculateDistanceMatrix builds a symmetric matrix of Euclidean distances
+// between all pairs of nodes, rounded to the nearest integer.
 func CalculateDistanceMatrix(nodes []Node) [][]int {
+	D, _ := CalculateDistanceMatrixWithPolicy(nodes, DistancePolicy{})
+	return D
+}
+
+// CalculateDistanceMatrixWithPolicy builds a symmetric matrix of Euclidean
+// distances between all pairs of nodes, scaled and rounded by the policy. It
+// returns an error for RoundExact.
+func CalculateDistanceMatrixWithPolicy(nodes []Node, policy DistancePolicy) ([][]int, error) {
+	if err := policy.checkInteger(); err != nil {
+		return nil, err
+	}
 	n := len(nodes)
 	distanceMatrix := make([][]int, n)
 	for i := range distanceMatrix {
@@ -14,12 +120,85 @@ func CalculateDistanceMatrix(nodes []Node) [][]int {
 	for i := 0; i < n; i++ {
 		for j := 0; j < n; j++ {
 			if i != j {
-				x1, y1 := nodes[i].X, nodes[i].Y
-				x2, y2 := nodes[j].X, nodes[j].Y
-				distance := math.Sqrt(math.Pow(float64(x2-x1), 2) + math.Pow(float64(y2-y1), 2))
-				distanceMatrix[i][j] = int(math.Round(distance))
+				distanceMatrix[i][j] = policy.Value(distance(nodes[i], nodes[j]))
 			}
 		}
 	}
+	log.Printf("Calculated distance matrix for %d nodes", n)
+	return distanceMatrix, nil
+}
+
+// NodeCosts returns the node costs rounded to the nearest integer.
+func NodeCosts(nodes []Node) []int {
+	costs, _ := NodeCostsWithPolicy(nodes, DistancePolicy{})
+	return costs
+}
+
+// NodeCostsWithPolicy returns the node costs, scaled and rounded by the
+// policy. It returns an error for RoundExact.
+func NodeCostsWithPolicy(nodes []Node, policy DistancePolicy) ([]int, error) {
+	if err := policy.checkInteger(); err != nil {
+		return nil, err
+	}
+	costs := make([]int, len(nodes))
+	for i, node := range nodes {
+		costs[i] = policy.Value(node.Cost)
+	}
+	return costs, nil
+}
+
+// CalculateExactDistanceMatrix builds a symmetric matrix of exact Euclidean
+// distances between all pairs of nodes, scaled by the policy. The rounding of
+// the policy is ignored.
+func CalculateExactDistanceMatrix(nodes []Node, policy DistancePolicy) [][]float64 {
+	n := len(nodes)
+	distanceMatrix := make([][]float64, n)
+	for i := range distanceMatrix {
+		distanceMatrix[i] = make([]float64, n)
+		for j := range distanceMatrix[i] {
+			if i != j {
+				distanceMatrix[i][j] = policy.scaled(distance(nodes[i], nodes[j]))
+			}
+		}
+	}
+	log.Printf("Calculated exact distance matrix for %d nodes", n)
 	return distanceMatrix
 }
+
+// NodeCostsExact returns the exact node costs, scaled by the policy. The
+// rounding of the policy is ignored.
+func NodeCostsExact(nodes []Node, policy DistancePolicy) []float64 {
+	costs := make([]float64, len(nodes))
+	for i, node := range nodes {
+		costs[i] = policy.scaled(node.Cost)
+	}
+	return costs
+}
+
+// Weights returns the distance matrix and the node costs of the nodes computed
+// with the policy, as W values. Exact distances need a floating-point W; with
+// the other policies a float64 W holds the rounded integers.
+func Weights[W ~int | ~float64](nodes []Node, policy DistancePolicy) ([][]W, []W, error) {
+	value := func(v float64) W { return W(policy.Value(v)) }
+	if policy.Rounding == RoundExact {
+		if half := 0.5; W(half) == 0 {
+			return nil, nil, errors.New("exact distances need floating-point weights")
+		}
+		value = func(v float64) W { return W(policy.scaled(v)) }
+	}
+
+	n := len(nodes)
+	D := make([][]W, n)
+	costs := make([]W, n)
+	for i := range D {
+		D[i] = make([]W, n)
+		for j := range D[i] {
+			if i != j {
+				D[i][j] = value(distance(nodes[i], nodes[j]))
+			}
+		}
+		costs[i] = value(nodes[i].Cost)
+	}
+	log.Printf("Calculated distance matrix for %d nodes", n)
+	return D, costs, nil
+}
@@ -0,0 +1,101 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+// policyNodes are two nodes at distance 2.5 with costs 1.5 and 2.
+var policyNodes = []Node{{0, 0, 1.5}, {1.5, 2, 2}}
+
+func TestIntegerPolicies(t *testing.T) {
+	tests := []struct {
+		policy DistancePolicy
+		dist   int
+		costs  []int
+	}{
+		{DistancePolicy{}, 3, []int{2, 2}},
+		{DistancePolicy{Rounding: RoundCeil}, 3, []int{2, 2}},
+		{DistancePolicy{Rounding: RoundFloor}, 2, []int{1, 2}},
+		{DistancePolicy{Rounding: RoundFloor, Scale: 10}, 25, []int{15, 20}},
+		{DistancePolicy{Rounding: RoundNearest, Scale: 0.1}, 0, []int{0, 0}},
+	}
+	for _, tt := range tests {
+		D, err := CalculateDistanceMatrixWithPolicy(policyNodes, tt.policy)
+		if err != nil {
+			t.Fatalf("%+v: %v", tt.policy, err)
+		}
+		if D[0][1] != tt.dist || D[1][0] != tt.dist || D[0][0] != 0 {
+			t.Errorf("%+v: distances %v, want %d", tt.policy, D, tt.dist)
+		}
+		costs, err := NodeCostsWithPolicy(policyNodes, tt.policy)
+		if err != nil {
+			t.Fatalf("%+v: %v", tt.policy, err)
+		}
+		if !reflect.DeepEqual(costs, tt.costs) {
+			t.Errorf("%+v: costs %v, want %v", tt.policy, costs, tt.costs)
+		}
+	}
+}
+
+func TestExactPolicyHasNoIntegerForm(t *testing.T) {
+	policy := DistancePolicy{Rounding: RoundExact}
+	if _, err := CalculateDistanceMatrixWithPolicy(policyNodes, policy); err == nil {
+		t.Error("CalculateDistanceMatrixWithPolicy accepted exact rounding")
+	}
+	if _, err := NodeCostsWithPolicy(policyNodes, policy); err == nil {
+		t.Error("NodeCostsWithPolicy accepted exact rounding")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Value did not panic for exact rounding")
+		}
+	}()
+	policy.Value(2.5)
+}
+
+func TestExactDistancesAreScaled(t *testing.T) {
+	policy := DistancePolicy{Rounding: RoundExact, Scale: 10}
+	D := CalculateExactDistanceMatrix(policyNodes, policy)
+	if D[0][1] != 25 || D[1][0] != 25 || D[0][0] != 0 {
+		t.Errorf("distances %v, want 25", D)
+	}
+	if costs := NodeCostsExact(policyNodes, policy); !reflect.DeepEqual(costs, []float64{15, 20}) {
+		t.Errorf("costs %v, want [15 20]", costs)
+	}
+	if costs := NodeCostsExact(policyNodes, DistancePolicy{Rounding: RoundExact}); !reflect.DeepEqual(costs, []float64{1.5, 2}) {
+		t.Errorf("unscaled costs %v, want [1.5 2]", costs)
+	}
+}
+
+func TestParseDistancePolicy(t *testing.T) {
+	if p, err := ParseDistancePolicy("Exact", 2); err != nil || p != (DistancePolicy{RoundExact, 2}) {
+		t.Errorf("ParseDistancePolicy(Exact, 2) = %+v, %v", p, err)
+	}
+	for _, bad := range []struct {
+		rounding string
+		scale    float64
+	}{{"trunc", 1}, {"round", -1}} {
+		if _, err := ParseDistancePolicy(bad.rounding, bad.scale); err == nil {
+			t.Errorf("ParseDistancePolicy(%q, %g) accepted", bad.rounding, bad.scale)
+		}
+	}
+}
+
+func TestWeights(t *testing.T) {
+	D, costs, err := Weights[float64](policyNodes, DistancePolicy{Rounding: RoundFloor, Scale: 10})
+	if err != nil || D[0][1] != 25 || !reflect.DeepEqual(costs, []float64{15, 20}) {
+		t.Errorf("floor x10 as float64: distances %v, costs %v, %v", D, costs, err)
+	}
+	D, costs, err = Weights[float64](policyNodes, DistancePolicy{Rounding: RoundExact})
+	if err != nil || D[0][1] != 2.5 || !reflect.DeepEqual(costs, []float64{1.5, 2}) {
+		t.Errorf("exact: distances %v, costs %v, %v", D, costs, err)
+	}
+	intD, intCosts, err := Weights[int](policyNodes, DistancePolicy{})
+	if err != nil || intD[0][1] != 3 || !reflect.DeepEqual(intCosts, []int{2, 2}) {
+		t.Errorf("round as int: distances %v, costs %v, %v", intD, intCosts, err)
+	}
+	if _, _, err := Weights[int](policyNodes, DistancePolicy{Rounding: RoundExact}); err == nil {
+		t.Error("Weights accepted exact rounding with integer weights")
+	}
+}
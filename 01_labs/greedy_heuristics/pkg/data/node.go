@@ -1,5 +1,8 @@
+// Package data defines input data structures and helpers for reading and
+// preprocessing TSP instances. It is shared by all labs.
 package data
 
+// Node represents a single point in the plane with an associated cost.
 type Node struct {
-	X, Y, Cost int
+	X, Y, Cost float64
 }
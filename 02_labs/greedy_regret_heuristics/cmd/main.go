@@ -1,62 +1,67 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
 	"time"
 
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/02_labs/greedy_regret_heuristics/pkg/algorithms"
-	"github.com/czajkowskis/evolutionary_computation/02_labs/greedy_regret_heuristics/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/02_labs/greedy_regret_heuristics/pkg/utils"
 	"github.com/czajkowskis/evolutionary_computation/02_labs/greedy_regret_heuristics/pkg/visualisation"
 )
 
-func measureExecutionTime(algorithm func() []algorithms.Solution) ([]algorithms.Solution, time.Duration) {
+const (
+	numGRASPRuns = 20
+	timeLimitA   = 3276.57 // Average running time of MSLS (lab 06) for instance A, in ms
+	timeLimitB   = 2342.11 // Average running time of MSLS (lab 06) for instance B, in ms
+)
+
+func measureExecutionTime[W algorithms.Weight](algorithm func() []algorithms.Solution[W]) ([]algorithms.Solution[W], time.Duration) {
 	start := time.Now()
 	solutions := algorithm()
 	elapsed := time.Since(start)
 	return solutions, elapsed
 }
 
-func processInstance(instanceName string, nodes []data.Node) {
+func processInstance[W algorithms.Weight](instanceName string, nodes []data.Node, policy data.DistancePolicy) {
 	fmt.Printf("Instance %s Statistics:\n", instanceName)
 
-	distanceMatrix := data.CalculateDistanceMatrix(nodes)
+	distanceMatrix, nodeCosts, err := data.Weights[W](nodes, policy)
+	if err != nil {
+		log.Fatalf("Error computing distances: %v", err)
+	}
 	startNodeIndices := utils.GenerateStartNodeIndices(len(nodes))
 	numSolutions := len(startNodeIndices)
 
-	nodeCosts := make([]int, len(nodes))
-	for i, node := range nodes {
-		nodeCosts[i] = node.Cost
-	}
-
 	// Apply algorithms
-	solutionSets := make(map[string][]algorithms.Solution)
+	solutionSets := make(map[string][]algorithms.Solution[W])
 	executionTimes := make(map[string]time.Duration)
 
-	var solutions []algorithms.Solution
+	var solutions []algorithms.Solution[W]
 	var elapsed time.Duration
 
-	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution {
+	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution[W] {
 		return algorithms.NearestNeighborWeightedTwoRegret(distanceMatrix, nodeCosts, startNodeIndices, 1, 0)
 	})
 	solutionSets["Nearest_Neighbor_Two_Regret"] = solutions
 	executionTimes["Nearest_Neighbor_Two_Regret"] = elapsed
 
-	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution {
+	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution[W] {
 		return algorithms.GreedyCycleWeightedTwoRegret(distanceMatrix, nodeCosts, startNodeIndices, 1, 0)
 	})
 	solutionSets["Greedy_Cycle_Two_Regret"] = solutions
 	executionTimes["Greedy_Cycle_Two_Regret"] = elapsed
 
-	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution {
+	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution[W] {
 		return algorithms.NearestNeighborWeightedTwoRegret(distanceMatrix, nodeCosts, startNodeIndices, 0.5, 0.5)
 	})
 	solutionSets["Nearest_Neighbor_Weighted_Sum"] = solutions
 	executionTimes["Nearest_Neighbor_Weighted_Sum"] = elapsed
 
-	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution {
+	solutions, elapsed = measureExecutionTime(func() []algorithms.Solution[W] {
 		return algorithms.GreedyCycleWeightedTwoRegret(distanceMatrix, nodeCosts, startNodeIndices, 0.5, 0.5)
 	})
 	solutionSets["Greedy_Cycle_Weighted_Sum"] = solutions
@@ -66,8 +71,8 @@ func processInstance(instanceName string, nodes []data.Node) {
 		if len(solutions) > 0 {
 			min, max, avg := utils.CalculateStatistics(solutions)
 			avgTime := float64(executionTimes[name].Nanoseconds()) / float64(numSolutions) / 1e6
-			// fmt.Printf("%s: min = %d, max = %d, average = %.2f, avg_time = %.4f ms\n", name, min, max, avg, avgTime)
-			fmt.Printf("%s: %.2f(%d,%d), avg_time = %.4f ms\n", name, avg, min, max, avgTime)
+			// fmt.Printf("%s: min = %v, max = %v, average = %.2f, avg_time = %.4f ms\n", name, min, max, avg, avgTime)
+			fmt.Printf("%s: %.2f(%v,%v), avg_time = %.4f ms\n", name, avg, min, max, avgTime)
 
 			bestSolution := algorithms.FindBestSolution(solutions)
 			fmt.Printf("Best path: %v\n", bestSolution.Path)
@@ -86,9 +91,82 @@ func processInstance(instanceName string, nodes []data.Node) {
 			}
 		}
 	}
+
+	runGRASP(instanceName, nodes, distanceMatrix, nodeCosts)
+}
+
+// runGRASP runs every GRASP configuration numGRASPRuns times with the MSLS
+// time budget of the instance and prints the statistics of the best
+// solutions found.
+func runGRASP[W algorithms.Weight](instanceName string, nodes []data.Node, distanceMatrix [][]W, nodeCosts []W) {
+	timeLimit := time.Duration(timeLimitA * float64(time.Millisecond))
+	if instanceName == "B" {
+		timeLimit = time.Duration(timeLimitB * float64(time.Millisecond))
+	}
+
+	configs := []struct {
+		name   string
+		title  string
+		config algorithms.GRASPConfig
+	}{
+		{"GRASP_Cardinality", "GRASP (Cardinality RCL, alpha = 0.1)", algorithms.GRASPConfig{
+			Constructor: algorithms.GRASPGreedyCycle, RegretWeight: 0.5, ObjectiveWeight: 0.5,
+			RCL: algorithms.RCLCardinality, Alpha: 0.1,
+		}},
+		{"GRASP_Value", "GRASP (Value RCL, alpha = 0.2)", algorithms.GRASPConfig{
+			Constructor: algorithms.GRASPGreedyCycle, RegretWeight: 0.5, ObjectiveWeight: 0.5,
+			RCL: algorithms.RCLValue, Alpha: 0.2,
+		}},
+		{"GRASP_Reactive", "Reactive GRASP (Value RCL)", algorithms.GRASPConfig{
+			Constructor: algorithms.GRASPGreedyCycle, RegretWeight: 0.5, ObjectiveWeight: 0.5,
+			RCL: algorithms.RCLValue, Reactive: true,
+		}},
+	}
+
+	for _, c := range configs {
+		solutions := make([]algorithms.Solution[W], 0, numGRASPRuns)
+		totalIterations := 0
+		var totalTime time.Duration
+		for run := 0; run < numGRASPRuns; run++ {
+			config := c.config
+			config.TimeLimit = timeLimit
+			config.Seed = int64(run)
+			result := algorithms.GRASP(distanceMatrix, nodeCosts, config)
+			solutions = append(solutions, result.BestSolution)
+			totalIterations += result.Iterations
+			totalTime += result.Duration
+		}
+
+		min, max, avg := utils.CalculateStatistics(solutions)
+		avgTime := float64(totalTime.Nanoseconds()) / float64(numGRASPRuns) / 1e6
+		avgIterations := float64(totalIterations) / float64(numGRASPRuns)
+		fmt.Printf("%s: %.2f(%v,%v), avg_time = %.4f ms, avg_iterations = %.1f\n", c.name, avg, min, max, avgTime, avgIterations)
+
+		bestSolution := algorithms.FindBestSolution(solutions)
+		fmt.Printf("Best path: %v\n", bestSolution.Path)
+
+		plotTitle := fmt.Sprintf("Best %s Solution for Instance %s", c.title, instanceName)
+		plotFileName := fmt.Sprintf("Best_%s_Solution_%s", c.name, instanceName)
+		if err := visualisation.PlotSolution(nodes, bestSolution.Path, plotTitle, plotFileName, 0, 4000, 0, 2000); err != nil {
+			log.Printf("Error plotting best solution for %s on instance %s: %v", c.name, instanceName, err)
+		}
+	}
 }
 
 func main() {
+	rounding := flag.String("rounding", "round", "distance and cost rounding: round, ceil, floor or exact")
+	scale := flag.Float64("scale", 1, "fixed-point factor applied to distances and costs before rounding")
+	flag.Parse()
+
+	policy, err := data.ParseDistancePolicy(*rounding, *scale)
+	if err != nil {
+		log.Fatalf("Invalid distance policy: %v", err)
+	}
+	run := processInstance[int]
+	if policy.Rounding == data.RoundExact {
+		run = processInstance[float64]
+	}
+
 	rand.Seed(time.Now().UnixNano())
 	// Read nodes from CSV files
 	nodesA, err := data.ReadNodes("./instances/TSPA.csv")
@@ -101,7 +179,7 @@ func main() {
 		log.Fatalf("Error reading TSPB.csv: %v", err)
 	}
 
-	processInstance("A", nodesA)
+	run("A", nodesA, policy)
 	fmt.Println()
-	processInstance("B", nodesB)
+	run("B", nodesB, policy)
 }
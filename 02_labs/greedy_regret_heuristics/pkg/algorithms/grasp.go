@@ -0,0 +1,315 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+)
+
+// GRASPConstructor selects the insertion heuristic used by the randomized
+// construction phase of GRASP.
+type GRASPConstructor int
+
+const (
+	// GRASPGreedyCycle inserts nodes between consecutive cycle nodes, as in
+	// GreedyCycleWeightedTwoRegret.
+	GRASPGreedyCycle GRASPConstructor = iota
+	// GRASPNearestNeighbor inserts nodes anywhere in an open path, as in
+	// NearestNeighborWeightedTwoRegret.
+	GRASPNearestNeighbor
+)
+
+// RCLType selects how the restricted candidate list (RCL) is built from the
+// weighted regret scores.
+type RCLType int
+
+const (
+	// RCLCardinality keeps the max(1, ceil(alpha*m)) best scored of the m
+	// candidate nodes.
+	RCLCardinality RCLType = iota
+	// RCLValue keeps every node whose score is at least
+	// best - alpha*(best - worst).
+	RCLValue
+)
+
+// GRASPConfig holds configuration for GRASP.
+type GRASPConfig struct {
+	Constructor     GRASPConstructor
+	RegretWeight    float64       // Weight of the normalized 2-regret in the score
+	ObjectiveWeight float64       // Weight of the normalized best insertion cost in the score
+	RCL             RCLType       // How the restricted candidate list is built
+	Alpha           float64       // RCL greediness: 0 is pure greedy, 1 is uniformly random
+	Reactive        bool          // Choose alpha from Alphas with self-adapting probabilities
+	Alphas          []float64     // Alpha values for reactive GRASP (default 0, 0.1, ..., 0.5)
+	ReactivePeriod  int           // Iterations between probability updates (default 20)
+	TimeLimit       time.Duration // Time limit for the algorithm
+	Seed            int64
+}
+
+// GRASPResult contains the result of GRASP execution.
+type GRASPResult[W Weight] struct {
+	BestSolution Solution[W]
+	Iterations   int       // number of construction + local search iterations
+	AlphaProbs   []float64 // final alpha probabilities of reactive GRASP
+	Duration     time.Duration
+}
+
+// graspCandidate is an unvisited node with its best insertion and score.
+type graspCandidate struct {
+	nodeIndex    int
+	bestPosition int
+	score        float64
+}
+
+// graspConstruct builds a solution with the weighted 2-regret insertion
+// heuristic, where each step inserts a node drawn uniformly from the
+// restricted candidate list instead of the best scored node.
+func graspConstruct[W Weight](distanceMatrix [][]W, nodeCosts []W, config GRASPConfig, alpha float64, rng *rand.Rand) Solution[W] {
+	n := len(nodeCosts)
+	k := (n + 1) / 2
+	cycle := config.Constructor == GRASPGreedyCycle
+
+	startNodeIndex := rng.Intn(n)
+	path := []int{startNodeIndex}
+	unvisited := make([]int, 0, n-1)
+	for i := 0; i < n; i++ {
+		if i != startNodeIndex {
+			unvisited = append(unvisited, i)
+		}
+	}
+
+	infos := make([]insertionInfo[W], len(unvisited))
+	candidates := make([]graspCandidate, 0, len(unvisited))
+
+	for len(path) < k && len(unvisited) > 0 {
+		maxPossibleRegret := 0.0
+		maxPossibleObjective := 0.0
+
+		for u, nodeIndex := range unvisited {
+			bestLocalCost := W(math.MaxInt32)
+			secondBestLocalCost := W(math.MaxInt32)
+			bestPos := -1
+
+			positions := len(path) + 1 // open path: before, between and after
+			if cycle {
+				positions = len(path)
+			}
+			for pos := 0; pos < positions; pos++ {
+				var insertionCost W
+				switch {
+				case cycle:
+					// insert between path[pos] and its successor
+					p1 := path[pos]
+					p2 := path[(pos+1)%len(path)]
+					insertionCost = distanceMatrix[p1][nodeIndex] + distanceMatrix[nodeIndex][p2] - distanceMatrix[p1][p2]
+					if len(path) == 1 {
+						insertionCost = 2 * distanceMatrix[p1][nodeIndex]
+					}
+				case pos == 0:
+					insertionCost = distanceMatrix[nodeIndex][path[0]]
+				case pos == len(path):
+					insertionCost = distanceMatrix[path[len(path)-1]][nodeIndex]
+				default:
+					prev := path[pos-1]
+					next := path[pos]
+					insertionCost = distanceMatrix[prev][nodeIndex] + distanceMatrix[nodeIndex][next] - distanceMatrix[prev][next]
+				}
+				insertionCost += nodeCosts[nodeIndex]
+
+				if insertionCost < bestLocalCost {
+					secondBestLocalCost = bestLocalCost
+					bestLocalCost = insertionCost
+					bestPos = pos
+				} else if insertionCost < secondBestLocalCost {
+					secondBestLocalCost = insertionCost
+				}
+			}
+			if secondBestLocalCost == math.MaxInt32 {
+				secondBestLocalCost = bestLocalCost
+			}
+			infos[u] = insertionInfo[W]{nodeIndex, bestLocalCost, secondBestLocalCost, bestPos}
+
+			if regret := float64(secondBestLocalCost - bestLocalCost); regret > maxPossibleRegret {
+				maxPossibleRegret = regret
+			}
+			if float64(bestLocalCost) > maxPossibleObjective {
+				maxPossibleObjective = float64(bestLocalCost)
+			}
+		}
+
+		// Avoid division by zero
+		if maxPossibleRegret == 0 {
+			maxPossibleRegret = 1
+		}
+		if maxPossibleObjective == 0 {
+			maxPossibleObjective = 1
+		}
+
+		candidates = candidates[:0]
+		for u, nodeIndex := range unvisited {
+			info := infos[u]
+			normalizedRegret := float64(info.secondBestCost-info.bestCost) / maxPossibleRegret
+			normalizedObjective := float64(info.bestCost) / maxPossibleObjective
+			candidates = append(candidates, graspCandidate{
+				nodeIndex:    nodeIndex,
+				bestPosition: info.bestPosition,
+				score:        config.RegretWeight*normalizedRegret - config.ObjectiveWeight*normalizedObjective,
+			})
+		}
+
+		chosen := candidates[selectFromRCL(candidates, config.RCL, alpha, rng)]
+
+		// Insert the chosen node at its best position
+		insertAt := chosen.bestPosition
+		if cycle {
+			insertAt++
+		}
+		path = append(path, 0)
+		copy(path[insertAt+1:], path[insertAt:])
+		path[insertAt] = chosen.nodeIndex
+
+		for u, nodeIndex := range unvisited {
+			if nodeIndex == chosen.nodeIndex {
+				last := len(unvisited) - 1
+				unvisited[u], infos[u] = unvisited[last], infos[last]
+				unvisited = unvisited[:last]
+				break
+			}
+		}
+	}
+
+	return Solution[W]{Path: path, Objective: objective(distanceMatrix, nodeCosts, path)}
+}
+
+// selectFromRCL builds the restricted candidate list and returns the index of
+// a uniformly drawn member. Higher scores are better.
+func selectFromRCL(candidates []graspCandidate, rcl RCLType, alpha float64, rng *rand.Rand) int {
+	if rcl == RCLCardinality {
+		size := int(math.Ceil(alpha * float64(len(candidates))))
+		if size < 1 {
+			size = 1
+		}
+		// Sort by score (ties by node index) so that the RCL is a prefix.
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].score != candidates[j].score {
+				return candidates[i].score > candidates[j].score
+			}
+			return candidates[i].nodeIndex < candidates[j].nodeIndex
+		})
+		return rng.Intn(size)
+	}
+
+	best, worst := math.Inf(-1), math.Inf(1)
+	for _, c := range candidates {
+		best = math.Max(best, c.score)
+		worst = math.Min(worst, c.score)
+	}
+	threshold := best - alpha*(best-worst)
+	count := 0
+	chosen := 0
+	for i, c := range candidates {
+		if c.score >= threshold {
+			// reservoir sampling: uniform choice among RCL members
+			count++
+			if rng.Intn(count) == 0 {
+				chosen = i
+			}
+		}
+	}
+	return chosen
+}
+
+// GRASP runs the Greedy Randomized Adaptive Search Procedure: every iteration
+// builds a solution with the randomized weighted 2-regret construction and
+// improves it with the steepest local search of lab 05 (2-opt and exchange
+// moves), until the time limit is reached. With Reactive set, alpha is drawn
+// in every iteration from Alphas with probabilities proportional to
+// (best / average objective obtained with that alpha)^10, updated every
+// ReactivePeriod iterations.
+func GRASP[W Weight](distanceMatrix [][]W, nodeCosts []W, config GRASPConfig) GRASPResult[W] {
+	startTime := time.Now()
+	rng := rand.New(rand.NewSource(config.Seed))
+
+	if len(nodeCosts) == 0 {
+		return GRASPResult[W]{}
+	}
+	alphas := []float64{config.Alpha}
+	if config.Reactive {
+		alphas = config.Alphas
+		if len(alphas) == 0 {
+			alphas = []float64{0, 0.1, 0.2, 0.3, 0.4, 0.5}
+		}
+	}
+	if config.ReactivePeriod <= 0 {
+		config.ReactivePeriod = 20
+	}
+
+	probs := make([]float64, len(alphas))
+	for i := range probs {
+		probs[i] = 1 / float64(len(alphas))
+	}
+	sums := make([]float64, len(alphas))
+	counts := make([]int, len(alphas))
+
+	var ls deltas.LocalSearcher[W]
+	var best Solution[W]
+	iterations := 0
+
+	for iterations == 0 || time.Since(startTime) < config.TimeLimit {
+		a := drawIndex(probs, rng)
+		sol := graspConstruct(distanceMatrix, nodeCosts, config, alphas[a], rng)
+		ls.Improve(distanceMatrix, nodeCosts, sol.Path)
+		sol.Objective = objective(distanceMatrix, nodeCosts, sol.Path)
+		iterations++
+
+		if iterations == 1 || sol.Objective < best.Objective {
+			best = sol
+		}
+		sums[a] += float64(sol.Objective)
+		counts[a]++
+
+		if config.Reactive && iterations%config.ReactivePeriod == 0 {
+			updateAlphaProbs(probs, sums, counts, float64(best.Objective))
+		}
+	}
+
+	return GRASPResult[W]{
+		BestSolution: best,
+		Iterations:   iterations,
+		AlphaProbs:   probs,
+		Duration:     time.Since(startTime),
+	}
+}
+
+// updateAlphaProbs sets the probability of every alpha proportionally to
+// (best / average)^10; alphas not tried yet keep the weight of the best one.
+func updateAlphaProbs(probs, sums []float64, counts []int, best float64) {
+	const amplification = 10
+	total := 0.0
+	for i := range probs {
+		q := 1.0
+		if counts[i] > 0 {
+			q = math.Pow(best/(sums[i]/float64(counts[i])), amplification)
+		}
+		probs[i] = q
+		total += q
+	}
+	for i := range probs {
+		probs[i] /= total
+	}
+}
+
+// drawIndex draws an index with the given probabilities.
+func drawIndex(probs []float64, rng *rand.Rand) int {
+	r := rng.Float64()
+	for i, p := range probs {
+		if r < p {
+			return i
+		}
+		r -= p
+	}
+	return len(probs) - 1
+}
@@ -0,0 +1,162 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// randomInstance builds a random Euclidean instance with dim nodes placed on
+// a 1000x1000 grid, with rounded distances and node costs in [0, 500).
+func randomInstance(dim int, rng *rand.Rand) ([][]int, []int) {
+	xs := make([]int, dim)
+	ys := make([]int, dim)
+	costs := make([]int, dim)
+	for i := 0; i < dim; i++ {
+		xs[i], ys[i], costs[i] = rng.Intn(1000), rng.Intn(1000), rng.Intn(500)
+	}
+	D := make([][]int, dim)
+	for i := range D {
+		D[i] = make([]int, dim)
+		for j := range D[i] {
+			if i != j {
+				D[i][j] = int(math.Round(math.Hypot(float64(xs[i]-xs[j]), float64(ys[i]-ys[j]))))
+			}
+		}
+	}
+	return D, costs
+}
+
+// exactInstance builds a random Euclidean instance like randomInstance, but
+// with unrounded float64 distances.
+func exactInstance(dim int, rng *rand.Rand) ([][]float64, []float64) {
+	D := make([][]float64, dim)
+	costs := make([]float64, dim)
+	xs := make([]float64, dim)
+	ys := make([]float64, dim)
+	for i := 0; i < dim; i++ {
+		xs[i], ys[i], costs[i] = rng.Float64()*1000, rng.Float64()*1000, float64(rng.Intn(500))
+	}
+	for i := range D {
+		D[i] = make([]float64, dim)
+		for j := range D[i] {
+			D[i][j] = math.Hypot(xs[i]-xs[j], ys[i]-ys[j])
+		}
+	}
+	return D, costs
+}
+
+// checkSolution fails the test unless sol visits half of the nodes (rounded
+// up), each at most once, and carries its correct objective.
+func checkSolution[W Weight](t *testing.T, D [][]W, costs []W, sol Solution[W]) {
+	t.Helper()
+	if len(sol.Path) != (len(D)+1)/2 {
+		t.Fatalf("%d nodes selected, want %d", len(sol.Path), (len(D)+1)/2)
+	}
+	seen := make([]bool, len(D))
+	for _, v := range sol.Path {
+		if v < 0 || v >= len(D) || seen[v] {
+			t.Fatalf("invalid or repeated node %d in %v", v, sol.Path)
+		}
+		seen[v] = true
+	}
+	if got := objective(D, costs, sol.Path); sol.Objective != got {
+		t.Fatalf("objective %v, recomputed %v", sol.Objective, got)
+	}
+}
+
+// TestGRASPConstruct checks the randomized construction for both constructors
+// and RCL types, from pure greedy to uniformly random choices.
+func TestGRASPConstruct(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	D, costs := randomInstance(41, rng)
+	for _, constructor := range []GRASPConstructor{GRASPGreedyCycle, GRASPNearestNeighbor} {
+		for _, rcl := range []RCLType{RCLCardinality, RCLValue} {
+			for _, alpha := range []float64{0, 0.3, 1} {
+				t.Run(fmt.Sprintf("%d/%d/%.1f", constructor, rcl, alpha), func(t *testing.T) {
+					config := GRASPConfig{Constructor: constructor, RegretWeight: 1, ObjectiveWeight: 1, RCL: rcl}
+					for trial := 0; trial < 5; trial++ {
+						checkSolution(t, D, costs, graspConstruct(D, costs, config, alpha, rng))
+					}
+				})
+			}
+		}
+	}
+}
+
+// TestGRASP checks that plain and reactive GRASP return a valid solution and,
+// for reactive GRASP, a probability distribution over the alphas.
+func TestGRASP(t *testing.T) {
+	D, costs := randomInstance(60, rand.New(rand.NewSource(1)))
+	for _, reactive := range []bool{false, true} {
+		t.Run(fmt.Sprintf("reactive=%v", reactive), func(t *testing.T) {
+			res := GRASP(D, costs, GRASPConfig{
+				RegretWeight:    1,
+				ObjectiveWeight: 1,
+				Alpha:           0.2,
+				Reactive:        reactive,
+				ReactivePeriod:  2,
+				TimeLimit:       50 * time.Millisecond,
+				Seed:            1,
+			})
+			checkSolution(t, D, costs, res.BestSolution)
+			if res.Iterations < 1 {
+				t.Fatalf("%d iterations", res.Iterations)
+			}
+			sum := 0.0
+			for _, p := range res.AlphaProbs {
+				sum += p
+			}
+			if math.Abs(sum-1) > 1e-9 {
+				t.Fatalf("alpha probabilities %v sum to %v", res.AlphaProbs, sum)
+			}
+		})
+	}
+}
+
+// TestExactWeights checks the regret heuristics and GRASP with unrounded
+// float64 distances.
+func TestExactWeights(t *testing.T) {
+	D, costs := exactInstance(41, rand.New(rand.NewSource(1)))
+	starts := []int{0, 7, 20}
+	for _, sol := range GreedyCycleWeightedTwoRegret(D, costs, starts, 1, 1) {
+		checkSolution(t, D, costs, sol)
+	}
+	for _, sol := range NearestNeighborWeightedTwoRegret(D, costs, starts, 1, 1) {
+		checkSolution(t, D, costs, sol)
+	}
+	res := GRASP(D, costs, GRASPConfig{
+		RegretWeight:    1,
+		ObjectiveWeight: 1,
+		Alpha:           0.2,
+		TimeLimit:       20 * time.Millisecond,
+		Seed:            1,
+	})
+	checkSolution(t, D, costs, res.BestSolution)
+}
+
+// TestUpdateAlphaProbs checks the reactive update: the probabilities sum to
+// one, follow (best / average)^10 and give an alpha not tried yet the weight
+// of an alpha whose average equals the best objective.
+func TestUpdateAlphaProbs(t *testing.T) {
+	probs := make([]float64, 4)
+	sums := []float64{2000, 3300, 0, 1000}
+	counts := []int{2, 3, 0, 1}
+	updateAlphaProbs(probs, sums, counts, 1000)
+
+	weights := []float64{1, math.Pow(1000.0/1100, 10), 1, 1}
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	for i, w := range weights {
+		if want := w / total; math.Abs(probs[i]-want) > 1e-12 {
+			t.Errorf("probs[%d] = %v, want %v", i, probs[i], want)
+		}
+	}
+	if probs[1] >= probs[0] {
+		t.Errorf("alpha with a worse average has probability %v >= %v", probs[1], probs[0])
+	}
+}
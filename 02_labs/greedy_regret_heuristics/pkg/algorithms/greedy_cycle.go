@@ -6,13 +6,13 @@ import (
 )
 
 // GreedyCycle generates solutions using a greedy cycle algorithm.
-func GreedyCycleWeightedTwoRegret(distanceMatrix [][]int, nodeCosts []int, startNodeIndices []int, regretWeight float64, objectiveWeight float64) []Solution {
+func GreedyCycleWeightedTwoRegret[W Weight](distanceMatrix [][]W, nodeCosts []W, startNodeIndices []int, regretWeight float64, objectiveWeight float64) []Solution[W] {
 	n := len(nodeCosts)
 	if n == 0 {
 		return nil
 	}
 	k := (n + 1) / 2 // 50% of nodes rounded up
-	var solutions []Solution
+	var solutions []Solution[W]
 
 	for _, startNodeIndex := range startNodeIndices {
 		path := []int{startNodeIndex}
@@ -26,7 +26,7 @@ func GreedyCycleWeightedTwoRegret(distanceMatrix [][]int, nodeCosts []int, start
 		// Second node: choose the nearest neighbor to the start node
 		if len(unvisited) > 0 {
 			bestNodeIndex := -1
-			minScore := math.MaxInt32
+			minScore := W(math.MaxInt32)
 			for nodeIndex := range unvisited {
 				score := 2*distanceMatrix[startNodeIndex][nodeIndex] + nodeCosts[nodeIndex]
 				if score < minScore {
@@ -45,18 +45,12 @@ func GreedyCycleWeightedTwoRegret(distanceMatrix [][]int, nodeCosts []int, start
 			maxPossibleRegret := 0.0
 			maxPossibleObjective := 0.0
 
-			type insertionInfo struct {
-				nodeIndex      int
-				bestCost       int
-				secondBestCost int
-				bestPosition   int
-			}
-			var insertionInfos []insertionInfo
+			var insertionInfos []insertionInfo[W]
 
 			// Single pass to calculate costs and find normalization values
 			for nodeIndex := range unvisited {
-				bestLocalCost := math.MaxInt32
-				secondBestLocalCost := math.MaxInt32
+				bestLocalCost := W(math.MaxInt32)
+				secondBestLocalCost := W(math.MaxInt32)
 				bestPos := -1
 
 				for i := 0; i < len(path); i++ {
@@ -73,7 +67,7 @@ func GreedyCycleWeightedTwoRegret(distanceMatrix [][]int, nodeCosts []int, start
 						secondBestLocalCost = insertionCost
 					}
 				}
-				insertionInfos = append(insertionInfos, insertionInfo{nodeIndex, bestLocalCost, secondBestLocalCost, bestPos})
+				insertionInfos = append(insertionInfos, insertionInfo[W]{nodeIndex, bestLocalCost, secondBestLocalCost, bestPos})
 
 				if secondBestLocalCost == math.MaxInt32 {
 					secondBestLocalCost = bestLocalCost
@@ -132,7 +126,7 @@ func GreedyCycleWeightedTwoRegret(distanceMatrix [][]int, nodeCosts []int, start
 		}
 
 		// Calculate final objective value
-		objective := 0
+		var objective W
 		if len(path) > 0 {
 			for i := 0; i < len(path); i++ {
 				objective += distanceMatrix[path[i]][path[(i+1)%len(path)]]
@@ -142,7 +136,7 @@ func GreedyCycleWeightedTwoRegret(distanceMatrix [][]int, nodeCosts []int, start
 			}
 		}
 
-		solutions = append(solutions, Solution{Path: path, Objective: objective})
+		solutions = append(solutions, Solution[W]{Path: path, Objective: objective})
 	}
 
 	return solutions
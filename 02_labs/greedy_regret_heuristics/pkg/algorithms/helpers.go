@@ -0,0 +1,30 @@
+package algorithms
+
+import "math"
+
+// Calculate objective function value
+func objective[W Weight](D [][]W, costs []W, path []int) W {
+	if len(path) == 0 {
+		return math.MaxInt32 / 4
+	}
+	var sum W
+	n := len(path)
+	for i := 0; i < n; i++ {
+		a := path[i]
+		b := path[(i+1)%n]
+		sum += D[a][b]
+	}
+	for _, v := range path {
+		sum += costs[v]
+	}
+	return sum
+}
+
+// insertionInfo holds the cheapest and second cheapest insertion cost of an
+// unvisited node and the position of the cheapest insertion.
+type insertionInfo[W Weight] struct {
+	nodeIndex      int
+	bestCost       W
+	secondBestCost W
+	bestPosition   int
+}
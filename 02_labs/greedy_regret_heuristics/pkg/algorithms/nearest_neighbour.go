@@ -5,14 +5,14 @@ import (
 	"sort"
 )
 
-func NearestNeighborWeightedTwoRegret(distanceMatrix [][]int, nodeCosts []int, startNodeIndices []int, regretWeight float64, objectiveWeight float64) []Solution {
+func NearestNeighborWeightedTwoRegret[W Weight](distanceMatrix [][]W, nodeCosts []W, startNodeIndices []int, regretWeight float64, objectiveWeight float64) []Solution[W] {
 	n := len(nodeCosts)
 
 	if n == 0 {
 		return nil
 	}
 	k := (n + 1) / 2
-	var solutions []Solution
+	var solutions []Solution[W]
 
 	for _, startNodeIndex := range startNodeIndices {
 		path := []int{startNodeIndex}
@@ -27,22 +27,16 @@ func NearestNeighborWeightedTwoRegret(distanceMatrix [][]int, nodeCosts []int, s
 			maxPossibleRegret := 0.0
 			maxPossibleObjective := 0.0
 
-			type insertionInfo struct {
-				nodeIndex      int
-				bestCost       int
-				secondBestCost int
-				bestPosition   int
-			}
-			var insertionInfos []insertionInfo
+			var insertionInfos []insertionInfo[W]
 
 			// Single pass to calculate costs and find normalization values
 			for nodeIndex := range unvisited {
-				bestLocalCost := math.MaxInt32
-				secondBestLocalCost := math.MaxInt32
+				bestLocalCost := W(math.MaxInt32)
+				secondBestLocalCost := W(math.MaxInt32)
 				bestPos := -1
 
 				for pos := 0; pos <= len(path); pos++ {
-					var insertionCost int
+					var insertionCost W
 					if pos == 0 {
 						// Insert at the beginning of the path
 						insertionCost = distanceMatrix[nodeIndex][path[0]] + nodeCosts[nodeIndex]
@@ -66,7 +60,7 @@ func NearestNeighborWeightedTwoRegret(distanceMatrix [][]int, nodeCosts []int, s
 						secondBestLocalCost = insertionCost
 					}
 				}
-				insertionInfos = append(insertionInfos, insertionInfo{nodeIndex, bestLocalCost, secondBestLocalCost, bestPos})
+				insertionInfos = append(insertionInfos, insertionInfo[W]{nodeIndex, bestLocalCost, secondBestLocalCost, bestPos})
 
 				if secondBestLocalCost == math.MaxInt32 {
 					secondBestLocalCost = bestLocalCost
@@ -122,7 +116,7 @@ func NearestNeighborWeightedTwoRegret(distanceMatrix [][]int, nodeCosts []int, s
 			}
 		}
 
-		objective := 0
+		var objective W
 		if len(path) > 1 {
 			for i := 0; i < len(path)-1; i++ {
 				objective += distanceMatrix[path[i]][path[i+1]]
@@ -133,7 +127,7 @@ func NearestNeighborWeightedTwoRegret(distanceMatrix [][]int, nodeCosts []int, s
 			objective += nodeCosts[nodeIndex]
 		}
 
-		solutions = append(solutions, Solution{Path: path, Objective: objective})
+		solutions = append(solutions, Solution[W]{Path: path, Objective: objective})
 	}
 
 	return solutions
@@ -33,11 +33,11 @@ type MethodSpec struct {
 	Name  string
 }
 
-func objective(D [][]int, costs []int, path []int) int {
+func objective[W Weight](D [][]W, costs []W, path []int) W {
 	if len(path) == 0 {
 		return math.MaxInt32 / 4
 	}
-	sum := 0
+	var sum W
 	n := len(path)
 	for i := 0; i < n; i++ {
 		a := path[i]
@@ -50,6 +50,14 @@ func objective(D [][]int, costs []int, path []int) int {
 	return sum
 }
 
+// improvementEps is the smallest objective decrease of an applied move, so
+// that floating-point noise cannot make a search with exact distances cycle.
+const improvementEps = 1e-9
+
+// improves reports whether a move with the given delta decreases the
+// objective by more than the floating-point noise of exact distances.
+func improves[W Weight](delta W) bool { return float64(delta) < -improvementEps }
+
 func prevIdx(i, n int) int {
 	if i == 0 {
 		return n - 1
@@ -66,7 +74,7 @@ func nextIdx(i, n int) int {
 func selectCount(n int) int { return (n + 1) / 2 }
 
 // random selection of K nodes and random permutation (cycle)
-func startRandom(D [][]int, costs []int, rng *rand.Rand) Solution {
+func startRandom[W Weight](D [][]W, costs []W, rng *rand.Rand) Solution[W] {
 	n := len(D)
 	k := selectCount(n)
 
@@ -78,11 +86,11 @@ func startRandom(D [][]int, costs []int, rng *rand.Rand) Solution {
 	path := append([]int(nil), idx[:k]...)
 	rng.Shuffle(k, func(i, j int) { path[i], path[j] = path[j], path[i] })
 
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
 // make a greedy path (using NN Any Position algortihm) - from a chosen node - as a start for LS
-func startGreedy(distanceMatrix [][]int, nodeCosts []int, startNodeIndex, k int) Solution {
+func startGreedy[W Weight](distanceMatrix [][]W, nodeCosts []W, startNodeIndex, k int) Solution[W] {
 	n := len(nodeCosts)
 
 	path := []int{startNodeIndex}
@@ -94,12 +102,12 @@ func startGreedy(distanceMatrix [][]int, nodeCosts []int, startNodeIndex, k int)
 	}
 
 	for len(path) < k {
-		minIncrease := math.MaxInt32
+		minIncrease := W(math.MaxInt32)
 		bestNodeIndex := -1
 		bestPosition := -1
 
 		for nodeIndex := range unvisited {
-			localMinIncrease := math.MaxInt32
+			localMinIncrease := W(math.MaxInt32)
 			localBestPos := -1
 
 			if len(path) == 1 {
@@ -159,13 +167,13 @@ func startGreedy(distanceMatrix [][]int, nodeCosts []int, startNodeIndex, k int)
 		}
 
 	}
-	return Solution{Path: path, Objective: objective(distanceMatrix, nodeCosts, path)}
+	return Solution[W]{Path: path, Objective: objective(distanceMatrix, nodeCosts, path)}
 }
 
 // DELTAS FOR DIFFERENT MOVES
 
 // intra-route move - two-nodes exchange: change path[i] with path[j]
-func deltaSwap(D [][]int, path []int, i, j int) int {
+func deltaSwap[W Weight](D [][]W, path []int, i, j int) W {
 	if i == j {
 		return 0
 	}
@@ -197,7 +205,7 @@ func deltaSwap(D [][]int, path []int, i, j int) int {
 }
 
 // intra-route move - two edges exchange: 2-opt between path[i] and path[j]
-func deltaTwoOpt(D [][]int, path []int, i, j int) int {
+func deltaTwoOpt[W Weight](D [][]W, path []int, i, j int) W {
 	if i == j {
 		return 0
 	}
@@ -216,7 +224,7 @@ func deltaTwoOpt(D [][]int, path []int, i, j int) int {
 }
 
 // inter-route move - two-nodes exchange - path[i] with u (u outside the current path)
-func deltaExchangeSelected(distanceMatrix [][]int, nodeCosts []int, path []int, i int, u int) int {
+func deltaExchangeSelected[W Weight](distanceMatrix [][]W, nodeCosts []W, path []int, i int, u int) W {
 	n := len(path)
 	a := path[prevIdx(i, n)]
 	v := path[i]
@@ -242,13 +250,15 @@ func applyExchangeSelected(path []int, i int, u int) { path[i] = u }
 
 // LS TYPES - Steepest / Greedy
 
-func localSearchSteepest(distanceMatrix [][]int, nodeCosts []int, init Solution, intra IntraType) Solution {
+func localSearchSteepest[W Weight](distanceMatrix [][]W, nodeCosts []W, init Solution[W], intra IntraType) Solution[W] {
 	path := append([]int(nil), init.Path...)
 	n := len(path)
 
 	for {
-		bestDelta := 0
+		var bestDelta W
 		bestMove := func() {}
+		// description of the best move for the verification mode
+		bestName, bestI, bestJ := "", 0, 0
 
 		// INTRA
 		switch intra {
@@ -260,6 +270,7 @@ func localSearchSteepest(distanceMatrix [][]int, nodeCosts []int, init Solution,
 						ii, jj := i, j
 						bestDelta = dl
 						bestMove = func() { applySwap(path, ii, jj) }
+						bestName, bestI, bestJ = "swap", ii, jj
 					}
 				}
 			}
@@ -271,6 +282,7 @@ func localSearchSteepest(distanceMatrix [][]int, nodeCosts []int, init Solution,
 						ii, jj := i, j
 						bestDelta = dl
 						bestMove = func() { applyTwoOpt(path, ii, jj) }
+						bestName, bestI, bestJ = "2-opt", ii, jj
 					}
 				}
 			}
@@ -294,19 +306,22 @@ func localSearchSteepest(distanceMatrix [][]int, nodeCosts []int, init Solution,
 					ii, uu := i, u
 					bestDelta = dl
 					bestMove = func() { applyExchangeSelected(path, ii, uu) }
+					bestName, bestI, bestJ = "exchange", ii, uu
 				}
 			}
 		}
-		if bestDelta < 0 {
+		if improves(bestDelta) {
+			before := objectiveBeforeMove(distanceMatrix, nodeCosts, path)
 			bestMove()
+			checkMove(distanceMatrix, nodeCosts, path, before, bestDelta, bestName, bestI, bestJ)
 		} else {
 			break
 		}
 	}
-	return Solution{Path: path, Objective: objective(distanceMatrix, nodeCosts, path)}
+	return Solution[W]{Path: path, Objective: objective(distanceMatrix, nodeCosts, path)}
 }
 
-func localSearchGreedy(distanceMatrix [][]int, nodeCosts []int, init Solution, intra IntraType, rng *rand.Rand) Solution {
+func localSearchGreedy[W Weight](distanceMatrix [][]W, nodeCosts []W, init Solution[W], intra IntraType, rng *rand.Rand) Solution[W] {
 	path := append([]int(nil), init.Path...)
 	n := len(path)
 
@@ -324,8 +339,10 @@ func localSearchGreedy(distanceMatrix [][]int, nodeCosts []int, init Solution, i
 				for _, i := range pi {
 					pj := randPermFrom(rng, i+1, n)
 					for _, j := range pj {
-						if deltaSwap(distanceMatrix, path, i, j) < 0 {
+						if dl := deltaSwap(distanceMatrix, path, i, j); improves(dl) {
+							before := objectiveBeforeMove(distanceMatrix, nodeCosts, path)
 							applySwap(path, i, j)
+							checkMove(distanceMatrix, nodeCosts, path, before, dl, "swap", i, j)
 							return true
 						}
 					}
@@ -335,8 +352,10 @@ func localSearchGreedy(distanceMatrix [][]int, nodeCosts []int, init Solution, i
 				for _, i := range pi {
 					pj := randPermFrom(rng, i+1, n)
 					for _, j := range pj {
-						if deltaTwoOpt(distanceMatrix, path, i, j) < 0 {
+						if dl := deltaTwoOpt(distanceMatrix, path, i, j); improves(dl) {
+							before := objectiveBeforeMove(distanceMatrix, nodeCosts, path)
 							applyTwoOpt(path, i, j)
+							checkMove(distanceMatrix, nodeCosts, path, before, dl, "2-opt", i, j)
 							return true
 						}
 					}
@@ -360,8 +379,10 @@ func localSearchGreedy(distanceMatrix [][]int, nodeCosts []int, init Solution, i
 			pi := randPerm(rng, n)
 			for _, i := range pi {
 				for _, u := range nonSel {
-					if deltaExchangeSelected(distanceMatrix, nodeCosts, path, i, u) < 0 {
+					if dl := deltaExchangeSelected(distanceMatrix, nodeCosts, path, i, u); improves(dl) {
+						before := objectiveBeforeMove(distanceMatrix, nodeCosts, path)
 						applyExchangeSelected(path, i, u)
+						checkMove(distanceMatrix, nodeCosts, path, before, dl, "exchange", i, u)
 						return true
 					}
 				}
@@ -386,7 +407,7 @@ func localSearchGreedy(distanceMatrix [][]int, nodeCosts []int, init Solution, i
 			break
 		}
 	}
-	return Solution{Path: path, Objective: objective(distanceMatrix, nodeCosts, path)}
+	return Solution[W]{Path: path, Objective: objective(distanceMatrix, nodeCosts, path)}
 }
 
 func randPerm(r *rand.Rand, n int) []int {
@@ -415,13 +436,13 @@ func randPermFrom(r *rand.Rand, start, n int) []int {
 // startNodeIndices:
 //   - for StartGreedy we will use consecutive indices as starting nodes for greedy construction
 //   - for StartRandom we ignore this list (generating numSolutions randomly)
-func RunLocalSearchBatch(
-	distanceMatrix [][]int,
-	nodeCosts []int,
+func RunLocalSearchBatch[W Weight](
+	distanceMatrix [][]W,
+	nodeCosts []W,
 	startNodeIndices []int,
 	m MethodSpec,
 	numSolutions int,
-) []Solution {
+) []Solution[W] {
 	if numSolutions <= 0 {
 		return nil
 	}
@@ -429,13 +450,13 @@ func RunLocalSearchBatch(
 	n := len(distanceMatrix)
 	k := selectCount(n)
 
-	results := make([]Solution, 0, numSolutions)
+	results := make([]Solution[W], 0, numSolutions)
 
 	switch m.Start {
 	case StartRandom:
 		for r := 0; r < numSolutions; r++ {
 			init := startRandom(distanceMatrix, nodeCosts, rng)
-			var sol Solution
+			var sol Solution[W]
 			switch m.LS {
 			case LS_Steepest:
 				sol = localSearchSteepest(distanceMatrix, nodeCosts, init, m.Intra)
@@ -453,7 +474,7 @@ func RunLocalSearchBatch(
 				start = r % n
 			}
 			init := startGreedy(distanceMatrix, nodeCosts, start, k)
-			var sol Solution
+			var sol Solution[W]
 			switch m.LS {
 			case LS_Steepest:
 				sol = localSearchSteepest(distanceMatrix, nodeCosts, init, m.Intra)
@@ -463,14 +484,14 @@ func RunLocalSearchBatch(
 			results = append(results, sol)
 
 			if sol.Objective > init.Objective {
-				log.Fatalf("LS worsened solution: init=%d, after=%d", init.Objective, sol.Objective)
+				log.Fatalf("LS worsened solution: init=%v, after=%v", init.Objective, sol.Objective)
 			}
 
 		}
 	default:
 		for r := 0; r < numSolutions; r++ {
 			init := startRandom(distanceMatrix, nodeCosts, rng)
-			var sol Solution
+			var sol Solution[W]
 			switch m.LS {
 			case LS_Steepest:
 				sol = localSearchSteepest(distanceMatrix, nodeCosts, init, m.Intra)
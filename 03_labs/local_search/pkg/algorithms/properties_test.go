@@ -0,0 +1,236 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
+)
+
+// testInstance is an instance for the property tests.
+type testInstance struct {
+	name  string
+	D     [][]int
+	costs []int
+}
+
+// randomInstance builds a random Euclidean instance with dim nodes placed on
+// a 1000x1000 grid, with rounded distances and node costs in [0, 500).
+func randomInstance(dim int, rng *rand.Rand) testInstance {
+	xs := make([]int, dim)
+	ys := make([]int, dim)
+	inst := testInstance{name: fmt.Sprintf("random-%d", dim), D: make([][]int, dim), costs: make([]int, dim)}
+	for i := 0; i < dim; i++ {
+		xs[i], ys[i], inst.costs[i] = rng.Intn(1000), rng.Intn(1000), rng.Intn(500)
+	}
+	for i := range inst.D {
+		inst.D[i] = make([]int, dim)
+		for j := range inst.D[i] {
+			if i != j {
+				inst.D[i][j] = int(math.Round(math.Hypot(float64(xs[i]-xs[j]), float64(ys[i]-ys[j]))))
+			}
+		}
+	}
+	return inst
+}
+
+// exactInstance builds a random Euclidean instance like randomInstance, but
+// with unrounded float64 distances.
+func exactInstance(dim int, rng *rand.Rand) ([][]float64, []float64) {
+	D := make([][]float64, dim)
+	costs := make([]float64, dim)
+	xs := make([]float64, dim)
+	ys := make([]float64, dim)
+	for i := 0; i < dim; i++ {
+		xs[i], ys[i], costs[i] = rng.Float64()*1000, rng.Float64()*1000, float64(rng.Intn(500))
+	}
+	for i := range D {
+		D[i] = make([]float64, dim)
+		for j := range D[i] {
+			D[i][j] = math.Hypot(xs[i]-xs[j], ys[i]-ys[j])
+		}
+	}
+	return D, costs
+}
+
+// propertyInstances returns the lab instances and small random instances.
+func propertyInstances(t *testing.T, rng *rand.Rand) []testInstance {
+	t.Helper()
+	var instances []testInstance
+	for _, name := range []string{"A", "B"} {
+		nodes, err := data.ReadNodes("../../instances/TSP" + name + ".csv")
+		if err != nil {
+			t.Fatal(err)
+		}
+		costs := data.NodeCosts(nodes)
+		instances = append(instances, testInstance{name, data.CalculateDistanceMatrix(nodes), costs})
+	}
+	for _, dim := range []int{5, 6, 7, 10, 25} {
+		instances = append(instances, randomInstance(dim, rng))
+	}
+	return instances
+}
+
+// randomTour selects between 3 and len(D) nodes at random and returns them in
+// random order.
+func randomTour(D [][]int, rng *rand.Rand) []int {
+	dim := len(D)
+	k := 3
+	if dim > 3 {
+		k += rng.Intn(dim - 2)
+	}
+	return rng.Perm(dim)[:k]
+}
+
+// describeTour prints short tours in full and long ones by their length.
+func describeTour(path []int) string {
+	if len(path) <= 20 {
+		return fmt.Sprint(path)
+	}
+	return fmt.Sprintf("tour of %d nodes", len(path))
+}
+
+// TestDeltaProperties checks every delta function against a full
+// recomputation of the objective: for random tours and random move positions,
+// objective(after) - objective(before) has to equal the delta. Tours of every
+// length from 3 nodes are tried so that moves between adjacent positions and
+// around the end of the path are covered.
+func TestDeltaProperties(t *testing.T) {
+	trials := 20000
+	if testing.Short() {
+		trials = 1000
+	}
+	rng := rand.New(rand.NewSource(1))
+	for _, inst := range propertyInstances(t, rng) {
+		t.Run(inst.name, func(t *testing.T) {
+			D, costs := inst.D, inst.costs
+			check := func(move string, path []int, i, j, delta int, apply func([]int)) {
+				t.Helper()
+				moved := append([]int(nil), path...)
+				apply(moved)
+				if got := objective(D, costs, moved) - objective(D, costs, path); got != delta {
+					t.Fatalf("%s(%d, %d) on %s: delta %d, objective changed by %d", move, i, j, describeTour(path), delta, got)
+				}
+			}
+
+			inSel := make([]bool, len(D))
+			for trial := 0; trial < trials; trial++ {
+				path := randomTour(D, rng)
+				n := len(path)
+				i, j := rng.Intn(n), rng.Intn(n)
+
+				check("swap", path, i, j, deltaSwap(D, path, i, j), func(p []int) { applySwap(p, i, j) })
+				check("2-opt", path, i, j, deltaTwoOpt(D, path, i, j), func(p []int) { applyTwoOpt(p, i, j) })
+
+				if n < len(D) {
+					clear(inSel)
+					for _, v := range path {
+						inSel[v] = true
+					}
+					u := rng.Intn(len(D))
+					for inSel[u] {
+						u = rng.Intn(len(D))
+					}
+					check("exchange", path, i, u, deltaExchangeSelected(D, costs, path, i, u),
+						func(p []int) { applyExchangeSelected(p, i, u) })
+				}
+			}
+		})
+	}
+}
+
+// TestLocalSearchProperties runs every local search variant from random and
+// greedy starting solutions with VerifyMoves enabled and checks that the
+// result is a valid tour of selectCount nodes, that its objective is reported
+// correctly, that it is never worse than the start and that the starting
+// solution is left unmodified.
+func TestLocalSearchProperties(t *testing.T) {
+	prev := VerifyMoves
+	VerifyMoves = true
+	defer func() { VerifyMoves = prev }()
+
+	runs := 10
+	if testing.Short() {
+		runs = 2
+	}
+	rng := rand.New(rand.NewSource(1))
+	for _, inst := range propertyInstances(t, rng) {
+		D, costs := inst.D, inst.costs
+		k := selectCount(len(D))
+		for _, intra := range []struct {
+			name string
+			typ  IntraType
+		}{{"Swap", IntraSwap}, {"2-opt", Intra2Opt}} {
+			for _, variant := range []struct {
+				name string
+				run  func(init Solution[int]) Solution[int]
+			}{
+				{"Steepest_" + intra.name, func(init Solution[int]) Solution[int] {
+					return localSearchSteepest(D, costs, init, intra.typ)
+				}},
+				{"Greedy_" + intra.name, func(init Solution[int]) Solution[int] {
+					return localSearchGreedy(D, costs, init, intra.typ, rng)
+				}},
+			} {
+				t.Run(inst.name+"/"+variant.name, func(t *testing.T) {
+					for r := 0; r < runs; r++ {
+						init := startRandom(D, costs, rng)
+						if r%2 == 1 {
+							init = startGreedy(D, costs, rng.Intn(len(D)), k)
+						}
+						start := append([]int(nil), init.Path...)
+						sol := variant.run(init)
+						if err := validateTour(len(D), k, sol.Path); err != nil {
+							t.Fatalf("from %s: %v", describeTour(start), err)
+						}
+						if got := objective(D, costs, sol.Path); got != sol.Objective {
+							t.Fatalf("from %s: reported objective %d, recomputed %d", describeTour(start), sol.Objective, got)
+						}
+						if sol.Objective > init.Objective {
+							t.Fatalf("worsened %s from %d to %d", describeTour(start), init.Objective, sol.Objective)
+						}
+						for i := range start {
+							if init.Path[i] != start[i] {
+								t.Fatalf("modified the starting solution %s", describeTour(start))
+							}
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+// TestExactWeights runs every local search variant with unrounded float64
+// distances and VerifyMoves enabled, and checks that the result is a valid
+// tour that is not worse than the start.
+func TestExactWeights(t *testing.T) {
+	prev := VerifyMoves
+	VerifyMoves = true
+	defer func() { VerifyMoves = prev }()
+
+	rng := rand.New(rand.NewSource(1))
+	D, costs := exactInstance(40, rng)
+	k := selectCount(len(D))
+	for _, intra := range []IntraType{IntraSwap, Intra2Opt} {
+		for r := 0; r < 4; r++ {
+			init := startRandom(D, costs, rng)
+			if r%2 == 1 {
+				init = startGreedy(D, costs, rng.Intn(len(D)), k)
+			}
+			for _, sol := range []Solution[float64]{
+				localSearchSteepest(D, costs, init, intra),
+				localSearchGreedy(D, costs, init, intra, rng),
+			} {
+				if err := validateTour(len(D), k, sol.Path); err != nil {
+					t.Fatal(err)
+				}
+				if sol.Objective > init.Objective {
+					t.Fatalf("worsened from %v to %v", init.Objective, sol.Objective)
+				}
+			}
+		}
+	}
+}
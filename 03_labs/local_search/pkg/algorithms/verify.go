@@ -0,0 +1,69 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+)
+
+// VerifyMoves enables the delta verification mode: after every move applied
+// by a local search the objective is recomputed from scratch and compared with
+// the objective before the move plus the move's delta, and the tour is checked
+// to still be a valid selection of distinct nodes. Any mismatch panics with a
+// description of the move. The checks make every move O(n), so the mode is
+// meant for debugging and for the property checks only.
+var VerifyMoves bool
+
+// objectiveBeforeMove returns the objective of path when VerifyMoves is set,
+// so that checkMove can compare it with the objective after the move.
+func objectiveBeforeMove[W Weight](D [][]W, costs []W, path []int) W {
+	if !VerifyMoves {
+		return 0
+	}
+	return objective(D, costs, path)
+}
+
+// checkMove verifies an applied move when VerifyMoves is set. For intra-route
+// moves i and j are the move's positions; for exchanges path[i] was replaced
+// by node j.
+func checkMove[W Weight](D [][]W, costs []W, path []int, before, delta W, move string, i, j int) {
+	if !VerifyMoves {
+		return
+	}
+	after := objective(D, costs, path)
+	if !sameObjective(after, before+delta) {
+		panic(fmt.Sprintf("verify: %s(%d, %d) on tour of %d nodes: delta %v, but objective changed from %v to %v (by %v)",
+			move, i, j, len(path), delta, before, after, after-before))
+	}
+	if err := validateTour(len(D), len(path), path); err != nil {
+		panic(fmt.Sprintf("verify: %s(%d, %d) broke the tour: %v", move, i, j, err))
+	}
+}
+
+// sameObjective reports whether two objectives are equal: exactly for integer
+// weights, up to the floating-point noise of the summation otherwise.
+func sameObjective[W Weight](a, b W) bool {
+	half := 0.5
+	if W(half) == 0 {
+		return a == b
+	}
+	return math.Abs(float64(a-b)) <= 1e-9*max(1, math.Abs(float64(a)))
+}
+
+// validateTour checks that path visits exactly want distinct nodes of an
+// instance with dim nodes.
+func validateTour(dim, want int, path []int) error {
+	if len(path) != want {
+		return fmt.Errorf("tour has %d nodes, want %d", len(path), want)
+	}
+	seen := make([]bool, dim)
+	for i, v := range path {
+		if v < 0 || v >= dim {
+			return fmt.Errorf("node %d at position %d is out of range [0, %d)", v, i, dim)
+		}
+		if seen[v] {
+			return fmt.Errorf("node %d is visited more than once", v)
+		}
+		seen[v] = true
+	}
+	return nil
+}
@@ -1,5 +0,0 @@
-package data
-
-type Node struct {
-	X, Y, Cost int
-}
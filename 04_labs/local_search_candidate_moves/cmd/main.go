@@ -1,26 +1,25 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
 	"time"
 
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/04_labs/local_search_candidate_moves/pkg/algorithms"
-	"github.com/czajkowskis/evolutionary_computation/04_labs/local_search_candidate_moves/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/04_labs/local_search_candidate_moves/pkg/utils"
 	"github.com/czajkowskis/evolutionary_computation/04_labs/local_search_candidate_moves/pkg/visualisation"
 )
 
-func processInstance(instanceName string, nodes []data.Node) {
+func processInstance[W algorithms.Weight](instanceName string, nodes []data.Node, policy data.DistancePolicy) {
 	log.Printf("Processing instance %s with %d nodes", instanceName, len(nodes))
 	fmt.Printf("Instance %s Statistics:\n", instanceName)
 
-	D := data.CalculateDistanceMatrix(nodes)
-
-	costs := make([]int, len(nodes))
-	for i, node := range nodes {
-		costs[i] = node.Cost
+	D, costs, err := data.Weights[W](nodes, policy)
+	if err != nil {
+		log.Fatalf("Error computing distances: %v", err)
 	}
 
 	numSolutions := 200
@@ -52,7 +51,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 		},
 	}
 
-	var rows []utils.Row
+	var rows []utils.Row[W]
 
 	for _, m := range methods {
 		log.Printf("Starting method: %s for instance %s", m.Name, instanceName)
@@ -71,7 +70,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 
 		best := algorithms.FindBestSolution(solutions)
 
-		rows = append(rows, utils.Row{
+		rows = append(rows, utils.Row[W]{
 			Name:      m.Name,
 			AvgV:      avgVal,
 			MinV:      minVal,
@@ -81,7 +80,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 			BestValue: best.Objective,
 		})
 
-		log.Printf("Completed method %s: best value %d, avg time %.2f ms", m.Name, best.Objective, avgTimeMs)
+		log.Printf("Completed method %s: best value %v, avg time %.2f ms", m.Name, best.Objective, avgTimeMs)
 
 		// Wykres najlepszej trasy
 		title := fmt.Sprintf("Best %s Solution for Instance %s", m.Name, instanceName)
@@ -94,7 +93,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 	// 5) Wyniki — konsola
 	fmt.Println("Objective value: av (min, max)")
 	for _, r := range rows {
-		fmt.Printf("%-34s  %.2f (%d, %d)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
+		fmt.Printf("%-34s  %.2f (%v, %v)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
 		fmt.Printf("Best path: %v\n", r.BestPath)
 	}
 	fmt.Println()
@@ -113,6 +112,19 @@ func processInstance(instanceName string, nodes []data.Node) {
 }
 
 func main() {
+	rounding := flag.String("rounding", "round", "distance and cost rounding: round, ceil, floor or exact")
+	scale := flag.Float64("scale", 1, "fixed-point factor applied to distances and costs before rounding")
+	flag.Parse()
+
+	policy, err := data.ParseDistancePolicy(*rounding, *scale)
+	if err != nil {
+		log.Fatalf("Invalid distance policy: %v", err)
+	}
+	run := processInstance[int]
+	if policy.Rounding == data.RoundExact {
+		run = processInstance[float64]
+	}
+
 	rand.Seed(time.Now().UnixNano())
 	log.Println("Starting evolutionary computation local search program")
 
@@ -125,9 +137,9 @@ func main() {
 		log.Fatalf("Error reading TSPB.csv: %v", err)
 	}
 
-	processInstance("A", nodesA)
+	run("A", nodesA, policy)
 	fmt.Println()
-	processInstance("B", nodesB)
+	run("B", nodesB, policy)
 
 	log.Println("Program execution completed")
 }
@@ -0,0 +1,150 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randomInstance builds a random Euclidean instance with dim nodes placed on
+// a 1000x1000 grid, with rounded distances and node costs in [0, 500).
+func randomInstance(dim int, rng *rand.Rand) ([][]int, []int) {
+	xs := make([]int, dim)
+	ys := make([]int, dim)
+	costs := make([]int, dim)
+	for i := 0; i < dim; i++ {
+		xs[i], ys[i], costs[i] = rng.Intn(1000), rng.Intn(1000), rng.Intn(500)
+	}
+	D := make([][]int, dim)
+	for i := range D {
+		D[i] = make([]int, dim)
+		for j := range D[i] {
+			if i != j {
+				D[i][j] = int(math.Round(math.Hypot(float64(xs[i]-xs[j]), float64(ys[i]-ys[j]))))
+			}
+		}
+	}
+	return D, costs
+}
+
+// exactInstance builds a random Euclidean instance like randomInstance, but
+// with unrounded float64 distances.
+func exactInstance(dim int, rng *rand.Rand) ([][]float64, []float64) {
+	D := make([][]float64, dim)
+	costs := make([]float64, dim)
+	xs := make([]float64, dim)
+	ys := make([]float64, dim)
+	for i := 0; i < dim; i++ {
+		xs[i], ys[i], costs[i] = rng.Float64()*1000, rng.Float64()*1000, float64(rng.Intn(500))
+	}
+	for i := range D {
+		D[i] = make([]float64, dim)
+		for j := range D[i] {
+			D[i][j] = math.Hypot(xs[i]-xs[j], ys[i]-ys[j])
+		}
+	}
+	return D, costs
+}
+
+// randomTour selects between minLen and len(D) nodes at random and returns
+// them in random order.
+func randomTour(D [][]int, minLen int, rng *rand.Rand) []int {
+	dim := len(D)
+	k := minLen
+	if dim > minLen {
+		k += rng.Intn(dim - minLen + 1)
+	}
+	return rng.Perm(dim)[:k]
+}
+
+// randomUnselected returns a random vertex outside path, which has to leave
+// at least one vertex unselected.
+func randomUnselected(dim int, path []int, rng *rand.Rand) int {
+	inSel := make([]bool, dim)
+	for _, v := range path {
+		inSel[v] = true
+	}
+	u := rng.Intn(dim)
+	for inSel[u] {
+		u = rng.Intn(dim)
+	}
+	return u
+}
+
+// TestDeltaProperties checks the delta functions against a full recomputation
+// of the objective: for random tours and random move positions,
+// objective(after) - objective(before) has to equal the delta. Tours of every
+// length from 3 nodes are tried so that moves between adjacent positions and
+// around the end of the path are covered.
+func TestDeltaProperties(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, dim := range []int{3, 4, 5, 7, 10, 25, 100} {
+		t.Run(fmt.Sprintf("random-%d", dim), func(t *testing.T) {
+			D, costs := randomInstance(dim, rng)
+			check := func(move string, path []int, i, j, delta int, apply func([]int)) {
+				t.Helper()
+				moved := append([]int(nil), path...)
+				apply(moved)
+				if got := objective(D, costs, moved) - objective(D, costs, path); got != delta {
+					t.Fatalf("%s(%d, %d) on %v: delta %d, objective changed by %d", move, i, j, path, delta, got)
+				}
+			}
+
+			for trial := 0; trial < 2000; trial++ {
+				path := randomTour(D, 3, rng)
+				n := len(path)
+				i, j := rng.Intn(n), rng.Intn(n)
+				check("2-opt", path, i, j, deltaTwoOpt(D, path, i, j), func(p []int) { applyTwoOpt(p, i, j) })
+				check("2-opt with positions", path, i, j, deltaTwoOpt(D, path, i, j), func(p []int) {
+					posOf := make([]int, dim)
+					for k, v := range p {
+						posOf[v] = k
+					}
+					applyTwoOptAndUpdatePos(p, posOf, i, j)
+					for k, v := range p {
+						if posOf[v] != k {
+							t.Fatalf("2-opt(%d, %d) on %v: posOf[%d] = %d, vertex is at %d", i, j, path, v, posOf[v], k)
+						}
+					}
+				})
+
+				if n < dim {
+					u := randomUnselected(dim, path, rng)
+					check("exchange", path, i, u, deltaExchangeSelected(D, costs, path, i, u),
+						func(p []int) { applyExchangeSelected(p, i, u) })
+				}
+			}
+		})
+	}
+}
+
+// TestExactWeights runs the baseline and the candidate local search with
+// unrounded float64 distances and checks that they return a selection of
+// selectCount distinct nodes that is not worse than the start.
+func TestExactWeights(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	D, costs := exactInstance(60, rng)
+	cd := buildCandidates(D, costs, 10)
+	for r := 0; r < 5; r++ {
+		init := startRandom(D, costs, rng)
+		for _, sol := range []Solution[float64]{
+			localSearchSteepestBaseline(D, costs, init),
+			localSearchSteepestCandidates(D, costs, init, cd),
+		} {
+			if len(sol.Path) != selectCount(len(D)) {
+				t.Fatalf("%d nodes selected, want %d", len(sol.Path), selectCount(len(D)))
+			}
+			seen := make([]bool, len(D))
+			for _, v := range sol.Path {
+				if seen[v] {
+					t.Fatalf("node %d visited twice in %v", v, sol.Path)
+				}
+				seen[v] = true
+			}
+			if sol.Objective > init.Objective {
+				t.Fatalf("worsened from %v to %v", init.Objective, sol.Objective)
+			}
+		}
+	}
+}
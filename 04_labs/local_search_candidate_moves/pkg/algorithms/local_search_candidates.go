@@ -17,11 +17,11 @@ type MethodSpec struct {
 	CandK   int  // how many nearest to include in candidate list
 }
 
-func objective(D [][]int, costs []int, path []int) int {
+func objective[W Weight](D [][]W, costs []W, path []int) W {
 	if len(path) == 0 {
 		return math.MaxInt32 / 4
 	}
-	sum := 0
+	var sum W
 	n := len(path)
 	for i := 0; i < n; i++ {
 		a := path[i]
@@ -34,6 +34,14 @@ func objective(D [][]int, costs []int, path []int) int {
 	return sum
 }
 
+// improvementEps is the smallest objective decrease of an applied move, so
+// that floating-point noise cannot make a search with exact distances cycle.
+const improvementEps = 1e-9
+
+// improves reports whether a move with the given delta decreases the
+// objective by more than the floating-point noise of exact distances.
+func improves[W Weight](delta W) bool { return float64(delta) < -improvementEps }
+
 func prevIdx(i, n int) int {
 	if i == 0 {
 		return n - 1
@@ -44,7 +52,7 @@ func nextIdx(i, n int) int { return (i + 1) % n }
 
 func selectCount(n int) int { return (n + 1) / 2 }
 
-func startRandom(D [][]int, costs []int, rng *rand.Rand) Solution {
+func startRandom[W Weight](D [][]W, costs []W, rng *rand.Rand) Solution[W] {
 	n := len(D)
 	k := selectCount(n)
 	idx := make([]int, n)
@@ -54,11 +62,11 @@ func startRandom(D [][]int, costs []int, rng *rand.Rand) Solution {
 	rng.Shuffle(n, func(i, j int) { idx[i], idx[j] = idx[j], idx[i] })
 	path := append([]int(nil), idx[:k]...)
 	rng.Shuffle(k, func(i, j int) { path[i], path[j] = path[j], path[i] })
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
 // intra-route move - two edges exchange: 2-opt between path[i] and path[j]
-func deltaTwoOpt(D [][]int, path []int, i, j int) int {
+func deltaTwoOpt[W Weight](D [][]W, path []int, i, j int) W {
 	if i == j {
 		return 0
 	}
@@ -77,7 +85,7 @@ func deltaTwoOpt(D [][]int, path []int, i, j int) int {
 }
 
 // inter-route move - two-nodes exchange - path[i] with u (u outside the current path)
-func deltaExchangeSelected(D [][]int, costs []int, path []int, i int, u int) int {
+func deltaExchangeSelected[W Weight](D [][]W, costs []W, path []int, i int, u int) W {
 	n := len(path)
 	a := path[prevIdx(i, n)]
 	v := path[i]
@@ -132,8 +140,14 @@ func packEdge(a, b int) uint64 {
 	return uint64(uint32(a))<<32 | uint64(uint32(b))
 }
 
+// neighbour is a node v with its weight w as a candidate of another node
+type neighbour[W Weight] struct {
+	v int
+	w W
+}
+
 // Build K nearest per node by weight = D[u][v] + cost[u]
-func buildCandidates(D [][]int, costs []int, K int) CandData {
+func buildCandidates[W Weight](D [][]W, costs []W, K int) CandData {
 	n := len(D)
 	if K <= 0 {
 		K = 10
@@ -142,14 +156,13 @@ func buildCandidates(D [][]int, costs []int, K int) CandData {
 	isCand := make(map[uint64]struct{}, n*K*2)
 
 	for u := 0; u < n; u++ {
-		type nb struct{ v, w int }
-		nbs := make([]nb, 0, n-1)
+		nbs := make([]neighbour[W], 0, n-1)
 		for v := 0; v < n; v++ {
 			if v == u {
 				continue
 			}
 			w := D[u][v] + costs[v]
-			nbs = append(nbs, nb{v: v, w: w})
+			nbs = append(nbs, neighbour[W]{v: v, w: w})
 		}
 		sort.Slice(nbs, func(i, j int) bool { return nbs[i].w < nbs[j].w })
 		m := K
@@ -172,12 +185,12 @@ func isCandidateEdge(cd CandData, a, b int) bool {
 	return ok
 }
 
-func localSearchSteepestBaseline(D [][]int, costs []int, init Solution) Solution {
+func localSearchSteepestBaseline[W Weight](D [][]W, costs []W, init Solution[W]) Solution[W] {
 	path := append([]int(nil), init.Path...)
 	n := len(path)
 
 	for {
-		bestDelta := 0
+		var bestDelta W
 		var bestMove func()
 
 		// intra-route move - two-edges exchange: 2-opt
@@ -214,13 +227,13 @@ func localSearchSteepestBaseline(D [][]int, costs []int, init Solution) Solution
 			}
 		}
 
-		if bestDelta < 0 {
+		if improves(bestDelta) {
 			bestMove()
 		} else {
 			break
 		}
 	}
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
 // Steepest local search with candidate moves
@@ -230,7 +243,7 @@ func localSearchSteepestBaseline(D [][]int, costs []int, init Solution) Solution
 //     B) remove (prev(n1),n1) and (prev(n2),n2) -> add (n1,n2) +(prev(n1),prev(n2)) -> applyTwoOpt(prev(i), prev(j))
 //   - if n2 is not in the cycle -> consider inter at position i with u=n2 only if (prev(i),u) or (u,next(i)) is candidate
 
-func localSearchSteepestCandidates(D [][]int, costs []int, init Solution, cd CandData) Solution {
+func localSearchSteepestCandidates[W Weight](D [][]W, costs []W, init Solution[W], cd CandData) Solution[W] {
 	path := append([]int(nil), init.Path...)
 	n := len(path)
 
@@ -249,7 +262,7 @@ func localSearchSteepestCandidates(D [][]int, costs []int, init Solution, cd Can
 	epoch := 0
 
 	for {
-		bestDelta := 0
+		var bestDelta W
 		var bestMove func()
 
 		// intra
@@ -348,29 +361,29 @@ func localSearchSteepestCandidates(D [][]int, costs []int, init Solution, cd Can
 			}
 		}
 
-		if bestDelta < 0 {
+		if improves(bestDelta) {
 			bestMove()
 		} else {
 			break
 		}
 	}
 
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
 // Batch local search runner
 
-func RunLocalSearchBatch(
-	D [][]int,
-	costs []int,
+func RunLocalSearchBatch[W Weight](
+	D [][]W,
+	costs []W,
 	m MethodSpec,
 	numSolutions int,
-) []Solution {
+) []Solution[W] {
 	if numSolutions <= 0 {
 		return nil
 	}
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	results := make([]Solution, 0, numSolutions)
+	results := make([]Solution[W], 0, numSolutions)
 
 	var cd CandData
 	if m.UseCand {
@@ -383,7 +396,7 @@ func RunLocalSearchBatch(
 
 	for r := 0; r < numSolutions; r++ {
 		init := startRandom(D, costs, rng)
-		var sol Solution
+		var sol Solution[W]
 		if m.UseCand {
 			sol = localSearchSteepestCandidates(D, costs, init, cd)
 		} else {
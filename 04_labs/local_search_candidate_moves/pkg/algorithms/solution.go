@@ -1,7 +1,11 @@
 package algorithms
 
+// Weight is the type of distances and node costs: integers, rounded or
+// scaled by the distance policy, or exact float64 values.
+type Weight interface{ ~int | ~float64 }
+
 // Solution represents a solution to the problem, including the path and its objective value.
-type Solution struct {
+type Solution[W Weight] struct {
 	Path      []int
-	Objective int
+	Objective W
 }
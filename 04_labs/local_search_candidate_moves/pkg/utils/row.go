@@ -1,11 +1,13 @@
 package utils
 
-type Row struct {
+import "github.com/czajkowskis/evolutionary_computation/04_labs/local_search_candidate_moves/pkg/algorithms"
+
+type Row[W algorithms.Weight] struct {
 	Name      string
 	AvgV      float64
-	MinV      int
-	MaxV      int
+	MinV      W
+	MaxV      W
 	AvgTms    float64
 	BestPath  []int
-	BestValue int
+	BestValue W
 }
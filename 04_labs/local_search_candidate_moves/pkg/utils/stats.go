@@ -1,18 +1,16 @@
 package utils
 
 import (
-	"math"
-
 	"github.com/czajkowskis/evolutionary_computation/04_labs/local_search_candidate_moves/pkg/algorithms"
 )
 
-func CalculateStatistics(solutions []algorithms.Solution) (int, int, float64) {
+func CalculateStatistics[W algorithms.Weight](solutions []algorithms.Solution[W]) (W, W, float64) {
 	if len(solutions) == 0 {
 		return 0, 0, 0
 	}
-	minObj := math.MaxInt32
-	maxObj := 0
-	sum := 0
+	minObj := solutions[0].Objective
+	maxObj := solutions[0].Objective
+	var sum W
 	for _, sol := range solutions {
 		obj := sol.Objective
 		if obj < minObj {
@@ -0,0 +1,123 @@
+// Command aco runs the MAX-MIN Ant System experiments for both instances,
+// with and without local search, under a common time limit.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
+	"github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+	"github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/utils"
+	"github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/visualisation"
+)
+
+// Configuration constants
+const (
+	numACORuns = 20      // Number of ACO runs per configuration and instance
+	timeLimitA = 3276.57 // Average running time of MSLS for instance A [ms]
+	timeLimitB = 2342.11 // Average running time of MSLS for instance B [ms]
+)
+
+func processInstance[W algorithms.Weight](instanceName string, nodes []data.Node, policy data.DistancePolicy, timeLimit time.Duration, runs int) {
+	log.Printf("Processing instance %s with %d nodes", instanceName, len(nodes))
+	fmt.Printf("Instance %s Statistics (time limit %v):\n", instanceName, timeLimit)
+
+	D, costs, err := data.Weights[W](nodes, policy)
+	if err != nil {
+		log.Fatalf("Error computing distances: %v", err)
+	}
+
+	configs := []struct {
+		Name   string
+		Config algorithms.ACOConfig
+	}{
+		{"MMAS", algorithms.ACOConfig{NumAnts: 25}},
+		{"MMAS_Restart", algorithms.ACOConfig{NumAnts: 25, RestartAfter: 1000}},
+		{"MMAS_LS", algorithms.ACOConfig{NumAnts: 10, Rho: 0.2, LocalSearch: true}},
+	}
+
+	lb := algorithms.LagrangianLowerBound(D, costs, algorithms.LowerBoundConfig{Seed: time.Now().UnixNano()})
+
+	var rows []utils.Row[W]
+	for _, c := range configs {
+		solutions := make([]algorithms.Solution[W], 0, runs)
+		var total time.Duration
+		iterations := 0
+		for run := 0; run < runs; run++ {
+			cfg := c.Config
+			cfg.TimeLimit = timeLimit
+			cfg.Seed = time.Now().UnixNano()
+			res := algorithms.AntColonyOptimization(D, costs, cfg)
+			solutions = append(solutions, res.BestSolution)
+			total += res.Duration
+			iterations += res.Iterations
+		}
+
+		minV, maxV, avgV := utils.CalculateStatistics(solutions)
+		best := algorithms.FindBestSolution(solutions)
+		rows = append(rows, utils.Row[W]{
+			Name:       c.Name,
+			AvgV:       avgV,
+			MinV:       minV,
+			MaxV:       maxV,
+			AvgTms:     float64(total.Nanoseconds()) / float64(runs) / 1e6,
+			BestPath:   best.Path,
+			BestValue:  best.Objective,
+			LowerBound: lb.Bound,
+		})
+		log.Printf("Completed method %s: best value %v, avg iterations %.1f",
+			c.Name, best.Objective, float64(iterations)/float64(runs))
+
+		title := fmt.Sprintf("Best %s Solution for Instance %s", c.Name, instanceName)
+		fileName := utils.SanitizeFileName(fmt.Sprintf("Best_%s_Solution_%s", c.Name, instanceName))
+		if err := visualisation.PlotSolution(nodes, best.Path, title, fileName, 0, 4000, 0, 2000); err != nil {
+			log.Printf("plot error for %s/%s: %v", instanceName, c.Name, err)
+		}
+	}
+
+	fmt.Println("Objective value: av (min, max)")
+	for _, r := range rows {
+		fmt.Printf("%-14s  %.2f (%v, %v)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
+		fmt.Printf("Best path: %v\n", r.BestPath)
+	}
+	fmt.Printf("Gap to the lower bound %v [%%]: best, avg\n", lb.Bound)
+	for _, r := range rows {
+		fmt.Printf("%-14s  %.2f, %.2f\n", r.Name, utils.GapPercent(float64(r.BestValue), lb.Bound), utils.GapPercent(r.AvgV, lb.Bound))
+	}
+
+	if err := utils.WriteExperimentResultsCSV("aco", instanceName, rows); err != nil {
+		log.Printf("CSV write error for instance %s: %v", instanceName, err)
+	}
+}
+
+func main() {
+	runs := flag.Int("runs", numACORuns, "ACO runs per configuration and instance")
+	rounding := flag.String("rounding", "round", "distance and cost rounding: round, ceil, floor or exact")
+	scale := flag.Float64("scale", 1, "fixed-point factor applied to distances and costs before rounding")
+	flag.Parse()
+
+	policy, err := data.ParseDistancePolicy(*rounding, *scale)
+	if err != nil {
+		log.Fatalf("Invalid distance policy: %v", err)
+	}
+	run := processInstance[int]
+	if policy.Rounding == data.RoundExact {
+		run = processInstance[float64]
+	}
+
+	nodesA, err := data.ReadNodes("./instances/TSPA.csv")
+	if err != nil {
+		log.Fatalf("Error reading TSPA.csv: %v", err)
+	}
+	nodesB, err := data.ReadNodes("./instances/TSPB.csv")
+	if err != nil {
+		log.Fatalf("Error reading TSPB.csv: %v", err)
+	}
+
+	run("A", nodesA, policy, time.Duration(timeLimitA*float64(time.Millisecond)), *runs)
+	fmt.Println()
+	run("B", nodesB, policy, time.Duration(timeLimitB*float64(time.Millisecond)), *runs)
+}
@@ -0,0 +1,117 @@
+// Command exact solves small random instances to optimality and reports how
+// far the local search methods are from the optima. Held-Karp and branch and
+// bound are cross-checked on the instances small enough for both; the command
+// exits with a non-zero status if they disagree or if a heuristic reports a
+// solution better than the optimum.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+)
+
+func main() {
+	seed := flag.Int64("seed", 1, "random seed")
+	sizes := flag.String("sizes", "8,10,12,14,16,20,24", "comma-separated instance sizes")
+	runs := flag.Int("runs", 20, "local search runs per method and instance")
+	maxNodes := flag.Int("max-nodes", 0, "branch and bound node budget (0 = unlimited)")
+	flag.Parse()
+
+	var dims []int
+	for _, s := range strings.Split(*sizes, ",") {
+		dim, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || dim < 1 {
+			log.Fatalf("Invalid instance size %q", s)
+		}
+		dims = append(dims, dim)
+	}
+
+	methods := []algorithms.MethodSpec{
+		{Name: "Baseline"},
+		{Name: "LM", UseLM: true},
+		{Name: "Candidates_K5", UseCand: true, CandK: 5},
+		{Name: "CandLM_K5", UseCand: true, CandK: 5, UseLM: true},
+	}
+
+	rng := rand.New(rand.NewSource(*seed))
+	failed := 0
+	for _, dim := range dims {
+		D, costs, pts := randomInstance(dim, rng)
+		name := fmt.Sprintf("random-%d", dim)
+
+		res, err := algorithms.SolveExact(D, costs, algorithms.ExactConfig{MaxNodes: *maxNodes, Seed: *seed})
+		if err != nil {
+			log.Fatalf("Error solving %s: %v", name, err)
+		}
+		status := "optimal"
+		if !res.Optimal {
+			status = fmt.Sprintf("node budget exhausted, lower bound %d", res.LowerBound)
+		}
+		fmt.Printf("%s: %d (%s, %s, %d nodes, %v)\n",
+			name, res.BestSolution.Objective, res.Method, status, res.Nodes, res.Duration)
+
+		if res.Method == algorithms.ExactHeldKarp {
+			bb := algorithms.BranchAndBound(D, costs, algorithms.ExactConfig{Seed: *seed})
+			if bb.BestSolution.Objective != res.BestSolution.Objective {
+				fmt.Printf("  FAIL: branch and bound found %d, Held-Karp %d\n",
+					bb.BestSolution.Objective, res.BestSolution.Objective)
+				failed++
+			}
+		}
+
+		opt := float64(res.BestSolution.Objective)
+		fmt.Printf("  %-16s %10s %10s %10s %8s\n", "Method", "Best", "BestGap%", "AvgGap%", "Optimal")
+		for _, m := range methods {
+			solutions, _ := algorithms.RunLocalSearchBatch(D, costs, pts, m, *runs)
+			best := algorithms.FindBestSolution(solutions)
+			sum, hits := 0.0, 0
+			for _, sol := range solutions {
+				sum += float64(sol.Objective)
+				if sol.Objective == res.BestSolution.Objective {
+					hits++
+				}
+			}
+			avg := sum / float64(len(solutions))
+			fmt.Printf("  %-16s %10d %10.2f %10.2f %5d/%d\n", m.Name, best.Objective,
+				100*(float64(best.Objective)-opt)/opt, 100*(avg-opt)/opt, hits, len(solutions))
+			if res.Optimal && best.Objective < res.BestSolution.Objective {
+				fmt.Printf("  FAIL: %s found %d below the optimum\n", m.Name, best.Objective)
+				failed++
+			}
+		}
+	}
+	if failed > 0 {
+		fmt.Printf("%d checks failed\n", failed)
+		os.Exit(1)
+	}
+	fmt.Println("all heuristics are consistent with the optima")
+}
+
+// randomInstance builds a random Euclidean instance with dim nodes placed on
+// a 1000x1000 grid, with rounded distances and node costs in [0, 500).
+func randomInstance(dim int, rng *rand.Rand) ([][]int, []int, []algorithms.Point) {
+	pts := make([]algorithms.Point, dim)
+	costs := make([]int, dim)
+	for i := 0; i < dim; i++ {
+		pts[i] = algorithms.Point{X: float64(rng.Intn(1000)), Y: float64(rng.Intn(1000))}
+		costs[i] = rng.Intn(500)
+	}
+	D := make([][]int, dim)
+	for i := range D {
+		D[i] = make([]int, dim)
+		for j := range D[i] {
+			if i != j {
+				D[i][j] = int(math.Round(math.Hypot(pts[i].X-pts[j].X, pts[i].Y-pts[j].Y)))
+			}
+		}
+	}
+	return D, costs, pts
+}
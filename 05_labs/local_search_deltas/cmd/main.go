@@ -2,31 +2,41 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
 	"time"
 
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
-	"github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/utils"
 	"github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/visualisation"
 )
 
 // processInstance runs the full experimental pipeline for a single instance:
 // build distance matrix, run all configured methods, print stats, plot best
-// solutions and persist CSV summaries.
-func processInstance(instanceName string, nodes []data.Node) {
+// solutions and persist CSV summaries. Distances and costs are computed with
+// the given policy as W values.
+func processInstance[W algorithms.Weight](instanceName string, nodes []data.Node, policy data.DistancePolicy) {
 	log.Printf("Processing instance %s with %d nodes", instanceName, len(nodes))
 	fmt.Printf("Instance %s Statistics:\n", instanceName)
 
-	D := data.CalculateDistanceMatrix(nodes)
+	D, costs, err := data.Weights[W](nodes, policy)
+	if err != nil {
+		log.Fatalf("Error computing distances: %v", err)
+	}
 
-	costs := make([]int, len(nodes))
+	pts := make([]algorithms.Point, len(nodes))
 	for i, node := range nodes {
-		costs[i] = node.Cost
+		pts[i] = algorithms.Point{X: node.X, Y: node.Y}
 	}
 
+	reportCandidateCoverage(instanceName, D, costs, pts)
+
+	lb := algorithms.LagrangianLowerBound(D, costs, algorithms.LowerBoundConfig{Seed: time.Now().UnixNano()})
+	log.Printf("Lagrangian lower bound for instance %s: %v (%d iterations, %v)", instanceName, lb.Bound, lb.Iterations, lb.Duration)
+
 	numSolutions := 200
 
 	methods := []algorithms.MethodSpec{
@@ -65,15 +75,60 @@ func processInstance(instanceName string, nodes []data.Node) {
 			CandK:   15,
 			UseLM:   false,
 		},
+		// CANDIDATE MOVES - alternative candidate list strategies, K = 10
+		{
+			Name:         "Candidates_Quadrant_Steepest_2opt_Random_K10",
+			UseCand:      true,
+			CandK:        10,
+			CandStrategy: algorithms.CandQuadrant,
+		},
+		{
+			Name:         "Candidates_Delaunay_Steepest_2opt_Random",
+			UseCand:      true,
+			CandStrategy: algorithms.CandDelaunay,
+		},
+		{
+			Name:         "Candidates_Alpha_Steepest_2opt_Random_K10",
+			UseCand:      true,
+			CandK:        10,
+			CandStrategy: algorithms.CandAlpha,
+		},
+		{
+			Name:         "Candidates_Hybrid_Steepest_2opt_Random_K10",
+			UseCand:      true,
+			CandK:        10,
+			CandStrategy: algorithms.CandHybrid,
+		},
+		// CANDIDATE MOVES + LM - K = 5
+		{
+			Name:    "CandLM_Steepest_2opt_Random_K5",
+			UseCand: true,
+			CandK:   5,
+			UseLM:   true,
+		},
+		// CANDIDATE MOVES + LM - K = 10
+		{
+			Name:    "CandLM_Steepest_2opt_Random_K10",
+			UseCand: true,
+			CandK:   10,
+			UseLM:   true,
+		},
+		// CANDIDATE MOVES + LM - K = 15
+		{
+			Name:    "CandLM_Steepest_2opt_Random_K15",
+			UseCand: true,
+			CandK:   15,
+			UseLM:   true,
+		},
 	}
 
-	var rows []utils.Row
+	var rows []utils.Row[W]
 
 	for _, m := range methods {
 		log.Printf("Starting method: %s for instance %s", m.Name, instanceName)
 		start := time.Now()
 
-		solutions, durations := algorithms.RunLocalSearchBatch(D, costs, m, numSolutions)
+		solutions, durations := algorithms.RunLocalSearchBatch(D, costs, pts, m, numSolutions)
 		batchTime := time.Since(start)
 
 		if len(solutions) == 0 {
@@ -105,21 +160,22 @@ func processInstance(instanceName string, nodes []data.Node) {
 
 		best := algorithms.FindBestSolution(solutions)
 
-		rows = append(rows, utils.Row{
-			Name:      m.Name,
-			AvgV:      avgVal,
-			MinV:      minVal,
-			MaxV:      maxVal,
-			AvgTms:    avgTimeMs,
-			BestPath:  best.Path,
-			BestValue: best.Objective,
+		rows = append(rows, utils.Row[W]{
+			Name:       m.Name,
+			AvgV:       avgVal,
+			MinV:       minVal,
+			MaxV:       maxVal,
+			AvgTms:     avgTimeMs,
+			BestPath:   best.Path,
+			BestValue:  best.Objective,
+			LowerBound: lb.Bound,
 		})
 
 		if m.UseLM && len(durations) > 0 {
-			log.Printf("Completed method %s: best value %d, avg time %.2f ms (min: %.2f, max: %.2f)",
+			log.Printf("Completed method %s: best value %v, avg time %.2f ms (min: %.2f, max: %.2f)",
 				m.Name, best.Objective, avgTimeMs, minTimeMs, maxTimeMs)
 		} else {
-			log.Printf("Completed method %s: best value %d, avg time %.2f ms",
+			log.Printf("Completed method %s: best value %v, avg time %.2f ms",
 				m.Name, best.Objective, avgTimeMs)
 		}
 
@@ -134,7 +190,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 	// 5) Wyniki — konsola
 	fmt.Println("Objective value: av (min, max)")
 	for _, r := range rows {
-		fmt.Printf("%-34s  %.2f (%d, %d)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
+		fmt.Printf("%-34s  %.2f (%v, %v)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
 		fmt.Printf("Best path: %v\n", r.BestPath)
 	}
 	fmt.Println()
@@ -143,6 +199,12 @@ func processInstance(instanceName string, nodes []data.Node) {
 	for _, r := range rows {
 		fmt.Printf("%-34s  %.4f\n", r.Name, r.AvgTms)
 	}
+	fmt.Println()
+
+	fmt.Printf("Gap to the lower bound %v [%%]: best, avg\n", lb.Bound)
+	for _, r := range rows {
+		fmt.Printf("%-34s  %.2f, %.2f\n", r.Name, utils.GapPercent(float64(r.BestValue), lb.Bound), utils.GapPercent(r.AvgV, lb.Bound))
+	}
 
 	// 6) CSV
 	if err := utils.WriteResultsCSV(instanceName, rows); err != nil {
@@ -152,9 +214,51 @@ func processInstance(instanceName string, nodes []data.Node) {
 	}
 }
 
+// reportCandidateCoverage prints, for every candidate list strategy and a
+// range of K, the fraction of edges of the best known solution that are
+// candidate edges, together with the average candidate list length.
+func reportCandidateCoverage[W algorithms.Weight](instanceName string, D [][]W, costs []W, pts []algorithms.Point) {
+	bestKnown, ok := bestKnownPaths[instanceName]
+	if !ok {
+		return
+	}
+	strategies := []algorithms.CandStrategy{
+		algorithms.CandNearest,
+		algorithms.CandQuadrant,
+		algorithms.CandDelaunay,
+		algorithms.CandAlpha,
+		algorithms.CandHybrid,
+	}
+
+	fmt.Println("Best known solution edges covered by candidate lists: coverage (avg list size)")
+	for _, strategy := range strategies {
+		fmt.Printf("%-10s", strategy)
+		for _, K := range []int{5, 10, 15, 20} {
+			cd := algorithms.BuildCandidateData(strategy, D, costs, pts, K)
+			fmt.Printf("  K=%-2d %6.2f%% (%5.2f)", K, 100*algorithms.CandidateCoverage(cd, bestKnown), cd.AvgListSize())
+		}
+		fmt.Println()
+	}
+	fmt.Println()
+}
+
 // main seeds RNG and runs the local search experiments for both provided
 // instances A and B.
 func main() {
+	flag.BoolVar(&algorithms.VerifyMoves, "verify", false, "recompute the objective after every local search move and panic on a delta mismatch")
+	rounding := flag.String("rounding", "round", "distance and cost rounding: round, ceil, floor or exact")
+	scale := flag.Float64("scale", 1, "fixed-point factor applied to distances and costs before rounding")
+	flag.Parse()
+
+	policy, err := data.ParseDistancePolicy(*rounding, *scale)
+	if err != nil {
+		log.Fatalf("Invalid distance policy: %v", err)
+	}
+	run := processInstance[int]
+	if policy.Rounding == data.RoundExact {
+		run = processInstance[float64]
+	}
+
 	rand.Seed(time.Now().UnixNano())
 	log.Println("Starting evolutionary computation local search program")
 
@@ -167,9 +271,9 @@ func main() {
 		log.Fatalf("Error reading TSPB.csv: %v", err)
 	}
 
-	processInstance("A", nodesA)
+	run("A", nodesA, policy)
 	fmt.Println()
-	processInstance("B", nodesB)
+	run("B", nodesB, policy)
 
 	log.Println("Program execution completed")
 }
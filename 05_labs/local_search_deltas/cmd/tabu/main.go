@@ -0,0 +1,93 @@
+// Command tabu runs the tabu search experiments for both instances with
+// different tenure policies and neighborhoods under a common time limit.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
+	"github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+	"github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/utils"
+)
+
+// Configuration constants
+const (
+	numTabuRuns = 20      // Number of tabu search runs per configuration and instance
+	timeLimitA  = 3276.57 // Average running time of MSLS for instance A [ms]
+	timeLimitB  = 2342.11 // Average running time of MSLS for instance B [ms]
+)
+
+func processInstance[W algorithms.Weight](instanceName string, nodes []data.Node, policy data.DistancePolicy, timeLimit time.Duration, runs int) {
+	log.Printf("Processing instance %s with %d nodes", instanceName, len(nodes))
+	fmt.Printf("Instance %s Statistics (time limit %v):\n", instanceName, timeLimit)
+
+	D, costs, err := data.Weights[W](nodes, policy)
+	if err != nil {
+		log.Fatalf("Error computing distances: %v", err)
+	}
+	pts := make([]algorithms.Point, len(nodes))
+	for i, node := range nodes {
+		pts[i] = algorithms.Point{X: node.X, Y: node.Y}
+	}
+
+	configs := []struct {
+		Name   string
+		Config algorithms.TabuConfig
+	}{
+		{"Tabu_Fixed", algorithms.TabuConfig{Tenure: algorithms.TenureFixed, TenureMin: 15}},
+		{"Tabu_Random", algorithms.TabuConfig{Tenure: algorithms.TenureRandom, TenureMin: 10, TenureMax: 30}},
+		{"Tabu_Reactive", algorithms.TabuConfig{Tenure: algorithms.TenureReactive, TenureMin: 10, TenureMax: 60}},
+		{"Tabu_Reactive_Cand_K10", algorithms.TabuConfig{Tenure: algorithms.TenureReactive, TenureMin: 10, TenureMax: 60, UseCand: true, CandK: 10}},
+	}
+
+	fmt.Println("Objective value: av (min, max), avg iterations, avg evaluations")
+	for _, c := range configs {
+		solutions := make([]algorithms.Solution[W], 0, runs)
+		iterations, evaluations := 0, 0
+		for run := 0; run < runs; run++ {
+			cfg := c.Config
+			cfg.TimeLimit = timeLimit
+			cfg.Seed = time.Now().UnixNano()
+			res := algorithms.TabuSearch(D, costs, pts, cfg)
+			solutions = append(solutions, res.BestSolution)
+			iterations += res.Iterations
+			evaluations += res.Evaluations
+		}
+		minV, maxV, avgV := utils.CalculateStatistics(solutions)
+		fmt.Printf("%-24s  %.2f (%v, %v)  %.1f  %.0f\n", c.Name, avgV, minV, maxV,
+			float64(iterations)/float64(runs), float64(evaluations)/float64(runs))
+		fmt.Printf("Best path: %v\n", algorithms.FindBestSolution(solutions).Path)
+	}
+}
+
+func main() {
+	runs := flag.Int("runs", numTabuRuns, "tabu search runs per configuration and instance")
+	rounding := flag.String("rounding", "round", "distance and cost rounding: round, ceil, floor or exact")
+	scale := flag.Float64("scale", 1, "fixed-point factor applied to distances and costs before rounding")
+	flag.Parse()
+
+	policy, err := data.ParseDistancePolicy(*rounding, *scale)
+	if err != nil {
+		log.Fatalf("Invalid distance policy: %v", err)
+	}
+	run := processInstance[int]
+	if policy.Rounding == data.RoundExact {
+		run = processInstance[float64]
+	}
+
+	nodesA, err := data.ReadNodes("./instances/TSPA.csv")
+	if err != nil {
+		log.Fatalf("Error reading TSPA.csv: %v", err)
+	}
+	nodesB, err := data.ReadNodes("./instances/TSPB.csv")
+	if err != nil {
+		log.Fatalf("Error reading TSPB.csv: %v", err)
+	}
+
+	run("A", nodesA, policy, time.Duration(timeLimitA*float64(time.Millisecond)), *runs)
+	fmt.Println()
+	run("B", nodesB, policy, time.Duration(timeLimitB*float64(time.Millisecond)), *runs)
+}
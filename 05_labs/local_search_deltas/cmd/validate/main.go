@@ -0,0 +1,107 @@
+// Command validate checks solutions against the lab instances. Without
+// arguments it validates the best_path of every row of the results CSVs in
+// output/results; CSV files can also be given as arguments. With -tour it
+// validates a single tour of the instance selected by -instance. The command
+// exits with a non-zero status if any solution is invalid. Solutions computed
+// with -rounding or -scale have to be validated with the same flags.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
+	"github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/validator"
+)
+
+func main() {
+	pathA := flag.String("a", "./instances/TSPA.csv", "instance A file")
+	pathB := flag.String("b", "./instances/TSPB.csv", "instance B file")
+	instanceName := flag.String("instance", "A", "instance of the tour given by -tour")
+	tour := flag.String("tour", "", "validate a single tour, e.g. \"[1 4 7]\"")
+	claimed := flag.Int("objective", -1, "claimed objective of the tour given by -tour (-1 = not checked)")
+	rounding := flag.String("rounding", "round", "distance and cost rounding of the solutions: round, ceil or floor")
+	scale := flag.Float64("scale", 1, "fixed-point factor applied to distances and costs before rounding")
+	flag.Parse()
+
+	policy, err := data.ParseDistancePolicy(*rounding, *scale)
+	if err != nil {
+		log.Fatalf("Invalid distance policy: %v", err)
+	}
+
+	instances := make(map[string]validator.Instance)
+	for name, path := range map[string]string{"A": *pathA, "B": *pathB} {
+		nodes, err := data.ReadNodes(path)
+		if err != nil {
+			log.Fatalf("Error reading %s: %v", path, err)
+		}
+		D, err := data.CalculateDistanceMatrixWithPolicy(nodes, policy)
+		if err != nil {
+			log.Fatalf("Error computing distances: %v", err)
+		}
+		costs, err := data.NodeCostsWithPolicy(nodes, policy)
+		if err != nil {
+			log.Fatalf("Error computing node costs: %v", err)
+		}
+		instances[name] = validator.Instance{D: D, Costs: costs}
+	}
+
+	if *tour != "" {
+		inst, ok := instances[*instanceName]
+		if !ok {
+			log.Fatalf("Unknown instance %q", *instanceName)
+		}
+		path, err := validator.ParsePath(*tour)
+		if err != nil {
+			log.Fatalf("Invalid tour: %v", err)
+		}
+		res := validator.Validate(inst, path, *claimed)
+		if !res.Valid() {
+			for _, p := range res.Problems {
+				fmt.Printf("INVALID: %s\n", p)
+			}
+			os.Exit(1)
+		}
+		fmt.Printf("valid, objective %d\n", res.Objective)
+		return
+	}
+
+	files := flag.Args()
+	if len(files) == 0 {
+		files, err = filepath.Glob("output/results/*.csv")
+		if err != nil {
+			log.Fatalf("Error listing results: %v", err)
+		}
+		if len(files) == 0 {
+			log.Fatalf("No results CSVs found in output/results")
+		}
+	}
+
+	checked, invalid := 0, 0
+	for _, file := range files {
+		rows, err := validator.ValidateResultsCSV(file, instances)
+		if err != nil {
+			fmt.Printf("%s: FAIL: %v\n", file, err)
+			invalid++
+			continue
+		}
+		for _, row := range rows {
+			checked++
+			if row.Valid() {
+				continue
+			}
+			invalid++
+			for _, p := range row.Problems {
+				fmt.Printf("%s:%d %s/%s: %s\n", file, row.Line, row.Instance, row.Method, p)
+			}
+		}
+	}
+	if invalid > 0 {
+		fmt.Printf("%d of %d solutions invalid\n", invalid, checked)
+		os.Exit(1)
+	}
+	fmt.Printf("all %d solutions valid\n", checked)
+}
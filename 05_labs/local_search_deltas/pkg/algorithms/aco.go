@@ -0,0 +1,292 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// ACOConfig holds configuration for the MAX-MIN Ant System.
+type ACOConfig struct {
+	TimeLimit      time.Duration // Time limit for the algorithm
+	MaxIterations  int           // Maximum number of colony iterations (0 = unlimited)
+	NumAnts        int           // Ants per iteration (default 20)
+	Alpha          float64       // Pheromone exponent (default 1)
+	Beta           float64       // Heuristic exponent (default 3)
+	Rho            float64       // Pheromone evaporation rate (default 0.02)
+	PBest          float64       // Probability of constructing the best tour at convergence, sets tau_min (default 0.05)
+	CandK          int           // Candidate list size used by the construction (default 15)
+	LocalSearch    bool          // Improve every ant's tour with steepest local search over candidate moves
+	BestSoFarEvery int           // Deposit with the best-so-far instead of the iteration-best ant every n-th iteration (default 5)
+	RestartAfter   int           // Reinitialise pheromone after this many iterations without a new best (0 = never)
+	Seed           int64
+}
+
+// ACOResult contains the result of the MAX-MIN Ant System execution.
+type ACOResult[W Weight] struct {
+	BestSolution Solution[W]
+	Iterations   int // number of colony iterations
+	Ants         int // number of constructed tours
+	Restarts     int // number of pheromone reinitialisations
+	Duration     time.Duration
+}
+
+// acoState holds the pheromone matrix and the combined attractiveness
+// tau[i][j]^alpha * eta[i][j]^beta, where eta[i][j] = 1 / (1 + D[i][j] +
+// costs[j]), so that an ant at i prefers short edges to cheap nodes.
+type acoState struct {
+	tau        [][]float64
+	eta        [][]float64 // eta^beta, fixed for the whole run
+	choice     [][]float64
+	tauMin     float64
+	tauMax     float64
+	alpha      float64
+	visited    []bool
+	candWeight []float64 // scratch buffer for the roulette over a candidate list
+}
+
+func newACOState[W Weight](D [][]W, costs []W, alpha, beta float64) *acoState {
+	dim := len(D)
+	st := &acoState{
+		tau:     make([][]float64, dim),
+		eta:     make([][]float64, dim),
+		choice:  make([][]float64, dim),
+		alpha:   alpha,
+		visited: make([]bool, dim),
+	}
+	for i := 0; i < dim; i++ {
+		st.tau[i] = make([]float64, dim)
+		st.eta[i] = make([]float64, dim)
+		st.choice[i] = make([]float64, dim)
+		for j := 0; j < dim; j++ {
+			if i != j {
+				st.eta[i][j] = math.Pow(1/float64(1+D[i][j]+costs[j]), beta)
+			}
+		}
+	}
+	return st
+}
+
+// setBounds recomputes the MMAS pheromone limits from the best objective:
+// tau_max = 1 / (rho * best) and tau_min chosen so that, once every choice
+// is at a limit, the best tour is constructed with probability pBest.
+func (st *acoState) setBounds(best, rho, pBest float64, k int) {
+	st.tauMax = 1 / (rho * best)
+	pDec := math.Pow(pBest, 1/float64(k))
+	avg := float64(k) / 2
+	st.tauMin = st.tauMax * (1 - pDec) / ((avg - 1) * pDec)
+	if st.tauMin > st.tauMax {
+		st.tauMin = st.tauMax
+	}
+}
+
+// reset sets every pheromone trail to tau_max.
+func (st *acoState) reset() {
+	for i := range st.tau {
+		for j := range st.tau[i] {
+			st.tau[i][j] = st.tauMax
+		}
+	}
+	st.updateChoice()
+}
+
+func (st *acoState) updateChoice() {
+	for i := range st.tau {
+		for j := range st.tau[i] {
+			st.choice[i][j] = math.Pow(st.tau[i][j], st.alpha) * st.eta[i][j]
+		}
+	}
+}
+
+// update evaporates all trails, deposits 1/objective on the edges of path
+// (in both directions) and clamps the trails to [tau_min, tau_max].
+func (st *acoState) update(path []int, obj, rho float64) {
+	for i := range st.tau {
+		for j := range st.tau[i] {
+			st.tau[i][j] *= 1 - rho
+		}
+	}
+	deposit := 1 / obj
+	n := len(path)
+	for i, a := range path {
+		b := path[nextIdx(i, n)]
+		st.tau[a][b] += deposit
+		st.tau[b][a] += deposit
+	}
+	for i := range st.tau {
+		for j := range st.tau[i] {
+			st.tau[i][j] = min(st.tauMax, max(st.tauMin, st.tau[i][j]))
+		}
+	}
+	st.updateChoice()
+}
+
+// construct lets one ant build a tour of k nodes into path. From the current
+// node the next one is drawn from the unvisited candidate neighbours with
+// probability proportional to choice; when all of them are visited the ant
+// moves to the unvisited node with the highest choice value.
+func (st *acoState) construct(path []int, k int, cd CandData, rng *rand.Rand) []int {
+	dim := len(st.visited)
+	for i := range st.visited {
+		st.visited[i] = false
+	}
+	cur := rng.Intn(dim)
+	path = append(path[:0], cur)
+	st.visited[cur] = true
+
+	for len(path) < k {
+		row := st.choice[cur]
+		list := cd.CandList[cur]
+		weights := st.candWeight[:0]
+		total := 0.0
+		for _, v := range list {
+			w := 0.0
+			if !st.visited[v] {
+				w = row[v]
+			}
+			weights = append(weights, w)
+			total += w
+		}
+		st.candWeight = weights
+
+		next := -1
+		if total > 0 {
+			r := rng.Float64() * total
+			for idx, w := range weights {
+				if w == 0 {
+					continue
+				}
+				next = list[idx]
+				if r < w {
+					break
+				}
+				r -= w
+			}
+		} else {
+			bestW := -1.0
+			for v := 0; v < dim; v++ {
+				if !st.visited[v] && row[v] > bestW {
+					bestW, next = row[v], v
+				}
+			}
+		}
+
+		path = append(path, next)
+		st.visited[next] = true
+		cur = next
+	}
+	return path
+}
+
+// AntColonyOptimization runs the MAX-MIN Ant System. In every iteration each
+// ant builds a cycle of selectCount nodes guided by the pheromone on edges
+// and by the heuristic 1 / (1 + D[i][j] + costs[j]), restricted to the
+// candidate lists of buildCandidates. Tours are optionally improved by
+// steepest local search with candidate moves. Only one ant deposits
+// pheromone per iteration (the iteration-best, or the best-so-far every
+// BestSoFarEvery iterations) and the trails are kept within the MMAS limits
+// [tau_min, tau_max], which are recomputed whenever a new best is found.
+func AntColonyOptimization[W Weight](D [][]W, costs []W, config ACOConfig) ACOResult[W] {
+	startTime := time.Now()
+	rng := rand.New(rand.NewSource(config.Seed))
+
+	if config.TimeLimit == 0 && config.MaxIterations == 0 {
+		config.TimeLimit = time.Second
+	}
+	if config.NumAnts <= 0 {
+		config.NumAnts = 20
+	}
+	if config.Alpha <= 0 {
+		config.Alpha = 1
+	}
+	if config.Beta <= 0 {
+		config.Beta = 3
+	}
+	if config.Rho <= 0 || config.Rho >= 1 {
+		config.Rho = 0.02
+	}
+	if config.PBest <= 0 || config.PBest >= 1 {
+		config.PBest = 0.05
+	}
+	if config.CandK <= 0 {
+		config.CandK = 15
+	}
+	if config.BestSoFarEvery <= 0 {
+		config.BestSoFarEvery = 5
+	}
+
+	dim := len(D)
+	k := selectCount(dim)
+	if dim < 3 {
+		return ACOResult[W]{BestSolution: startRandom(D, costs, rng), Duration: time.Since(startTime)}
+	}
+
+	cd := buildCandidates(D, costs, config.CandK)
+	ws := newLSWorkspace[W](dim)
+	st := newACOState(D, costs, config.Alpha, config.Beta)
+
+	// The initial limits come from a greedy-like tour built on the heuristic
+	// alone (all trails equal), so that tau_max has the right magnitude.
+	st.tauMax = 1
+	st.reset()
+	path := st.construct(nil, k, cd, rng)
+	if config.LocalSearch {
+		ws.steepestCandidates(D, costs, path, cd)
+	}
+	best := Solution[W]{Path: append([]int(nil), path...), Objective: objective(D, costs, path)}
+	st.setBounds(float64(best.Objective), config.Rho, config.PBest, k)
+	st.reset()
+
+	iterBest := Solution[W]{Path: make([]int, 0, k)}
+	iterations, ants, restarts, sinceBest := 0, 1, 0, 0
+
+	for {
+		if config.TimeLimit > 0 && time.Since(startTime) >= config.TimeLimit {
+			break
+		}
+		if config.MaxIterations > 0 && iterations >= config.MaxIterations {
+			break
+		}
+
+		iterBest.Objective = MaxWeight[W]()
+		for a := 0; a < config.NumAnts; a++ {
+			path = st.construct(path, k, cd, rng)
+			if config.LocalSearch {
+				ws.steepestCandidates(D, costs, path, cd)
+			}
+			ants++
+			if obj := objective(D, costs, path); obj < iterBest.Objective {
+				iterBest = Solution[W]{Path: append(iterBest.Path[:0], path...), Objective: obj}
+			}
+		}
+		iterations++
+
+		sinceBest++
+		if iterBest.Objective < best.Objective {
+			best = Solution[W]{Path: append(best.Path[:0], iterBest.Path...), Objective: iterBest.Objective}
+			st.setBounds(float64(best.Objective), config.Rho, config.PBest, k)
+			sinceBest = 0
+		}
+
+		if config.RestartAfter > 0 && sinceBest >= config.RestartAfter {
+			st.reset()
+			restarts++
+			sinceBest = 0
+			continue
+		}
+
+		if iterations%config.BestSoFarEvery == 0 {
+			st.update(best.Path, float64(best.Objective), config.Rho)
+		} else {
+			st.update(iterBest.Path, float64(iterBest.Objective), config.Rho)
+		}
+	}
+
+	return ACOResult[W]{
+		BestSolution: best,
+		Iterations:   iterations,
+		Ants:         ants,
+		Restarts:     restarts,
+		Duration:     time.Since(startTime),
+	}
+}
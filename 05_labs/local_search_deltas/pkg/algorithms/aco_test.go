@@ -0,0 +1,102 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestAntColonyOptimization checks that every variant stops after
+// MaxIterations and returns a cycle of selectCount distinct nodes with a
+// correct objective.
+func TestAntColonyOptimization(t *testing.T) {
+	inst := randomInstance(60, rand.New(rand.NewSource(1)))
+	D, costs := inst.D, inst.costs
+	for _, config := range []ACOConfig{
+		{MaxIterations: 10, NumAnts: 5},
+		{MaxIterations: 10, NumAnts: 5, LocalSearch: true},
+		{MaxIterations: 10, NumAnts: 5, RestartAfter: 2},
+	} {
+		t.Run(fmt.Sprintf("ls=%v/restart=%d", config.LocalSearch, config.RestartAfter), func(t *testing.T) {
+			config.Seed = 1
+			res := AntColonyOptimization(D, costs, config)
+			if res.Iterations != config.MaxIterations {
+				t.Errorf("%d iterations, want %d", res.Iterations, config.MaxIterations)
+			}
+			path := res.BestSolution.Path
+			if len(path) != selectCount(len(D)) {
+				t.Fatalf("%d nodes selected, want %d", len(path), selectCount(len(D)))
+			}
+			seen := make([]bool, len(D))
+			for _, v := range path {
+				if v < 0 || v >= len(D) || seen[v] {
+					t.Fatalf("invalid or repeated node %d in %v", v, path)
+				}
+				seen[v] = true
+			}
+			if got := objective(D, costs, path); res.BestSolution.Objective != got {
+				t.Fatalf("objective %d, recomputed %d", res.BestSolution.Objective, got)
+			}
+		})
+	}
+}
+
+// TestACOPheromoneBounds checks the MMAS limits: tau_max = 1 / (rho * best),
+// tau_min <= tau_max, a reset puts every trail at tau_max, and every update
+// keeps the trails within the limits, so that an edge never reinforced decays
+// to tau_min and a reinforced one stays at tau_max.
+func TestACOPheromoneBounds(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	inst := randomInstance(30, rng)
+	D, costs := inst.D, inst.costs
+	const rho, pBest, best = 0.1, 0.05, 5000
+	k := selectCount(len(D))
+
+	st := newACOState(D, costs, 1, 3)
+	st.setBounds(best, rho, pBest, k)
+	if want := 1 / (rho * best); math.Abs(st.tauMax-want) > 1e-15 {
+		t.Fatalf("tau_max %v, want %v", st.tauMax, want)
+	}
+	if st.tauMin <= 0 || st.tauMin > st.tauMax {
+		t.Fatalf("tau_min %v outside (0, tau_max = %v]", st.tauMin, st.tauMax)
+	}
+
+	st.reset()
+	for i := range st.tau {
+		for j := range st.tau[i] {
+			if st.tau[i][j] != st.tauMax {
+				t.Fatalf("tau[%d][%d] = %v after reset, want tau_max", i, j, st.tau[i][j])
+			}
+		}
+	}
+
+	// the same tour is reinforced every time; the other trails evaporate
+	path := rng.Perm(len(D))[:k]
+	for it := 0; it < 500; it++ {
+		st.update(path, best, rho)
+		for i := range st.tau {
+			for j := range st.tau[i] {
+				if st.tau[i][j] < st.tauMin || st.tau[i][j] > st.tauMax {
+					t.Fatalf("update %d: tau[%d][%d] = %v outside [%v, %v]", it, i, j, st.tau[i][j], st.tauMin, st.tauMax)
+				}
+			}
+		}
+	}
+	onPath := make([]bool, len(D))
+	for _, v := range path {
+		onPath[v] = true
+	}
+	a, b := path[0], path[1]
+	if math.Abs(st.tau[a][b]-st.tauMax) > 1e-12 {
+		t.Errorf("reinforced edge (%d, %d) has tau %v, want tau_max %v", a, b, st.tau[a][b], st.tauMax)
+	}
+	for u := range onPath {
+		if !onPath[u] {
+			if st.tau[a][u] != st.tauMin {
+				t.Errorf("unused edge (%d, %d) has tau %v, want tau_min %v", a, u, st.tau[a][u], st.tauMin)
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package algorithms
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
+)
+
+// benchStarts is the number of random starting solutions cycled through by
+// the benchmarks.
+const benchStarts = 50
+
+var benchMethods = []MethodSpec{
+	{Name: "Baseline"},
+	{Name: "LM", UseLM: true},
+	{Name: "Candidates_K10", UseCand: true, CandK: 10},
+	{Name: "CandLM_K10", UseCand: true, CandK: 10, UseLM: true},
+}
+
+// lsFixture is an instance with random starting solutions and a workspace
+// warmed up by one run from every start, so that no later run needs more
+// room in the list of moves than the workspace already has.
+type lsFixture struct {
+	D      [][]int
+	costs  []int
+	cd     CandData
+	starts [][]int
+	path   []int
+	ws     *lsWorkspace[int]
+}
+
+func newLSFixture(tb testing.TB, file string, m MethodSpec) *lsFixture {
+	tb.Helper()
+	nodes, err := data.ReadNodes(file)
+	if err != nil {
+		tb.Fatalf("reading %s: %v", file, err)
+	}
+	f := &lsFixture{
+		D:     data.CalculateDistanceMatrix(nodes),
+		costs: data.NodeCosts(nodes),
+	}
+	if m.UseCand {
+		pts := make([]Point, len(nodes))
+		for i, node := range nodes {
+			pts[i] = Point{X: node.X, Y: node.Y}
+		}
+		f.cd = BuildCandidateData(m.CandStrategy, f.D, f.costs, pts, m.CandK)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	f.starts = make([][]int, benchStarts)
+	for i := range f.starts {
+		f.starts[i] = startRandom(f.D, f.costs, rng).Path
+	}
+	f.path = make([]int, len(f.starts[0]))
+	f.ws = newLSWorkspace[int](len(f.D))
+	for _, start := range f.starts {
+		f.runFrom(m, start)
+	}
+	return f
+}
+
+func (f *lsFixture) runFrom(m MethodSpec, start []int) {
+	copy(f.path, start)
+	f.ws.run(m, f.D, f.costs, f.path, f.cd)
+}
+
+func benchmarkLocalSearch(b *testing.B, file string) {
+	for _, m := range benchMethods {
+		b.Run(m.Name, func(b *testing.B) {
+			f := newLSFixture(b, file, m)
+			total := 0
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				f.runFrom(m, f.starts[i%benchStarts])
+				total += objective(f.D, f.costs, f.path)
+			}
+			b.ReportMetric(float64(total)/float64(b.N), "obj")
+		})
+	}
+}
+
+func BenchmarkLocalSearchA(b *testing.B) { benchmarkLocalSearch(b, "../../instances/TSPA.csv") }
+func BenchmarkLocalSearchB(b *testing.B) { benchmarkLocalSearch(b, "../../instances/TSPB.csv") }
+
+// TestLocalSearchZeroAllocs checks that the search loops of every method do
+// not allocate once the workspace is warm.
+func TestLocalSearchZeroAllocs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the local searches on a full instance")
+	}
+	for _, m := range benchMethods {
+		t.Run(m.Name, func(t *testing.T) {
+			f := newLSFixture(t, "../../instances/TSPA.csv", m)
+			next := 0
+			allocs := testing.AllocsPerRun(benchStarts, func() {
+				f.runFrom(m, f.starts[next%benchStarts])
+				next++
+			})
+			if allocs != 0 {
+				t.Errorf("%v allocations per run, want 0", allocs)
+			}
+		})
+	}
+}
@@ -2,9 +2,9 @@ package algorithms
 
 // FindBestSolution returns the solution with the smallest objective value from
 // the provided slice. For an empty slice it returns the zero-value Solution.
-func FindBestSolution(solutions []Solution) Solution {
+func FindBestSolution[W Weight](solutions []Solution[W]) Solution[W] {
 	if len(solutions) == 0 {
-		return Solution{}
+		return Solution[W]{}
 	}
 	bestSolution := solutions[0]
 	for _, sol := range solutions {
@@ -0,0 +1,178 @@
+package algorithms
+
+// buildReverseCandidates returns, for every node v, the list of nodes u such
+// that v appears in the candidate list of u.
+func buildReverseCandidates(cd CandData) [][]int {
+	rev := make([][]int, len(cd.CandList))
+	for u, list := range cd.CandList {
+		for _, v := range list {
+			rev[v] = append(rev[v], u)
+		}
+	}
+	return rev
+}
+
+// applyTwoOptShorterAndUpdatePos performs 2-opt(i, j) by reversing whichever
+// side of the cycle is shorter, keeping posOf in sync. Both sides yield the
+// same cycle. It returns the position of the first reversed element and the
+// number of reversed elements.
+func applyTwoOptShorterAndUpdatePos(path []int, posOf []int, i, j int) (int, int) {
+	n := len(path)
+	if i > j {
+		i, j = j, i
+	}
+	start, length := i+1, j-i
+	if length > n-length {
+		start, length = nextIdx(j, n), n-length
+	}
+	for l, r := 0, length-1; l < r; l, r = l+1, r-1 {
+		pl, pr := (start+l)%n, (start+r)%n
+		vl, vr := path[pl], path[pr]
+		path[pl], path[pr] = vr, vl
+		posOf[vl], posOf[vr] = pr, pl
+	}
+	return start, length
+}
+
+// localSearchSteepestCandLM performs steepest local search combining candidate
+// moves with list-of-moves (LM) delta reuse. Only moves that introduce at least
+// one candidate edge are inserted into the LM, and after each applied move the
+// LM is updated incrementally around the modified part of the tour instead of
+// re-evaluating the whole neighborhood.
+func localSearchSteepestCandLM[W Weight](D [][]W, costs []W, init Solution[W], cd CandData) Solution[W] {
+	path := append([]int(nil), init.Path...)
+	newLSWorkspace[W](len(D)).steepestCandLM(D, costs, path, cd)
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
+}
+
+// addTwoOptForEdge stores both 2-opt moves introducing the edge (x, y):
+// 2-opt(i, j) adds (x, y) and (next(x), next(y)), while
+// 2-opt(prev(i), prev(j)) adds (prev(x), prev(y)) and (x, y).
+func (ws *lsWorkspace[W]) addTwoOptForEdge(x, y int) {
+	i, j := ws.posOf[x], ws.posOf[y]
+	if i < 0 || j < 0 {
+		return
+	}
+	n := len(ws.path)
+	ws.addTwoOptLM(i, j)
+	ws.addTwoOptLM(prevIdx(i, n), prevIdx(j, n))
+}
+
+// addTwoOptAround stores 2-opt moves introducing any candidate edge incident
+// to the selected vertex x.
+func (ws *lsWorkspace[W]) addTwoOptAround(x int) {
+	for _, y := range ws.cd.CandList[x] {
+		ws.addTwoOptForEdge(x, y)
+	}
+	for _, y := range ws.cd.revCand[x] {
+		ws.addTwoOptForEdge(x, y)
+	}
+}
+
+// addExchangeAt stores exchanges at position i introducing a candidate edge,
+// i.e. u is a candidate of prev(path[i]) or next(path[i]).
+func (ws *lsWorkspace[W]) addExchangeAt(i int) {
+	n := len(ws.path)
+	a := ws.path[prevIdx(i, n)]
+	b := ws.path[nextIdx(i, n)]
+	for _, u := range ws.cd.CandList[a] {
+		if ws.posOf[u] < 0 {
+			ws.addExchangeLM(i, u)
+		}
+	}
+	for _, u := range ws.cd.CandList[b] {
+		if ws.posOf[u] < 0 {
+			ws.addExchangeLM(i, u)
+		}
+	}
+}
+
+// addExchangeOf stores exchanges inserting the unselected vertex u next to a
+// selected vertex w that has u on its candidate list.
+func (ws *lsWorkspace[W]) addExchangeOf(u int) {
+	n := len(ws.path)
+	for _, w := range ws.cd.revCand[u] {
+		p := ws.posOf[w]
+		if p < 0 {
+			continue
+		}
+		ws.addExchangeLM(prevIdx(p, n), u)
+		ws.addExchangeLM(nextIdx(p, n), u)
+	}
+}
+
+// steepestCandLM is the workspace kernel of localSearchSteepestCandLM; it
+// improves path in place.
+func (ws *lsWorkspace[W]) steepestCandLM(D [][]W, costs []W, path []int, cd CandData) {
+	ws.load(D, costs, path)
+	ws.cd = cd
+	n := len(path)
+	if n == 0 {
+		return
+	}
+	if cd.revCand == nil {
+		ws.cd.revCand = buildReverseCandidates(cd)
+	}
+
+	// heuristic capacity: the candidate neighborhood is O(n*K)
+	capacity := n * 4
+	if len(cd.CandList) > 0 {
+		capacity *= len(cd.CandList[0]) + 1
+	}
+	ws.lm.reset(capacity)
+
+	// Build the full improving candidate neighborhood once for the initial
+	// solution.
+	for i := 0; i < n; i++ {
+		for _, y := range cd.CandList[path[i]] {
+			ws.addTwoOptForEdge(path[i], y)
+		}
+	}
+	for i := 0; i < n; i++ {
+		ws.addExchangeAt(i)
+	}
+
+	for {
+		bestMove, hasBest := ws.lm.bestApplicable(path, ws.posOf)
+		if !hasBest || !Improves(bestMove.delta) {
+			break
+		}
+
+		// remove the applied move from LM using its removed edges
+		ws.lm.remove(bestMove)
+
+		before := ws.objectiveBeforeMove()
+		switch bestMove.kind {
+		case MoveTwoOpt:
+			// indices were stored in v,u when best was selected
+			start, length := applyTwoOptShorterAndUpdatePos(path, ws.posOf, bestMove.v, bestMove.u)
+			ws.moves++
+			ws.verifyMove(before, bestMove.delta, MoveTwoOpt, bestMove.v, bestMove.u)
+
+			// The reversed segment changed orientation, so 2-opt moves pairing
+			// one of its edges with an outside edge have to be regenerated,
+			// together with moves around the two new edges at its ends.
+			for k := start - 1; k <= start+length; k++ {
+				ws.addTwoOptAround(path[(k+n)%n])
+			}
+			first, last := prevIdx(start, n), (start+length-1)%n
+			for _, k := range [4]int{first, start, last, nextIdx(last, n)} {
+				ws.addExchangeAt(k)
+			}
+		case MoveExchangeSelected:
+			p := ws.posOf[bestMove.v]
+			if p < 0 {
+				continue
+			}
+			vOld := bestMove.v
+			ws.applyExchange(p, bestMove.u)
+			ws.verifyMove(before, bestMove.delta, MoveExchangeSelected, p, bestMove.u)
+
+			for _, k := range [3]int{prevIdx(p, n), p, nextIdx(p, n)} {
+				ws.addTwoOptAround(path[k])
+				ws.addExchangeAt(k)
+			}
+			ws.addExchangeOf(vOld)
+		}
+	}
+}
@@ -0,0 +1,477 @@
+package algorithms
+
+import (
+	"math"
+	"sort"
+)
+
+// Point is a node location in the plane, used by the geometric candidate
+// list strategies.
+type Point struct {
+	X, Y float64
+}
+
+// CandStrategy selects how candidate lists are built.
+type CandStrategy int
+
+const (
+	// CandNearest takes the K nearest neighbours by D[u][v] + costs[v].
+	CandNearest CandStrategy = iota
+	// CandQuadrant takes the nearest neighbours in each of the four quadrants
+	// around a node and fills up to K with the nearest remaining ones.
+	CandQuadrant
+	// CandDelaunay takes all neighbours in the Delaunay triangulation; K is
+	// ignored.
+	CandDelaunay
+	// CandAlpha takes the K neighbours with the smallest alpha-nearness with
+	// respect to the minimum 1-tree.
+	CandAlpha
+	// CandHybrid takes the union of CandNearest and CandDelaunay.
+	CandHybrid
+)
+
+// String returns a short name of the strategy used in reports.
+func (s CandStrategy) String() string {
+	switch s {
+	case CandQuadrant:
+		return "quadrant"
+	case CandDelaunay:
+		return "delaunay"
+	case CandAlpha:
+		return "alpha"
+	case CandHybrid:
+		return "hybrid"
+	default:
+		return "nearest"
+	}
+}
+
+// newCandData wraps candidate lists together with the candidate edge lookup
+// and the reverse candidate lists.
+func newCandData(cand [][]int) CandData {
+	isCand := make(map[uint64]struct{}, len(cand)*10*2)
+	for u, list := range cand {
+		for _, v := range list {
+			isCand[packEdge(u, v)] = struct{}{}
+		}
+	}
+	cd := CandData{CandList: cand, isCand: isCand}
+	cd.revCand = buildReverseCandidates(cd)
+	return cd
+}
+
+// BuildCandidateData builds candidate lists with the given strategy. The
+// points are only used by the geometric strategies (quadrant, Delaunay and
+// hybrid).
+func BuildCandidateData[W Weight](strategy CandStrategy, D [][]W, costs []W, pts []Point, K int) CandData {
+	if K <= 0 {
+		K = 10
+	}
+	switch strategy {
+	case CandQuadrant:
+		return newCandData(quadrantCandidates(D, costs, pts, K))
+	case CandDelaunay:
+		return newCandData(sortByWeight(D, costs, delaunayNeighbors(pts)))
+	case CandAlpha:
+		return newCandData(alphaCandidates(D, costs, K))
+	case CandHybrid:
+		nearest := buildCandidates(D, costs, K).CandList
+		return newCandData(unionLists(nearest, sortByWeight(D, costs, delaunayNeighbors(pts))))
+	default:
+		return buildCandidates(D, costs, K)
+	}
+}
+
+// CandidateCoverage returns the fraction of edges of the given cycle that are
+// candidate edges.
+func CandidateCoverage(cd CandData, path []int) float64 {
+	n := len(path)
+	if n == 0 {
+		return 0
+	}
+	covered := 0
+	for i := 0; i < n; i++ {
+		if isCandidateEdge(cd, path[i], path[nextIdx(i, n)]) {
+			covered++
+		}
+	}
+	return float64(covered) / float64(n)
+}
+
+// AvgListSize returns the average length of the candidate lists.
+func (cd CandData) AvgListSize() float64 {
+	if len(cd.CandList) == 0 {
+		return 0
+	}
+	total := 0
+	for _, list := range cd.CandList {
+		total += len(list)
+	}
+	return float64(total) / float64(len(cd.CandList))
+}
+
+// sortByWeight orders every list by D[u][v] + costs[v], the same weight used
+// by the nearest neighbour strategy.
+func sortByWeight[W Weight](D [][]W, costs []W, lists [][]int) [][]int {
+	for u, list := range lists {
+		sort.SliceStable(list, func(i, j int) bool {
+			return D[u][list[i]]+costs[list[i]] < D[u][list[j]]+costs[list[j]]
+		})
+	}
+	return lists
+}
+
+// unionLists merges two families of candidate lists, keeping the order of the
+// first one and appending unseen entries of the second one.
+func unionLists(first, second [][]int) [][]int {
+	out := make([][]int, len(first))
+	for u := range first {
+		seen := make(map[int]bool, len(first[u])+len(second[u]))
+		list := make([]int, 0, len(first[u])+len(second[u]))
+		for _, v := range first[u] {
+			if !seen[v] {
+				seen[v] = true
+				list = append(list, v)
+			}
+		}
+		for _, v := range second[u] {
+			if !seen[v] {
+				seen[v] = true
+				list = append(list, v)
+			}
+		}
+		out[u] = list
+	}
+	return out
+}
+
+// quadrantCandidates takes the ceil(K/4) best neighbours (by D[u][v] +
+// costs[v]) from each quadrant around u and fills the list up to K with the
+// best remaining neighbours regardless of their quadrant.
+func quadrantCandidates[W Weight](D [][]W, costs []W, pts []Point, K int) [][]int {
+	n := len(D)
+	perQuadrant := (K + 3) / 4
+	cand := make([][]int, n)
+
+	for u := 0; u < n; u++ {
+		order := make([]int, 0, n-1)
+		for v := 0; v < n; v++ {
+			if v != u {
+				order = append(order, v)
+			}
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return D[u][order[i]]+costs[order[i]] < D[u][order[j]]+costs[order[j]]
+		})
+
+		m := K
+		if m > len(order) {
+			m = len(order)
+		}
+		list := make([]int, 0, m)
+		taken := make(map[int]bool, m)
+		var inQuadrant [4]int
+		for _, v := range order {
+			q := quadrantOf(pts[u], pts[v])
+			if inQuadrant[q] < perQuadrant && len(list) < m {
+				inQuadrant[q]++
+				taken[v] = true
+				list = append(list, v)
+			}
+		}
+		for _, v := range order {
+			if len(list) >= m {
+				break
+			}
+			if !taken[v] {
+				list = append(list, v)
+			}
+		}
+		cand[u] = list
+	}
+	return cand
+}
+
+// quadrantOf returns the quadrant (0..3) of q relative to p. Points sharing
+// the location of p fall into quadrant 0.
+func quadrantOf(p, q Point) int {
+	dx, dy := q.X-p.X, q.Y-p.Y
+	switch {
+	case dx >= 0 && dy > 0, dx == 0 && dy == 0:
+		return 0
+	case dx < 0 && dy >= 0:
+		return 1
+	case dx <= 0 && dy < 0:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// delaunayNeighbors returns the neighbours of every point in the Delaunay
+// triangulation, computed with the Bowyer-Watson algorithm. Points sharing a
+// location with an earlier point are linked to that point and inherit its
+// neighbours.
+func delaunayNeighbors(pts []Point) [][]int {
+	n := len(pts)
+	neighbors := make([][]int, n)
+	if n < 2 {
+		return neighbors
+	}
+
+	// Skip exact duplicates; they would create degenerate triangles.
+	twinOf := make([]int, n)
+	firstAt := make(map[Point]int, n)
+	for i, p := range pts {
+		if j, ok := firstAt[p]; ok {
+			twinOf[i] = j
+			continue
+		}
+		firstAt[p] = i
+		twinOf[i] = -1
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range pts {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+		minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+	}
+	span := math.Max(maxX-minX, maxY-minY)
+	if span == 0 {
+		span = 1
+	}
+	midX, midY := (minX+maxX)/2, (minY+maxY)/2
+
+	// The super-triangle vertices get indices n, n+1, n+2.
+	all := make([]Point, n, n+3)
+	copy(all, pts)
+	all = append(all,
+		Point{X: midX - 20*span, Y: midY - span},
+		Point{X: midX, Y: midY + 20*span},
+		Point{X: midX + 20*span, Y: midY - span},
+	)
+
+	tris := []triangle{newTriangle(all, n, n+1, n+2)}
+	for i := 0; i < n; i++ {
+		if twinOf[i] >= 0 {
+			continue
+		}
+		p := all[i]
+
+		// Remove triangles whose circumcircle contains p and remember the
+		// boundary of the resulting polygonal hole.
+		edgeCount := make(map[[2]int]int)
+		kept := tris[:0]
+		var bad []triangle
+		for _, t := range tris {
+			if t.inCircumcircle(p) {
+				bad = append(bad, t)
+				for _, e := range t.edges() {
+					edgeCount[e]++
+				}
+			} else {
+				kept = append(kept, t)
+			}
+		}
+		tris = kept
+		for _, t := range bad {
+			for _, e := range t.edges() {
+				if edgeCount[e] == 1 {
+					tris = append(tris, newTriangle(all, e[0], e[1], i))
+				}
+			}
+		}
+	}
+
+	seen := make(map[[2]int]bool)
+	for _, t := range tris {
+		for _, e := range t.edges() {
+			if e[0] >= n || e[1] >= n || seen[e] {
+				continue
+			}
+			seen[e] = true
+			neighbors[e[0]] = append(neighbors[e[0]], e[1])
+			neighbors[e[1]] = append(neighbors[e[1]], e[0])
+		}
+	}
+
+	for i, j := range twinOf {
+		if j < 0 {
+			continue
+		}
+		neighbors[i] = append([]int{j}, neighbors[j]...)
+		neighbors[j] = append(neighbors[j], i)
+	}
+	return neighbors
+}
+
+// triangle is a Delaunay triangle together with its circumcircle.
+type triangle struct {
+	a, b, c    int
+	cx, cy, r2 float64
+}
+
+func newTriangle(pts []Point, a, b, c int) triangle {
+	pa, pb, pc := pts[a], pts[b], pts[c]
+	d := 2 * (pa.X*(pb.Y-pc.Y) + pb.X*(pc.Y-pa.Y) + pc.X*(pa.Y-pb.Y))
+	t := triangle{a: a, b: b, c: c}
+	if d == 0 {
+		// collinear vertices: treat the circumcircle as infinite
+		t.r2 = math.Inf(1)
+		return t
+	}
+	a2 := pa.X*pa.X + pa.Y*pa.Y
+	b2 := pb.X*pb.X + pb.Y*pb.Y
+	c2 := pc.X*pc.X + pc.Y*pc.Y
+	t.cx = (a2*(pb.Y-pc.Y) + b2*(pc.Y-pa.Y) + c2*(pa.Y-pb.Y)) / d
+	t.cy = (a2*(pc.X-pb.X) + b2*(pa.X-pc.X) + c2*(pb.X-pa.X)) / d
+	t.r2 = (pa.X-t.cx)*(pa.X-t.cx) + (pa.Y-t.cy)*(pa.Y-t.cy)
+	return t
+}
+
+func (t triangle) inCircumcircle(p Point) bool {
+	if math.IsInf(t.r2, 1) {
+		return true
+	}
+	dx, dy := p.X-t.cx, p.Y-t.cy
+	return dx*dx+dy*dy < t.r2
+}
+
+// edges returns the triangle edges with endpoints in increasing order.
+func (t triangle) edges() [3][2]int {
+	sorted := func(x, y int) [2]int {
+		if x > y {
+			x, y = y, x
+		}
+		return [2]int{x, y}
+	}
+	return [3][2]int{sorted(t.a, t.b), sorted(t.b, t.c), sorted(t.c, t.a)}
+}
+
+// alphaCandidates takes for every node the K neighbours with the smallest
+// alpha-nearness, i.e. the increase of the minimum 1-tree weight when the
+// edge is forced into the tree. Edge weights are 2*D[u][v] + costs[u] +
+// costs[v], so the weight of a cycle is twice its objective value. Ties are
+// broken by the edge weight.
+func alphaCandidates[W Weight](D [][]W, costs []W, K int) [][]int {
+	n := len(D)
+	cand := make([][]int, n)
+	if n < 3 {
+		for u := 0; u < n; u++ {
+			for v := 0; v < n; v++ {
+				if v != u {
+					cand[u] = append(cand[u], v)
+				}
+			}
+		}
+		return cand
+	}
+	w := func(u, v int) W { return 2*D[u][v] + costs[u] + costs[v] }
+
+	// Minimum spanning tree over nodes 1..n-1 (Prim); node 0 is the special
+	// node of the 1-tree.
+	parent := make([]int, n)
+	best := make([]W, n)
+	inTree := make([]bool, n)
+	for v := range best {
+		best[v] = MaxWeight[W]()
+		parent[v] = -1
+	}
+	best[1] = 0
+	adj := make([][]int, n)
+	for iter := 1; iter < n; iter++ {
+		u := -1
+		for v := 1; v < n; v++ {
+			if !inTree[v] && (u == -1 || best[v] < best[u]) {
+				u = v
+			}
+		}
+		inTree[u] = true
+		if parent[u] >= 0 {
+			adj[u] = append(adj[u], parent[u])
+			adj[parent[u]] = append(adj[parent[u]], u)
+		}
+		for v := 1; v < n; v++ {
+			if !inTree[v] && w(u, v) < best[v] {
+				best[v] = w(u, v)
+				parent[v] = u
+			}
+		}
+	}
+
+	// The two cheapest edges of the special node belong to the 1-tree.
+	first, second := -1, -1
+	for v := 1; v < n; v++ {
+		switch {
+		case first == -1 || w(0, v) < w(0, first):
+			first, second = v, first
+		case second == -1 || w(0, v) < w(0, second):
+			second = v
+		}
+	}
+
+	alpha := make([][]W, n)
+	for u := range alpha {
+		alpha[u] = make([]W, n)
+	}
+	for v := 1; v < n; v++ {
+		if v != first && v != second {
+			alpha[0][v] = w(0, v) - w(0, second)
+			alpha[v][0] = alpha[0][v]
+		}
+	}
+
+	// beta[v] is the heaviest tree edge on the path from the root to v; the
+	// alpha value of a non-tree edge (root, v) is its weight minus beta[v].
+	beta := make([]W, n)
+	stack := make([]int, 0, n)
+	visited := make([]bool, n)
+	for root := 1; root < n; root++ {
+		for v := range visited {
+			visited[v] = false
+		}
+		beta[root] = -MaxWeight[W]()
+		visited[root] = true
+		stack = append(stack[:0], root)
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, v := range adj[u] {
+				if visited[v] {
+					continue
+				}
+				visited[v] = true
+				beta[v] = max(beta[u], w(u, v))
+				stack = append(stack, v)
+			}
+		}
+		for v := 1; v < n; v++ {
+			if v != root {
+				alpha[root][v] = w(root, v) - beta[v]
+			}
+		}
+	}
+
+	for u := 0; u < n; u++ {
+		order := make([]int, 0, n-1)
+		for v := 0; v < n; v++ {
+			if v != u {
+				order = append(order, v)
+			}
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			a, b := order[i], order[j]
+			if alpha[u][a] != alpha[u][b] {
+				return alpha[u][a] < alpha[u][b]
+			}
+			return w(u, a) < w(u, b)
+		})
+		m := K
+		if m > len(order) {
+			m = len(order)
+		}
+		cand[u] = order[:m:m]
+	}
+	return cand
+}
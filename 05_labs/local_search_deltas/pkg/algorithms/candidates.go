@@ -7,6 +7,7 @@ import "sort"
 type CandData struct {
 	CandList [][]int
 	isCand   map[uint64]struct{}
+	revCand  [][]int // revCand[v] lists the nodes u with v in CandList[u]
 }
 
 func packEdge(a, b int) uint64 {
@@ -16,25 +17,29 @@ func packEdge(a, b int) uint64 {
 	return uint64(uint32(a))<<32 | uint64(uint32(b))
 }
 
+// candNeighbor is a candidate neighbour v with its weight D[u][v] + costs[v].
+type candNeighbor[W Weight] struct {
+	v int
+	w W
+}
+
 // buildCandidates builds K nearest neighbors for each node, using the weight
 // D[u][v] + costs[v]. It also builds a fast lookup map for candidate edges.
-func buildCandidates(D [][]int, costs []int, K int) CandData {
+func buildCandidates[W Weight](D [][]W, costs []W, K int) CandData {
 	n := len(D)
 	if K <= 0 {
 		K = 10
 	}
 	cand := make([][]int, n)
-	isCand := make(map[uint64]struct{}, n*K*2)
 
 	for u := 0; u < n; u++ {
-		type nb struct{ v, w int }
-		nbs := make([]nb, 0, n-1)
+		nbs := make([]candNeighbor[W], 0, n-1)
 		for v := 0; v < n; v++ {
 			if v == u {
 				continue
 			}
 			w := D[u][v] + costs[v]
-			nbs = append(nbs, nb{v: v, w: w})
+			nbs = append(nbs, candNeighbor[W]{v: v, w: w})
 		}
 		sort.Slice(nbs, func(i, j int) bool { return nbs[i].w < nbs[j].w })
 		m := K
@@ -45,11 +50,10 @@ func buildCandidates(D [][]int, costs []int, K int) CandData {
 		for i := 0; i < m; i++ {
 			v := nbs[i].v
 			list[i] = v
-			isCand[packEdge(u, v)] = struct{}{}
 		}
 		cand[u] = list
 	}
-	return CandData{CandList: cand, isCand: isCand}
+	return newCandData(cand)
 }
 
 func isCandidateEdge(cd CandData, a, b int) bool {
@@ -59,27 +63,21 @@ func isCandidateEdge(cd CandData, a, b int) bool {
 
 // localSearchSteepestCandidates performs steepest-descent local search using
 // candidate moves (2-opt intra-route and exchanges with unselected vertices).
-func localSearchSteepestCandidates(D [][]int, costs []int, init Solution, cd CandData) Solution {
+func localSearchSteepestCandidates[W Weight](D [][]W, costs []W, init Solution[W], cd CandData) Solution[W] {
 	path := append([]int(nil), init.Path...)
-	n := len(path)
-
-	// quick lookup structures
-	posOf := make([]int, len(D))
-	inSel := make([]bool, len(D))
-	for i := range posOf {
-		posOf[i] = -1
-	}
-	for i, v := range path {
-		posOf[v] = i
-		inSel[v] = true
-	}
+	newLSWorkspace[W](len(D)).steepestCandidates(D, costs, path, cd)
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
+}
 
-	visitMark := make([]int, len(D))
-	epoch := 0
+// steepestCandidates is the workspace kernel of localSearchSteepestCandidates;
+// it improves path in place.
+func (ws *lsWorkspace[W]) steepestCandidates(D [][]W, costs []W, path []int, cd CandData) {
+	ws.load(D, costs, path)
+	n := len(path)
+	posOf := ws.posOf
 
 	for {
-		bestDelta := 0
-		var bestMove func()
+		best := lsMove[W]{}
 
 		// intra
 		for i := 0; i < n; i++ {
@@ -99,90 +97,47 @@ func localSearchSteepestCandidates(D [][]int, costs []int, init Solution, cd Can
 				// Prune symmetric duplicates: evaluate pair only when j > i for move A.
 				if j > i {
 					// MOVE A: 2-opt(i, j)  (cuts (i,i+1) & (j,j+1))
-					if dlA := deltaTwoOpt(D, path, i, j); dlA < bestDelta {
-						ii, jj := i, j
-						bestDelta = dlA
-						bestMove = func() {
-							applyTwoOptAndUpdatePos(path, posOf, ii, jj)
-						}
+					if dlA := DeltaTwoOpt(D, path, i, j); dlA < best.delta {
+						best = lsMove[W]{kind: MoveTwoOpt, i: i, j: j, delta: dlA}
 					}
 				}
 
 				// MOVE B: 2-opt(prev(i), prev(j)) (cuts (i-1,i) & (j-1,j))
 				ii := prevIdx(i, n)
 				jj := prevIdx(j, n)
-				if dlB := deltaTwoOpt(D, path, ii, jj); dlB < bestDelta {
-					iii, jjj := ii, jj
-					bestDelta = dlB
-					bestMove = func() {
-						applyTwoOptAndUpdatePos(path, posOf, iii, jjj)
-					}
+				if dlB := DeltaTwoOpt(D, path, ii, jj); dlB < best.delta {
+					best = lsMove[W]{kind: MoveTwoOpt, i: ii, j: jj, delta: dlB}
 				}
 			}
 		}
 
-		// inter - allow only if at least one of the introduced edges is a candidate edge (a,u) or (u,b), where a = prev(path[i]), b = next(path[i])
+		// inter - allow only if at least one of the introduced edges is a
+		// candidate edge (a,u) or (u,b), where a = prev(path[i]), b = next(path[i]).
+		// Taking u from cand[a] or cand[b] guarantees this by construction.
 		for i := 0; i < n; i++ {
 			a := path[prevIdx(i, n)]
 			b := path[nextIdx(i, n)]
 
-			epoch++
-			// Iterate cand[a]
-			for _, u := range cd.CandList[a] {
-				if visitMark[u] == epoch {
-					continue
-				}
-				visitMark[u] = epoch
-				if inSel[u] {
-					continue
-				}
-				// must introduce at least one candidate edge
-				if !(isCandidateEdge(cd, a, u) || isCandidateEdge(cd, b, u)) {
-					continue
-				}
-				if dl := deltaExchangeSelected(D, costs, path, i, u); dl < bestDelta {
-					ii, uu := i, u
-					bestDelta = dl
-					bestMove = func() {
-						vOld := path[ii]
-						applyExchangeSelected(path, ii, uu)
-
-						inSel[vOld], inSel[uu] = false, true
-						posOf[vOld], posOf[uu] = -1, ii
+			epoch := ws.nextEpoch()
+			for _, list := range [2][]int{cd.CandList[a], cd.CandList[b]} {
+				for _, u := range list {
+					if ws.visitMark[u] == epoch {
+						continue
 					}
-				}
-			}
-			// Iterate cand[b]
-			for _, u := range cd.CandList[b] {
-				if visitMark[u] == epoch {
-					continue
-				}
-				visitMark[u] = epoch
-				if inSel[u] {
-					continue
-				}
-				if !(isCandidateEdge(cd, a, u) || isCandidateEdge(cd, b, u)) {
-					continue
-				}
-				if dl := deltaExchangeSelected(D, costs, path, i, u); dl < bestDelta {
-					ii, uu := i, u
-					bestDelta = dl
-					bestMove = func() {
-						vOld := path[ii]
-						applyExchangeSelected(path, ii, uu)
-						inSel[vOld], inSel[uu] = false, true
-						posOf[vOld], posOf[uu] = -1, ii
+					ws.visitMark[u] = epoch
+					if posOf[u] >= 0 {
+						continue
+					}
+					if dl := DeltaExchangeSelected(D, costs, path, i, u); dl < best.delta {
+						best = lsMove[W]{kind: MoveExchangeSelected, i: i, j: u, delta: dl}
 					}
 				}
 			}
 		}
 
-		if bestDelta < 0 {
-			bestMove()
-		} else {
+		if !Improves(best.delta) {
 			break
 		}
+		ws.apply(best)
 	}
-
-	return Solution{Path: path, Objective: objective(D, costs, path)}
 }
@@ -0,0 +1,401 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// ExactMethod selects the exact solver.
+type ExactMethod int
+
+const (
+	// ExactAuto uses Held-Karp for instances with at most HeldKarpMaxNodes
+	// nodes and branch and bound otherwise.
+	ExactAuto ExactMethod = iota
+	// ExactHeldKarp uses the Held-Karp dynamic program.
+	ExactHeldKarp
+	// ExactBranchAndBound uses branch and bound with 1-tree lower bounds.
+	ExactBranchAndBound
+)
+
+func (m ExactMethod) String() string {
+	switch m {
+	case ExactHeldKarp:
+		return "held_karp"
+	case ExactBranchAndBound:
+		return "branch_and_bound"
+	default:
+		return "auto"
+	}
+}
+
+// HeldKarpMaxNodes is the largest instance solved by the Held-Karp dynamic
+// program; its memory grows as 2^n * n.
+const HeldKarpMaxNodes = 18
+
+// ExactConfig holds configuration for the exact solvers.
+type ExactConfig struct {
+	Method   ExactMethod
+	MaxNodes int // Branch and bound node budget (0 = unlimited)
+	Seed     int64
+}
+
+// ExactResult contains the result of an exact solver. If the branch and bound
+// node budget is exhausted, Optimal is false, BestSolution is the best
+// solution found and LowerBound the root bound.
+type ExactResult struct {
+	BestSolution Solution[int]
+	LowerBound   int
+	Optimal      bool
+	Method       ExactMethod
+	Nodes        int // branch and bound search nodes (0 for Held-Karp)
+	Duration     time.Duration
+}
+
+// SolveExact solves the instance to optimality with the configured method.
+// The exact solvers work with integer distances and node costs only.
+func SolveExact(D [][]int, costs []int, config ExactConfig) (ExactResult, error) {
+	n := len(D)
+	if n == 0 {
+		return ExactResult{}, fmt.Errorf("empty instance")
+	}
+	method := config.Method
+	if method == ExactAuto {
+		method = ExactBranchAndBound
+		if n <= HeldKarpMaxNodes {
+			method = ExactHeldKarp
+		}
+	}
+
+	if method == ExactHeldKarp {
+		if n > HeldKarpMaxNodes {
+			return ExactResult{}, fmt.Errorf("held-karp supports at most %d nodes, instance has %d", HeldKarpMaxNodes, n)
+		}
+		start := time.Now()
+		best := HeldKarp(D, costs)
+		return ExactResult{
+			BestSolution: best,
+			LowerBound:   best.Objective,
+			Optimal:      true,
+			Method:       ExactHeldKarp,
+			Duration:     time.Since(start),
+		}, nil
+	}
+	return BranchAndBound(D, costs, config), nil
+}
+
+// HeldKarp solves the instance with the Held-Karp dynamic program over
+// subsets: dp[S][j] is the cheapest path starting at the lowest node of S,
+// visiting exactly the nodes of S and ending at j, including the costs of the
+// nodes of S. The tour is closed once S holds selectCount(n) nodes. Intended
+// for at most HeldKarpMaxNodes nodes.
+func HeldKarp(D [][]int, costs []int) Solution[int] {
+	n := len(D)
+	k := selectCount(n)
+	const inf = math.MaxInt32
+	dp := make([]int32, (1<<n)*n)
+	parent := make([]int8, (1<<n)*n)
+	for i := range dp {
+		dp[i] = inf
+	}
+	for s := 0; s < n; s++ {
+		dp[(1<<s)*n+s] = int32(costs[s])
+	}
+
+	best, bestMask, bestEnd := inf, 0, 0
+	for mask := 1; mask < 1<<n; mask++ {
+		size := bits.OnesCount(uint(mask))
+		if size > k {
+			continue
+		}
+		s := bits.TrailingZeros(uint(mask))
+		for j := s; j < n; j++ {
+			val := int(dp[mask*n+j])
+			if val == inf {
+				continue
+			}
+			if size == k {
+				if total := val + D[j][s]; total < best {
+					best, bestMask, bestEnd = total, mask, j
+				}
+				continue
+			}
+			for v := s + 1; v < n; v++ {
+				if mask&(1<<v) != 0 {
+					continue
+				}
+				next := (mask|1<<v)*n + v
+				if cand := val + D[j][v] + costs[v]; cand < int(dp[next]) {
+					dp[next] = int32(cand)
+					parent[next] = int8(j)
+				}
+			}
+		}
+	}
+
+	// walk the parents back from the last node to the lowest one
+	path := make([]int, 0, k)
+	mask, j := bestMask, bestEnd
+	for {
+		path = append(path, j)
+		if mask == 1<<j {
+			break
+		}
+		prev := int(parent[mask*n+j])
+		mask &^= 1 << j
+		j = prev
+	}
+	return Solution[int]{Path: path, Objective: objective(D, costs, path)}
+}
+
+// bnbEdge is an edge of the complete graph with its doubled weight.
+type bnbEdge struct{ a, b, w int }
+
+// bnbSearch holds the state of the branch and bound search. All weights are
+// doubled so that the node costs can be split between the incident edges:
+// W(u, v) = 2*D[u][v] + costs[u] + costs[v], and the W-length of a tour is
+// twice its objective.
+type bnbSearch struct {
+	n, k     int
+	W        [][]int
+	order    [][]int   // nodes sorted by W from each node
+	edges    []bnbEdge // all edges sorted by W
+	uf       []int
+	path     []int
+	inPath   []bool
+	best     []int
+	bestW    int
+	nodes    int
+	maxNodes int
+	aborted  bool
+}
+
+// BranchAndBound solves the instance with a depth-first branch and bound that
+// selects the nodes and the tour together. Tours are built from their lowest
+// node s by appending nodes greater than s; the two orientations of a tour are
+// distinguished by requiring the second node to be lower than the last one.
+// A partial path is pruned with a 1-tree bound including node costs: the
+// completion back to s consists of an edge leaving the last node, an edge
+// entering s and a forest of the remaining edges among the available nodes,
+// whose cheapest form is the prefix of Kruskal's algorithm (the greedy forest
+// is optimal for every number of edges). The search starts from the best of a
+// few local optima of random solutions.
+func BranchAndBound(D [][]int, costs []int, config ExactConfig) ExactResult {
+	start := time.Now()
+	n := len(D)
+	k := selectCount(n)
+	bs := &bnbSearch{
+		n:        n,
+		k:        k,
+		W:        make([][]int, n),
+		order:    make([][]int, n),
+		uf:       make([]int, n),
+		inPath:   make([]bool, n),
+		maxNodes: config.MaxNodes,
+	}
+	for u := 0; u < n; u++ {
+		bs.W[u] = make([]int, n)
+		for v := 0; v < n; v++ {
+			bs.W[u][v] = 2*D[u][v] + costs[u] + costs[v]
+			if v > u {
+				bs.edges = append(bs.edges, bnbEdge{a: u, b: v, w: bs.W[u][v]})
+			}
+		}
+		order := make([]int, 0, n-1)
+		for v := 0; v < n; v++ {
+			if v != u {
+				order = append(order, v)
+			}
+		}
+		W := bs.W[u]
+		sort.SliceStable(order, func(a, b int) bool { return W[order[a]] < W[order[b]] })
+		bs.order[u] = order
+	}
+	sort.SliceStable(bs.edges, func(a, b int) bool { return bs.edges[a].w < bs.edges[b].w })
+
+	// initial upper bound from local optima of random solutions
+	rng := rand.New(rand.NewSource(config.Seed))
+	ws := newLSWorkspace[int](n)
+	incumbent := Solution[int]{Objective: math.MaxInt32}
+	for r := 0; r < 10; r++ {
+		path := startRandom(D, costs, rng).Path
+		ws.steepestBaseline(D, costs, path)
+		if obj := objective(D, costs, path); obj < incumbent.Objective {
+			incumbent = Solution[int]{Path: path, Objective: obj}
+		}
+	}
+	bs.best = incumbent.Path
+	bs.bestW = 2 * incumbent.Objective
+
+	// root bounds of every lowest node, searched from the most promising
+	type root struct{ s, bound int }
+	roots := make([]root, 0, n)
+	rootBound := bs.bestW
+	for s := 0; s+k <= n; s++ {
+		bs.push(s)
+		bound := bs.bound(0)
+		bs.pop()
+		roots = append(roots, root{s: s, bound: bound})
+		rootBound = min(rootBound, bound)
+	}
+	sort.SliceStable(roots, func(a, b int) bool { return roots[a].bound < roots[b].bound })
+
+	for _, r := range roots {
+		if r.bound >= bs.bestW {
+			break
+		}
+		bs.push(r.s)
+		bs.search(0)
+		bs.pop()
+		if bs.aborted {
+			break
+		}
+	}
+
+	best := Solution[int]{Path: bs.best, Objective: objective(D, costs, bs.best)}
+	res := ExactResult{
+		BestSolution: best,
+		LowerBound:   best.Objective,
+		Optimal:      !bs.aborted,
+		Method:       ExactBranchAndBound,
+		Nodes:        bs.nodes,
+		Duration:     time.Since(start),
+	}
+	if bs.aborted {
+		res.LowerBound = (rootBound + 1) / 2
+	}
+	return res
+}
+
+func (bs *bnbSearch) push(v int) {
+	bs.path = append(bs.path, v)
+	bs.inPath[v] = true
+}
+
+func (bs *bnbSearch) pop() {
+	v := bs.path[len(bs.path)-1]
+	bs.path = bs.path[:len(bs.path)-1]
+	bs.inPath[v] = false
+}
+
+// available reports whether v may still be appended to the current path.
+func (bs *bnbSearch) available(v int) bool {
+	return v > bs.path[0] && !bs.inPath[v]
+}
+
+// search extends the current path, whose W-length is pathW, depth first.
+func (bs *bnbSearch) search(pathW int) {
+	bs.nodes++
+	if bs.maxNodes > 0 && bs.nodes > bs.maxNodes {
+		bs.aborted = true
+		return
+	}
+	m := len(bs.path)
+	s, last := bs.path[0], bs.path[m-1]
+	if m == bs.k {
+		if total := pathW + bs.W[last][s]; total < bs.bestW {
+			bs.bestW = total
+			bs.best = append([]int(nil), bs.path...)
+		}
+		return
+	}
+	if bs.bound(pathW) >= bs.bestW {
+		return
+	}
+
+	for _, v := range bs.order[last] {
+		if !bs.available(v) {
+			continue
+		}
+		// the last node of a tour has to be greater than its second one
+		if m == bs.k-1 && m > 1 && v < bs.path[1] {
+			continue
+		}
+		bs.push(v)
+		bs.search(pathW + bs.W[last][v])
+		bs.pop()
+		if bs.aborted {
+			return
+		}
+	}
+}
+
+// bound returns a lower bound on the W-length of every tour extending the
+// current path, whose W-length is pathW.
+func (bs *bnbSearch) bound(pathW int) int {
+	m := len(bs.path)
+	s, last := bs.path[0], bs.path[m-1]
+	r := bs.k - m // nodes still to be added
+	if r == 0 {
+		return pathW + bs.W[last][s]
+	}
+
+	// the edges leaving the last node and entering s; from the root path both
+	// are incident to s and go to different nodes
+	minLast, minS := -1, -1
+	for _, v := range bs.order[last] {
+		if bs.available(v) {
+			minLast = bs.W[last][v]
+			break
+		}
+	}
+	skip := 0
+	if m == 1 && r > 1 {
+		skip = 1
+	}
+	for _, v := range bs.order[s] {
+		if !bs.available(v) {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		minS = bs.W[s][v]
+		break
+	}
+	if minLast < 0 || minS < 0 {
+		return math.MaxInt
+	}
+	if m == 1 && r == 1 {
+		// a two-node tour uses its only edge twice
+		minS = minLast
+	}
+
+	// Kruskal's forest with r-1 edges among the available nodes
+	forest, count := 0, 0
+	for v := 0; v < bs.n; v++ {
+		bs.uf[v] = v
+	}
+	for _, e := range bs.edges {
+		if count >= r-1 {
+			break
+		}
+		if !bs.available(e.a) || !bs.available(e.b) {
+			continue
+		}
+		ra, rb := bs.find(e.a), bs.find(e.b)
+		if ra == rb {
+			continue
+		}
+		bs.uf[ra] = rb
+		forest += e.w
+		count++
+	}
+	if count < r-1 {
+		return math.MaxInt
+	}
+	return pathW + minLast + minS + forest
+}
+
+func (bs *bnbSearch) find(v int) int {
+	for bs.uf[v] != v {
+		bs.uf[v] = bs.uf[bs.uf[v]]
+		v = bs.uf[v]
+	}
+	return v
+}
@@ -0,0 +1,101 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// bruteForce returns the optimal objective by enumerating every set of
+// selectCount(n) nodes and every cycle through it.
+func bruteForce(D [][]int, costs []int) int {
+	n := len(D)
+	k := selectCount(n)
+	best := math.MaxInt
+	path := make([]int, 0, k)
+	used := make([]bool, n)
+
+	// permute fixes path[0] as the smallest node of the set, so every cycle
+	// is enumerated once per direction
+	var permute func(set []int)
+	permute = func(set []int) {
+		if len(path) == k {
+			best = min(best, objective(D, costs, path))
+			return
+		}
+		for _, v := range set[1:] {
+			if !used[v] {
+				used[v] = true
+				path = append(path, v)
+				permute(set)
+				path = path[:len(path)-1]
+				used[v] = false
+			}
+		}
+	}
+	var choose func(start int, set []int)
+	choose = func(start int, set []int) {
+		if len(set) == k {
+			path = append(path[:0], set[0])
+			permute(set)
+			return
+		}
+		for v := start; v < n; v++ {
+			choose(v+1, append(set, v))
+		}
+	}
+	choose(0, make([]int, 0, k))
+	return best
+}
+
+// TestExactSolversAgree checks Held-Karp and branch and bound against brute
+// force on random instances of up to 12 nodes, and that both return valid
+// tours with the reported objective.
+func TestExactSolversAgree(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for dim := 1; dim <= 12; dim++ {
+		for trial := 0; trial < 3; trial++ {
+			inst := randomInstance(dim, rng)
+			D, costs := inst.D, inst.costs
+			want := bruteForce(D, costs)
+
+			hk := HeldKarp(D, costs)
+			bb := BranchAndBound(D, costs, ExactConfig{Seed: 1})
+			if !bb.Optimal {
+				t.Fatalf("%s: branch and bound did not prove optimality", inst.name)
+			}
+			for name, sol := range map[string]Solution[int]{"Held-Karp": hk, "branch and bound": bb.BestSolution} {
+				if sol.Objective != want {
+					t.Errorf("%s: %s found %d, brute force %d", inst.name, name, sol.Objective, want)
+				}
+				if err := validateTour(dim, selectCount(dim), sol.Path); err != nil {
+					t.Errorf("%s: %s: %v", inst.name, name, err)
+				} else if got := objective(D, costs, sol.Path); got != sol.Objective {
+					t.Errorf("%s: %s reported %d, tour has objective %d", inst.name, name, sol.Objective, got)
+				}
+			}
+		}
+	}
+}
+
+// TestHeuristicsNotBelowOptimum checks that no local search method reports a
+// solution better than the optimum.
+func TestHeuristicsNotBelowOptimum(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, dim := range []int{8, 10, 12, 16} {
+		inst := randomInstance(dim, rng)
+		res, err := SolveExact(inst.D, inst.costs, ExactConfig{Seed: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		opt := res.BestSolution.Objective
+		for _, m := range propertyMethods {
+			solutions, _ := RunLocalSearchBatch(inst.D, inst.costs, inst.pts, m, 20)
+			for _, sol := range solutions {
+				if sol.Objective < opt {
+					t.Errorf("%s: %s found %d below the optimum %d", inst.name, m.Name, sol.Objective, opt)
+				}
+			}
+		}
+	}
+}
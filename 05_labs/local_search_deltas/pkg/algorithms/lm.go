@@ -13,93 +13,241 @@ type edgeKey struct {
 	x, y int
 }
 
+// moveKey identifies a move by its two removed edges. For exchange moves the
+// inserted vertex u is part of the key as well, since several exchanges can
+// remove the same pair of edges.
 type moveKey struct {
 	e1 edgeKey
 	e2 edgeKey
+	u  int
 }
 
 // MoveRecord stores a single improving move together with its precomputed delta.
-type MoveRecord struct {
+type MoveRecord[W Weight] struct {
 	kind  MoveType
 	a, b  int // endpoints of first removed edge
 	c, d  int // endpoints of second removed edge
 	v, u  int // for exchange: v replaced by u (selected vertex v, new vertex u)
-	delta int // precomputed delta value
+	delta W   // precomputed delta value
 	key   moveKey
 }
 
-// lmState stores a list-of-moves (LM) and an index to find moves by their edge keys.
-type lmState struct {
-	moves []MoveRecord
-	index map[moveKey]int
+// lmState stores a list-of-moves (LM). Moves live in stable slots of pool;
+// heap orders the slots as a binary min-heap by delta, so the best stored
+// move is always at the top, and index finds the slot of a move by its edge
+// keys. Moves that are no longer applicable are evicted lazily when they
+// reach the top.
+type lmState[W Weight] struct {
+	pool    []MoveRecord[W]
+	heapPos []int // position of every slot in heap
+	free    []int // unused slots
+	heap    []int
+	index   moveIndex
+}
+
+// reset empties the LM while keeping its buffers for the next search.
+func (lm *lmState[W]) reset(capacity int) {
+	lm.pool = lm.pool[:0]
+	lm.heapPos = lm.heapPos[:0]
+	lm.free = lm.free[:0]
+	lm.heap = lm.heap[:0]
+	lm.index.reset(capacity)
+}
+
+// moveIndex is an open-addressing hash table (linear probing) mapping move
+// keys to their slots in lmState.pool. Unlike a Go map it can be cleared
+// without releasing its memory, so the LM of a reused workspace does not
+// allocate once the table has grown to the working size.
+type moveIndex struct {
+	keys  []moveKey
+	vals  []int // slot in lmState.pool, -1 for an empty slot
+	count int
+}
+
+// reset clears the table and makes room for at least capacity keys.
+func (mi *moveIndex) reset(capacity int) {
+	size := 16
+	for size < 2*capacity {
+		size *= 2
+	}
+	if len(mi.vals) < size {
+		mi.keys = make([]moveKey, size)
+		mi.vals = make([]int, size)
+	}
+	for i := range mi.vals {
+		mi.vals[i] = -1
+	}
+	mi.count = 0
+}
+
+func (mi *moveIndex) home(k moveKey) int {
+	h := uint64(k.e1.x)*0x9E3779B97F4A7C15 ^ uint64(k.e1.y)*0xC2B2AE3D27D4EB4F ^
+		uint64(k.e2.x)*0x165667B19E3779F9 ^ uint64(k.e2.y)*0x27D4EB2F165667C5 ^
+		uint64(k.u+1)*0x94D049BB133111EB
+	h ^= h >> 29
+	return int(h & uint64(len(mi.vals)-1))
+}
+
+// find returns the slot holding k, or the empty slot where k would go.
+func (mi *moveIndex) find(k moveKey) (int, bool) {
+	mask := len(mi.vals) - 1
+	for i := mi.home(k); ; i = (i + 1) & mask {
+		if mi.vals[i] < 0 {
+			return i, false
+		}
+		if mi.keys[i] == k {
+			return i, true
+		}
+	}
+}
+
+func (mi *moveIndex) get(k moveKey) (int, bool) {
+	if len(mi.vals) == 0 {
+		return 0, false
+	}
+	slot, ok := mi.find(k)
+	return mi.vals[slot], ok
+}
+
+func (mi *moveIndex) set(k moveKey, v int) {
+	if len(mi.vals) == 0 || 2*(mi.count+1) > len(mi.vals) {
+		mi.grow()
+	}
+	slot, ok := mi.find(k)
+	if !ok {
+		mi.keys[slot] = k
+		mi.count++
+	}
+	mi.vals[slot] = v
+}
+
+// delete removes k using backward-shift deletion, so no tombstones are needed.
+func (mi *moveIndex) delete(k moveKey) {
+	if len(mi.vals) == 0 {
+		return
+	}
+	slot, ok := mi.find(k)
+	if !ok {
+		return
+	}
+	mask := len(mi.vals) - 1
+	for j := (slot + 1) & mask; mi.vals[j] >= 0; j = (j + 1) & mask {
+		h := mi.home(mi.keys[j])
+		// move j into the hole unless its home lies cyclically in (slot, j]
+		if (j-h)&mask >= (j-slot)&mask {
+			mi.keys[slot], mi.vals[slot] = mi.keys[j], mi.vals[j]
+			slot = j
+		}
+	}
+	mi.vals[slot] = -1
+	mi.count--
+}
+
+func (mi *moveIndex) grow() {
+	oldKeys, oldVals := mi.keys, mi.vals
+	size := 2 * len(oldVals)
+	if size < 16 {
+		size = 16
+	}
+	mi.keys = make([]moveKey, size)
+	mi.vals = make([]int, size)
+	for i := range mi.vals {
+		mi.vals[i] = -1
+	}
+	mi.count = 0
+	for i, v := range oldVals {
+		if v >= 0 {
+			slot, _ := mi.find(oldKeys[i])
+			mi.keys[slot], mi.vals[slot] = oldKeys[i], v
+			mi.count++
+		}
+	}
+}
+
+// addTwoOptLM stores 2-opt(i, j) in the LM if it is improving.
+func (ws *lsWorkspace[W]) addTwoOptLM(i, j int) {
+	path := ws.path
+	n := len(path)
+	if i == j || nextIdx(i, n) == j || nextIdx(j, n) == i {
+		return
+	}
+	dl := DeltaTwoOpt(ws.D, path, i, j)
+	if !Improves(dl) {
+		return
+	}
+	ws.lm.addMove(MoveRecord[W]{
+		kind:  MoveTwoOpt,
+		a:     path[i],
+		b:     path[nextIdx(i, n)],
+		c:     path[j],
+		d:     path[nextIdx(j, n)],
+		v:     -1,
+		u:     -1,
+		delta: dl,
+	})
+}
+
+// addExchangeLM stores the exchange of path[i] with the unselected vertex u in
+// the LM if it is improving.
+func (ws *lsWorkspace[W]) addExchangeLM(i, u int) {
+	path := ws.path
+	n := len(path)
+	dl := DeltaExchangeSelected(ws.D, ws.costs, path, i, u)
+	if !Improves(dl) {
+		return
+	}
+	v := path[i]
+	ws.lm.addMove(MoveRecord[W]{
+		kind:  MoveExchangeSelected,
+		a:     path[prevIdx(i, n)],
+		b:     v,
+		c:     v,
+		d:     path[nextIdx(i, n)],
+		v:     v,
+		u:     u,
+		delta: dl,
+	})
 }
 
 // buildFullNeighborhoodLM builds the full improving neighborhood for the
 // current solution and stores it in the LM structure. This is called once
 // for the initial solution; subsequent iterations update LM incrementally.
-func buildFullNeighborhoodLM(D [][]int, costs []int, path []int, nonSel []int, lm *lmState) {
-	n := len(path)
+func (ws *lsWorkspace[W]) buildFullNeighborhoodLM() {
+	n := len(ws.path)
 
 	// intra: 2-opt
 	for i := 0; i < n; i++ {
 		for j := i + 1; j < n; j++ {
-			dl := deltaTwoOpt(D, path, i, j)
-			if dl >= 0 {
-				continue
-			}
-			a := path[i]
-			b := path[nextIdx(i, n)]
-			c := path[j]
-			d := path[nextIdx(j, n)]
-			rec := MoveRecord{
-				kind:  MoveTwoOpt,
-				a:     a,
-				b:     b,
-				c:     c,
-				d:     d,
-				v:     -1,
-				u:     -1,
-				delta: dl,
-			}
-			lm.addMove(rec)
+			ws.addTwoOptLM(i, j)
 		}
 	}
 
 	// inter: selected vertex with unselected one
 	for i := 0; i < n; i++ {
-		for _, u := range nonSel {
-			dl := deltaExchangeSelected(D, costs, path, i, u)
-			if dl >= 0 {
-				continue
-			}
-			a := path[prevIdx(i, n)]
-			v := path[i]
-			b := path[nextIdx(i, n)]
-			rec := MoveRecord{
-				kind:  MoveExchangeSelected,
-				a:     a,
-				b:     v,
-				c:     v,
-				d:     b,
-				v:     v,
-				u:     u,
-				delta: dl,
-			}
-			lm.addMove(rec)
+		for _, u := range ws.nonSel {
+			ws.addExchangeLM(i, u)
 		}
 	}
 }
 
 // updateLMAfterMove updates the LM after applying a move by generating new
 // improving moves in the vicinity of the modified edges/vertices instead of
-// rebuilding the full neighborhood.
-func updateLMAfterMove(D [][]int, costs []int, path []int, posOf []int, nonSel []int, bestMove MoveRecord, lm *lmState) {
-	n := len(path)
+// rebuilding the full neighborhood. The affected edge starts are collected in
+// the touched scratch list, de-duplicated with visitMark.
+func (ws *lsWorkspace[W]) updateLMAfterMove(bestMove MoveRecord[W]) {
+	n := len(ws.path)
 	if n == 0 {
 		return
 	}
 
-	edgeStarts := make(map[int]struct{}, 8)
+	epoch := ws.nextEpoch()
+	ws.touched = ws.touched[:0]
+	touch := func(k int) {
+		if ws.visitMark[k] != epoch {
+			ws.visitMark[k] = epoch
+			ws.touched = append(ws.touched, k)
+		}
+	}
 
 	switch bestMove.kind {
 	case MoveTwoOpt:
@@ -115,78 +263,33 @@ func updateLMAfterMove(D [][]int, costs []int, path []int, posOf []int, nonSel [
 			i, j = j, i
 		}
 		for k := i; k <= j; k++ {
-			edgeStarts[k] = struct{}{}
-			edgeStarts[prevIdx(k, n)] = struct{}{}
+			touch(k)
+			touch(prevIdx(k, n))
 		}
 	case MoveExchangeSelected:
 		uNew := bestMove.u
 		if uNew < 0 {
 			break
 		}
-		pos := posOf[uNew]
+		pos := ws.posOf[uNew]
 		if pos < 0 || pos >= n {
 			break
 		}
-		edgeStarts[pos] = struct{}{}
-		edgeStarts[prevIdx(pos, n)] = struct{}{}
-	}
-
-	if len(edgeStarts) == 0 {
-		return
+		touch(pos)
+		touch(prevIdx(pos, n))
 	}
 
 	// 2-opt moves touching affected edges.
-	for i := range edgeStarts {
+	for _, i := range ws.touched {
 		for j := 0; j < n; j++ {
-			if j == i {
-				continue
-			}
-			if j == nextIdx(i, n) || j == prevIdx(i, n) {
-				continue
-			}
-			dl := deltaTwoOpt(D, path, i, j)
-			if dl >= 0 {
-				continue
-			}
-			a := path[i]
-			b := path[nextIdx(i, n)]
-			c := path[j]
-			d := path[nextIdx(j, n)]
-			rec := MoveRecord{
-				kind:  MoveTwoOpt,
-				a:     a,
-				b:     b,
-				c:     c,
-				d:     d,
-				v:     -1,
-				u:     -1,
-				delta: dl,
-			}
-			lm.addMove(rec)
+			ws.addTwoOptLM(i, j)
 		}
 	}
 
 	// Exchange moves for positions adjacent to affected edges.
-	for i := range edgeStarts {
-		for _, u := range nonSel {
-			dl := deltaExchangeSelected(D, costs, path, i, u)
-			if dl >= 0 {
-				continue
-			}
-			a := path[prevIdx(i, n)]
-			v := path[i]
-			b := path[nextIdx(i, n)]
-			rec := MoveRecord{
-				kind:  MoveExchangeSelected,
-				a:     a,
-				b:     v,
-				c:     v,
-				d:     b,
-				v:     v,
-				u:     u,
-				delta: dl,
-			}
-			lm.addMove(rec)
+	for _, i := range ws.touched {
+		for _, u := range ws.nonSel {
+			ws.addExchangeLM(i, u)
 		}
 	}
 }
@@ -203,39 +306,132 @@ func canonicalMoveKey(e1, e2 edgeKey) moveKey {
 	if e2.x < e1.x || (e2.x == e1.x && e2.y < e1.y) {
 		e1, e2 = e2, e1
 	}
-	return moveKey{e1: e1, e2: e2}
+	return moveKey{e1: e1, e2: e2, u: -1}
 }
 
-func (lm *lmState) addMove(rec MoveRecord) {
+func (lm *lmState[W]) addMove(rec MoveRecord[W]) {
 	e1 := canonicalEdge(rec.a, rec.b)
 	e2 := canonicalEdge(rec.c, rec.d)
 	key := canonicalMoveKey(e1, e2)
-	if _, exists := lm.index[key]; exists {
+	if rec.kind == MoveExchangeSelected {
+		key.u = rec.u
+	}
+	if _, exists := lm.index.get(key); exists {
 		return
 	}
 	rec.key = key
-	lm.moves = append(lm.moves, rec)
-	lm.index[key] = len(lm.moves) - 1
-}
 
-func (lm *lmState) remove(rec MoveRecord) {
-	if len(lm.moves) == 0 {
-		return
+	var slot int
+	if k := len(lm.free); k > 0 {
+		slot = lm.free[k-1]
+		lm.free = lm.free[:k-1]
+		lm.pool[slot] = rec
+	} else {
+		slot = len(lm.pool)
+		lm.pool = append(lm.pool, rec)
+		lm.heapPos = append(lm.heapPos, 0)
 	}
-	key := rec.key
-	idx, ok := lm.index[key]
+	lm.index.set(key, slot)
+	lm.heapPos[slot] = len(lm.heap)
+	lm.heap = append(lm.heap, slot)
+	lm.up(len(lm.heap) - 1)
+}
+
+func (lm *lmState[W]) remove(rec MoveRecord[W]) {
+	slot, ok := lm.index.get(rec.key)
 	if !ok {
 		return
 	}
-	lastIdx := len(lm.moves) - 1
-	if idx != lastIdx {
-		// swap with last and update index
-		lastRec := lm.moves[lastIdx]
-		lm.moves[idx] = lastRec
-		lm.index[lastRec.key] = idx
+	i, last := lm.heapPos[slot], len(lm.heap)-1
+	if i != last {
+		lm.swap(i, last)
+	}
+	lm.heap = lm.heap[:last]
+	lm.index.delete(rec.key)
+	lm.free = append(lm.free, slot)
+	if i != last {
+		lm.down(i)
+		lm.up(i)
+	}
+}
+
+func (lm *lmState[W]) less(i, j int) bool {
+	return lm.pool[lm.heap[i]].delta < lm.pool[lm.heap[j]].delta
+}
+
+// swap exchanges two heap entries and keeps their positions in sync.
+func (lm *lmState[W]) swap(i, j int) {
+	lm.heap[i], lm.heap[j] = lm.heap[j], lm.heap[i]
+	lm.heapPos[lm.heap[i]] = i
+	lm.heapPos[lm.heap[j]] = j
+}
+
+// up restores the heap order by moving entry i towards the top.
+func (lm *lmState[W]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !lm.less(i, parent) {
+			return
+		}
+		lm.swap(i, parent)
+		i = parent
+	}
+}
+
+// down restores the heap order by moving entry i towards the leaves.
+func (lm *lmState[W]) down(i int) {
+	n := len(lm.heap)
+	for {
+		smallest := i
+		if l := 2*i + 1; l < n && lm.less(l, smallest) {
+			smallest = l
+		}
+		if r := 2*i + 2; r < n && lm.less(r, smallest) {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		lm.swap(i, smallest)
+		i = smallest
+	}
+}
+
+// bestApplicable returns the best move that is applicable to the current
+// tour. Moves are taken from the top of the heap; a move whose removed edges
+// are no longer in the tour, or no longer have the same relative orientation,
+// is evicted and the next one is examined, so each stale move is checked once.
+// For 2-opt moves the returned record carries the current cut indices in its
+// v,u fields. The returned move stays in the LM.
+func (lm *lmState[W]) bestApplicable(path []int, posOf []int) (MoveRecord[W], bool) {
+	dim := len(posOf)
+	for len(lm.heap) > 0 {
+		rec := lm.pool[lm.heap[0]]
+		switch rec.kind {
+		case MoveTwoOpt:
+			ok1, fwd1, cut1 := findEdgeCut(path, posOf, rec.a, rec.b)
+			ok2, fwd2, cut2 := findEdgeCut(path, posOf, rec.c, rec.d)
+			// if the orientation changed relative to when the move was
+			// created, the stored delta is no longer valid; the move is
+			// regenerated in the neighborhood phase
+			if ok1 && ok2 && fwd1 == fwd2 {
+				rec.v, rec.u = cut1, cut2
+				return rec, true
+			}
+		case MoveExchangeSelected:
+			// v has to be still selected and u not
+			if rec.v >= 0 && rec.v < dim && posOf[rec.v] >= 0 &&
+				!(rec.u >= 0 && rec.u < dim && posOf[rec.u] >= 0) {
+				ok1, fwd1, _ := findEdgeCut(path, posOf, rec.a, rec.b)
+				ok2, fwd2, _ := findEdgeCut(path, posOf, rec.c, rec.d)
+				if ok1 && ok2 && fwd1 == fwd2 {
+					return rec, true
+				}
+			}
+		}
+		lm.remove(rec)
 	}
-	lm.moves = lm.moves[:lastIdx]
-	delete(lm.index, key)
+	return MoveRecord[W]{}, false
 }
 
 // findEdgeCut finds whether an undirected edge (x,y) appears in the current cycle defined by path/posOf.
@@ -281,159 +477,57 @@ func findEdgeCut(path []int, posOf []int, x, y int) (ok bool, forward bool, cutI
 
 // localSearchSteepestLM performs steepest local search with list-of-moves (LM)
 // delta reuse, using the same neighborhood as the baseline variant.
-func localSearchSteepestLM(D [][]int, costs []int, init Solution) Solution {
+func localSearchSteepestLM[W Weight](D [][]W, costs []W, init Solution[W]) Solution[W] {
 	path := append([]int(nil), init.Path...)
+	newLSWorkspace[W](len(D)).steepestLM(D, costs, path)
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
+}
+
+// steepestLM is the workspace kernel of localSearchSteepestLM; it improves
+// path in place.
+func (ws *lsWorkspace[W]) steepestLM(D [][]W, costs []W, path []int) {
+	ws.load(D, costs, path)
 	n := len(path)
 	if n == 0 {
-		return init
-	}
-
-	dim := len(D)
-
-	// quick lookup structures
-	posOf := make([]int, dim)
-	inSel := make([]bool, dim)
-	for i := range posOf {
-		posOf[i] = -1
-	}
-	for i, v := range path {
-		posOf[v] = i
-		inSel[v] = true
-	}
-
-	// heuristic preallocation: typical number of moves is O(n^2)
-	prealloc := n * n
-	lm := lmState{
-		moves: make([]MoveRecord, 0, prealloc),
-		index: make(map[moveKey]int, prealloc),
-	}
-
-	// maintain the list of non-selected vertices incrementally.
-	nonSel := make([]int, 0, dim-n)
-	for u := 0; u < dim; u++ {
-		if !inSel[u] {
-			nonSel = append(nonSel, u)
-		}
+		return
 	}
 
-	// removeFromSlice removes value from xs using swap-and-pop (O(1)).
-	// Order is not preserved, but this is acceptable for nonSel.
-	removeFromSlice := func(xs []int, value int) []int {
-		for i, v := range xs {
-			if v == value {
-				lastIdx := len(xs) - 1
-				xs[i] = xs[lastIdx]
-				return xs[:lastIdx]
-			}
-		}
-		return xs
-	}
+	// typical number of moves is O(n^2)
+	ws.lm.reset(n * n)
 
 	// Build full improving neighborhood once for the initial solution.
-	buildFullNeighborhoodLM(D, costs, path, nonSel, &lm)
+	ws.buildFullNeighborhoodLM()
 
 	for {
-		bestDelta := 0
-		var bestMove MoveRecord
-		hasBest := false
-
 		// 1) Browse LM, reusing stored deltas when applicable.
-		//    While browsing, aggressively prune moves that are no longer
-		//    applicable so we don't keep scanning dead entries.
-		for idx := 0; idx < len(lm.moves); {
-			rec := lm.moves[idx]
-			removed := false
-			switch rec.kind {
-			case MoveTwoOpt:
-				ok1, fwd1, cut1 := findEdgeCut(path, posOf, rec.a, rec.b)
-				ok2, fwd2, cut2 := findEdgeCut(path, posOf, rec.c, rec.d)
-				if !ok1 || !ok2 {
-					lm.remove(rec)
-					removed = true
-				} else if fwd1 != fwd2 {
-					// orientation changed relative to when the move was created, so
-					// the stored delta is no longer valid; let it be regenerated
-					// in the neighborhood phase and drop this stale entry.
-					lm.remove(rec)
-					removed = true
-				} else {
-					// same relative direction (both forward or both reversed) -> applicable now
-					if rec.delta < bestDelta || !hasBest {
-						hasBest = true
-						bestDelta = rec.delta
-						// normalise cut indices according to current orientation
-						bestMove = rec
-						// store current cut indices into unused fields v,u for convenience
-						bestMove.v = cut1
-						bestMove.u = cut2
-					}
-				}
-			case MoveExchangeSelected:
-				// Early checks before expensive findEdgeCut calls.
-				// Verify v is still selected and u is not.
-				if rec.v < 0 || rec.v >= dim || posOf[rec.v] == -1 {
-					lm.remove(rec)
-					removed = true
-				} else if rec.u >= 0 && rec.u < dim && inSel[rec.u] {
-					lm.remove(rec)
-					removed = true
-				} else {
-					ok1, fwd1, _ := findEdgeCut(path, posOf, rec.a, rec.b)
-					ok2, fwd2, _ := findEdgeCut(path, posOf, rec.c, rec.d)
-					if !ok1 || !ok2 {
-						lm.remove(rec)
-						removed = true
-					} else if fwd1 != fwd2 {
-						lm.remove(rec)
-						removed = true
-					} else if rec.delta < bestDelta || !hasBest {
-						hasBest = true
-						bestDelta = rec.delta
-						bestMove = rec
-					}
-				}
-			}
-			if !removed {
-				idx++
-			}
-		}
+		bestMove, hasBest := ws.lm.bestApplicable(path, ws.posOf)
 
 		// 2) New moves are added incrementally in updateLMAfterMove after an
 		// improving move is applied, so we do not rebuild the full
 		// neighborhood here.
-		if !hasBest || bestDelta >= 0 {
+		if !hasBest || !Improves(bestMove.delta) {
 			break
 		}
 
 		// 3) Apply the best move and update structures; then update LM.
+		before := ws.objectiveBeforeMove()
 		switch bestMove.kind {
 		case MoveTwoOpt:
 			// indices were stored in v,u when best was selected
-			i := bestMove.v
-			j := bestMove.u
-			applyTwoOptAndUpdatePos(path, posOf, i, j)
+			ws.applyTwoOpt(bestMove.v, bestMove.u)
+			ws.verifyMove(before, bestMove.delta, MoveTwoOpt, bestMove.v, bestMove.u)
 		case MoveExchangeSelected:
-			posV := posOf[bestMove.v]
-			if posV >= 0 {
-				vOld := bestMove.v
-				uNew := bestMove.u
-				applyExchangeSelected(path, posV, uNew)
-				inSel[vOld], inSel[uNew] = false, true
-				posOf[vOld], posOf[uNew] = -1, posV
-
-				// keep nonSel consistent with the incremental update policy.
-				nonSel = removeFromSlice(nonSel, uNew)
-				nonSel = append(nonSel, vOld)
+			if p := ws.posOf[bestMove.v]; p >= 0 {
+				ws.applyExchange(p, bestMove.u)
+				ws.verifyMove(before, bestMove.delta, MoveExchangeSelected, p, bestMove.u)
 			}
 		}
 
 		// remove the applied move from LM using its removed edges
-		lm.remove(bestMove)
+		ws.lm.remove(bestMove)
 
 		// Incrementally add new moves affected by this modification instead of
 		// rebuilding the neighborhood from scratch.
-		updateLMAfterMove(D, costs, path, posOf, nonSel, bestMove, &lm)
+		ws.updateLMAfterMove(bestMove)
 	}
-
-	return Solution{Path: path, Objective: objective(D, costs, path)}
 }
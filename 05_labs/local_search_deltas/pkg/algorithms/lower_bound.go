@@ -0,0 +1,189 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"time"
+)
+
+// LowerBoundConfig holds configuration for the Lagrangian lower bound.
+type LowerBoundConfig struct {
+	Iterations int     // Subgradient iterations (default 1000)
+	Step       float64 // Initial step scale of the Polyak rule (default 2)
+	Patience   int     // Iterations without improvement before the step scale is halved (default 20)
+	UpperBound float64 // Objective of a known solution (0 = best of a few local optima)
+	Seed       int64
+}
+
+// LowerBoundResult contains the result of the Lagrangian lower bound.
+type LowerBoundResult[W Weight] struct {
+	Bound      W // rounded up for integer weights, the objective is integral
+	Iterations int
+	Duration   time.Duration
+}
+
+// LagrangianLowerBound returns a lower bound on the objective of every tour
+// through selectCount(n) nodes. The relaxation keeps the exact-K selection
+// and a K-edge 1-tree: K nodes with the smallest costs and K edges forming a
+// forest of K-1 edges plus one more edge, whose cheapest form is the prefix of
+// Kruskal's algorithm and the cheapest edge outside of it. Without further
+// coupling between the edges and the selected nodes, the degree constraints
+// deg(v) = 2 if v is selected and 0 otherwise are dualised with multipliers
+// lambda: the edge (u, v) costs d(u, v) + lambda_u + lambda_v and node v costs
+// costs[v] - 2*lambda_v. The multipliers are optimised by subgradient ascent
+// with the Polyak step towards the upper bound.
+func LagrangianLowerBound[W Weight](D [][]W, costs []W, config LowerBoundConfig) LowerBoundResult[W] {
+	start := time.Now()
+	if config.Iterations <= 0 {
+		config.Iterations = 1000
+	}
+	if config.Step <= 0 {
+		config.Step = 2
+	}
+	if config.Patience <= 0 {
+		config.Patience = 20
+	}
+
+	n := len(D)
+	k := selectCount(n)
+	if k < 3 {
+		// at most two nodes: bounded by the cheapest node costs
+		sorted := append([]W(nil), costs...)
+		slices.Sort(sorted)
+		var bound W
+		for _, c := range sorted[:k] {
+			bound += c
+		}
+		return LowerBoundResult[W]{Bound: bound, Duration: time.Since(start)}
+	}
+
+	upper := config.UpperBound
+	if upper <= 0 {
+		rng := rand.New(rand.NewSource(config.Seed))
+		ws := newLSWorkspace[W](n)
+		upper = math.Inf(1)
+		for r := 0; r < 5; r++ {
+			path := startRandom(D, costs, rng).Path
+			ws.steepestBaseline(D, costs, path)
+			upper = min(upper, float64(objective(D, costs, path)))
+		}
+	}
+
+	type edge struct{ a, b int }
+	edges := make([]edge, 0, n*(n-1)/2)
+	for a := 0; a < n; a++ {
+		for b := a + 1; b < n; b++ {
+			edges = append(edges, edge{a, b})
+		}
+	}
+	weight := make([]float64, len(edges))
+	order := make([]int, len(edges))
+	nodeOrder := make([]int, n)
+	nodeWeight := make([]float64, n)
+	lambda := make([]float64, n)
+	degree := make([]int, n)
+	selected := make([]bool, n)
+	uf := make([]int, n)
+	find := func(v int) int {
+		for uf[v] != v {
+			uf[v] = uf[uf[v]]
+			v = uf[v]
+		}
+		return v
+	}
+
+	best := math.Inf(-1)
+	step := config.Step
+	sinceImprovement := 0
+	it := 0
+	for ; it < config.Iterations && step > 1e-4; it++ {
+		// 1-tree: Kruskal's forest with k-1 edges plus the cheapest other edge
+		for i, e := range edges {
+			weight[i] = float64(D[e.a][e.b]) + lambda[e.a] + lambda[e.b]
+			order[i] = i
+		}
+		sort.Slice(order, func(x, y int) bool { return weight[order[x]] < weight[order[y]] })
+		for v := 0; v < n; v++ {
+			uf[v] = v
+			degree[v] = 0
+		}
+		value := 0.0
+		treeEdges, extra := 0, -1
+		for _, i := range order {
+			if treeEdges == k-1 && extra >= 0 {
+				break
+			}
+			e := edges[i]
+			if treeEdges < k-1 {
+				if ra, rb := find(e.a), find(e.b); ra != rb {
+					uf[ra] = rb
+					treeEdges++
+					value += weight[i]
+					degree[e.a]++
+					degree[e.b]++
+					continue
+				}
+			}
+			if extra < 0 {
+				extra = i
+				value += weight[i]
+				degree[e.a]++
+				degree[e.b]++
+			}
+		}
+
+		// selection: the k nodes of the smallest Lagrangian cost
+		for v := 0; v < n; v++ {
+			nodeOrder[v] = v
+			nodeWeight[v] = float64(costs[v]) - 2*lambda[v]
+			selected[v] = false
+		}
+		sort.Slice(nodeOrder, func(x, y int) bool { return nodeWeight[nodeOrder[x]] < nodeWeight[nodeOrder[y]] })
+		for _, v := range nodeOrder[:k] {
+			selected[v] = true
+			value += nodeWeight[v]
+		}
+
+		if value > best+1e-9 {
+			best = value
+			sinceImprovement = 0
+		} else if sinceImprovement++; sinceImprovement >= config.Patience {
+			step /= 2
+			sinceImprovement = 0
+		}
+
+		// subgradient of the dualised degree constraints
+		norm := 0.0
+		for v := 0; v < n; v++ {
+			g := float64(degree[v])
+			if selected[v] {
+				g -= 2
+			}
+			norm += g * g
+		}
+		if norm == 0 || best >= upper {
+			it++
+			break
+		}
+		t := step * (upper - value) / norm
+		for v := 0; v < n; v++ {
+			g := float64(degree[v])
+			if selected[v] {
+				g -= 2
+			}
+			lambda[v] += t * g
+		}
+	}
+
+	bound := W(best)
+	if Integral[W]() {
+		bound = W(math.Ceil(best - 1e-6))
+	}
+	return LowerBoundResult[W]{
+		Bound:      bound,
+		Iterations: it,
+		Duration:   time.Since(start),
+	}
+}
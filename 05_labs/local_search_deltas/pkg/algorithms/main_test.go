@@ -0,0 +1,14 @@
+package algorithms
+
+import (
+	"io"
+	"log"
+	"os"
+	"testing"
+)
+
+// TestMain silences the progress logging of the data package.
+func TestMain(m *testing.M) {
+	log.SetOutput(io.Discard)
+	os.Exit(m.Run())
+}
@@ -1,7 +1,8 @@
 package algorithms
 
-// intra-route move - two edges exchange: 2-opt between path[i] and path[j]
-func deltaTwoOpt(D [][]int, path []int, i, j int) int {
+// DeltaTwoOpt returns the objective change of the intra-route move
+// exchanging two edges: 2-opt between path[i] and path[j].
+func DeltaTwoOpt[W Weight](D [][]W, path []int, i, j int) W {
 	if i == j {
 		return 0
 	}
@@ -19,8 +20,9 @@ func deltaTwoOpt(D [][]int, path []int, i, j int) int {
 	return after - before
 }
 
-// inter-route move - two-nodes exchange - path[i] with u (u outside the current path)
-func deltaExchangeSelected(D [][]int, costs []int, path []int, i int, u int) int {
+// DeltaExchangeSelected returns the objective change of the inter-route move
+// exchanging path[i] with u (u outside the current path).
+func DeltaExchangeSelected[W Weight](D [][]W, costs []W, path []int, i int, u int) W {
 	n := len(path)
 	a := path[prevIdx(i, n)]
 	v := path[i]
@@ -30,8 +32,8 @@ func deltaExchangeSelected(D [][]int, costs []int, path []int, i int, u int) int
 	return after - before
 }
 
-// applyTwoOpt performs a 2-opt move on the path between indices i and j, in-place.
-func applyTwoOpt(path []int, i, j int) {
+// ApplyTwoOpt performs a 2-opt move on the path between indices i and j, in-place.
+func ApplyTwoOpt(path []int, i, j int) {
 	n := len(path)
 	if i == j || nextIdx(i, n) == j || nextIdx(j, n) == i {
 		return
@@ -62,6 +64,6 @@ func applyTwoOptAndUpdatePos(path []int, posOf []int, i, j int) {
 	}
 }
 
-// applyExchangeSelected replaces the selected vertex at position i with a new
+// ApplyExchangeSelected replaces the selected vertex at position i with a new
 // vertex u (which must be outside the current path).
-func applyExchangeSelected(path []int, i int, u int) { path[i] = u }
+func ApplyExchangeSelected(path []int, i int, u int) { path[i] = u }
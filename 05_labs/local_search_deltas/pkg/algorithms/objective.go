@@ -6,21 +6,60 @@ import (
 )
 
 // MethodSpec describes a single configured local search method used in experiments.
-// It controls whether candidate moves or list-of-moves (LM) delta reuse is used.
+// It controls whether candidate moves or list-of-moves (LM) delta reuse is used;
+// setting both combines them into a single LM restricted to candidate moves.
 type MethodSpec struct {
-	Name    string
-	UseCand bool // should use candidate moves?
-	CandK   int  // how many nearest to include in candidate list
-	UseLM   bool // should use list-of-moves (LM) delta reuse?
+	Name         string
+	UseCand      bool         // should use candidate moves?
+	CandK        int          // how many nearest to include in candidate list
+	CandStrategy CandStrategy // how candidate lists are built (default: K nearest)
+	UseLM        bool         // should use list-of-moves (LM) delta reuse?
+}
+
+// Weight is the type of distances and node costs: integers, rounded or
+// scaled by the distance policy, or exact float64 values.
+type Weight interface {
+	~int | ~float64
+}
+
+// improvementEps is the smallest objective decrease of an applied move, so
+// that floating-point noise cannot make a search with exact distances cycle.
+const improvementEps = 1e-9
+
+// Improves reports whether a move with the given delta decreases the
+// objective by more than the floating-point noise of exact distances.
+func Improves[W Weight](delta W) bool { return float64(delta) < -improvementEps }
+
+// Integral reports whether W is an integer type.
+func Integral[W Weight]() bool {
+	half := 0.5
+	return W(half) == 0
+}
+
+// MaxWeight returns the largest value of W, used as infinity.
+func MaxWeight[W Weight]() W {
+	if Integral[W]() {
+		return W(math.MaxInt)
+	}
+	return W(math.Inf(1))
+}
+
+// sameObjective reports whether two objectives are equal: exactly for integer
+// weights, up to the floating-point noise of the summation otherwise.
+func sameObjective[W Weight](a, b W) bool {
+	if Integral[W]() {
+		return a == b
+	}
+	return math.Abs(float64(a-b)) <= 1e-9*max(1, math.Abs(float64(a)))
 }
 
 // objective computes the tour length plus node costs for a given path.
 // An empty path is treated as a very large (effectively infinite) objective.
-func objective(D [][]int, costs []int, path []int) int {
+func objective[W Weight](D [][]W, costs []W, path []int) W {
 	if len(path) == 0 {
 		return math.MaxInt32 / 4
 	}
-	sum := 0
+	var sum W
 	n := len(path)
 	for i := 0; i < n; i++ {
 		a := path[i]
@@ -49,7 +88,7 @@ func selectCount(n int) int { return (n + 1) / 2 }
 
 // startRandom builds an initial solution by selecting selectCount(n) nodes at
 // random and shuffling their order.
-func startRandom(D [][]int, costs []int, rng *rand.Rand) Solution {
+func startRandom[W Weight](D [][]W, costs []W, rng *rand.Rand) Solution[W] {
 	n := len(D)
 	k := selectCount(n)
 	idx := make([]int, n)
@@ -59,5 +98,5 @@ func startRandom(D [][]int, costs []int, rng *rand.Rand) Solution {
 	rng.Shuffle(n, func(i, j int) { idx[i], idx[j] = idx[j], idx[i] })
 	path := append([]int(nil), idx[:k]...)
 	rng.Shuffle(k, func(i, j int) { path[i], path[j] = path[j], path[i] })
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
@@ -0,0 +1,220 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
+)
+
+// propertyMethods are the local search methods run by the property tests.
+var propertyMethods = []MethodSpec{
+	{Name: "Baseline"},
+	{Name: "LM", UseLM: true},
+	{Name: "Candidates_K5", UseCand: true, CandK: 5},
+	{Name: "CandLM_K5", UseCand: true, CandK: 5, UseLM: true},
+	{Name: "CandLM_Hybrid_K5", UseCand: true, CandK: 5, CandStrategy: CandHybrid, UseLM: true},
+}
+
+// testInstance is an instance for the property tests, with rounded and exact
+// distances and node costs.
+type testInstance struct {
+	name       string
+	D          [][]int
+	costs      []int
+	exactD     [][]float64
+	exactCosts []float64
+	pts        []Point
+}
+
+// randomInstance builds a random Euclidean instance with dim nodes placed on
+// a 1000x1000 grid, with node costs in [0, 500).
+func randomInstance(dim int, rng *rand.Rand) testInstance {
+	inst := testInstance{
+		name:       fmt.Sprintf("random-%d", dim),
+		D:          make([][]int, dim),
+		costs:      make([]int, dim),
+		exactD:     make([][]float64, dim),
+		exactCosts: make([]float64, dim),
+		pts:        make([]Point, dim),
+	}
+	for i := 0; i < dim; i++ {
+		inst.pts[i] = Point{X: float64(rng.Intn(1000)), Y: float64(rng.Intn(1000))}
+		inst.costs[i] = rng.Intn(500)
+		inst.exactCosts[i] = float64(inst.costs[i])
+	}
+	for i := range inst.D {
+		inst.D[i] = make([]int, dim)
+		inst.exactD[i] = make([]float64, dim)
+		for j := range inst.D[i] {
+			if i != j {
+				inst.exactD[i][j] = math.Hypot(inst.pts[i].X-inst.pts[j].X, inst.pts[i].Y-inst.pts[j].Y)
+				inst.D[i][j] = int(math.Round(inst.exactD[i][j]))
+			}
+		}
+	}
+	return inst
+}
+
+// labInstance reads one of the lab instances.
+func labInstance(t *testing.T, name string) testInstance {
+	t.Helper()
+	nodes, err := data.ReadNodes("../../instances/TSP" + name + ".csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inst := testInstance{
+		name:       name,
+		D:          data.CalculateDistanceMatrix(nodes),
+		costs:      data.NodeCosts(nodes),
+		exactD:     data.CalculateExactDistanceMatrix(nodes, data.DistancePolicy{}),
+		exactCosts: data.NodeCostsExact(nodes, data.DistancePolicy{}),
+		pts:        make([]Point, len(nodes)),
+	}
+	for i, node := range nodes {
+		inst.pts[i] = Point{X: node.X, Y: node.Y}
+	}
+	return inst
+}
+
+// propertyInstances returns the lab instances and small random instances.
+func propertyInstances(t *testing.T, rng *rand.Rand) []testInstance {
+	instances := []testInstance{labInstance(t, "A"), labInstance(t, "B")}
+	for _, dim := range []int{5, 6, 7, 10, 25} {
+		instances = append(instances, randomInstance(dim, rng))
+	}
+	return instances
+}
+
+// randomTour selects between 3 and len(D) nodes at random and returns them in
+// random order.
+func randomTour[W Weight](D [][]W, rng *rand.Rand) []int {
+	dim := len(D)
+	k := 3
+	if dim > 3 {
+		k += rng.Intn(dim - 2)
+	}
+	return rng.Perm(dim)[:k]
+}
+
+// describeTour prints short tours in full and long ones by their length.
+func describeTour(path []int) string {
+	if len(path) <= 20 {
+		return fmt.Sprint(path)
+	}
+	return fmt.Sprintf("tour of %d nodes", len(path))
+}
+
+// TestDeltaProperties checks every delta function against a full
+// recomputation of the objective: for random tours and random move positions,
+// objective(after) - objective(before) has to equal the delta. The 2-opt check
+// also covers the variant reversing the shorter side of the cycle, which has
+// to keep the position index in sync.
+func TestDeltaProperties(t *testing.T) {
+	trials := 20000
+	if testing.Short() {
+		trials = 1000
+	}
+	rng := rand.New(rand.NewSource(1))
+	for _, inst := range propertyInstances(t, rng) {
+		t.Run(inst.name, func(t *testing.T) {
+			D, costs := inst.D, inst.costs
+			check := func(move string, path []int, i, j, delta int, apply func([]int)) {
+				t.Helper()
+				moved := append([]int(nil), path...)
+				apply(moved)
+				if got := objective(D, costs, moved) - objective(D, costs, path); got != delta {
+					t.Fatalf("%s(%d, %d) on %s: delta %d, objective changed by %d", move, i, j, describeTour(path), delta, got)
+				}
+			}
+
+			posOf := make([]int, len(D))
+			inSel := make([]bool, len(D))
+			for trial := 0; trial < trials; trial++ {
+				path := randomTour(D, rng)
+				n := len(path)
+				i, j := rng.Intn(n), rng.Intn(n)
+				dl := DeltaTwoOpt(D, path, i, j)
+
+				check("2-opt", path, i, j, dl, func(p []int) { ApplyTwoOpt(p, i, j) })
+				if i != j {
+					check("shorter 2-opt", path, i, j, dl, func(p []int) {
+						for v := range posOf {
+							posOf[v] = -1
+						}
+						for k, v := range p {
+							posOf[v] = k
+						}
+						applyTwoOptShorterAndUpdatePos(p, posOf, i, j)
+						for k, v := range p {
+							if posOf[v] != k {
+								t.Fatalf("shorter 2-opt(%d, %d) on %s: posOf[%d] = %d, vertex is at %d", i, j, describeTour(path), v, posOf[v], k)
+							}
+						}
+					})
+				}
+
+				if n < len(D) {
+					clear(inSel)
+					for _, v := range path {
+						inSel[v] = true
+					}
+					u := rng.Intn(len(D))
+					for inSel[u] {
+						u = rng.Intn(len(D))
+					}
+					check("exchange", path, i, u, DeltaExchangeSelected(D, costs, path, i, u),
+						func(p []int) { ApplyExchangeSelected(p, i, u) })
+				}
+			}
+		})
+	}
+}
+
+// TestLocalSearchProperties runs every method from random starting solutions
+// with VerifyMoves enabled, on a workspace reused between runs, and checks
+// that the result is a valid tour of selectCount nodes and never worse than
+// the start, with rounded and with exact distances.
+func TestLocalSearchProperties(t *testing.T) {
+	prev := VerifyMoves
+	VerifyMoves = true
+	defer func() { VerifyMoves = prev }()
+
+	runs := 10
+	if testing.Short() {
+		runs = 2
+	}
+	rng := rand.New(rand.NewSource(1))
+	for _, inst := range propertyInstances(t, rng) {
+		checkLocalSearch(t, inst.name, inst.D, inst.costs, inst.pts, runs, rng)
+		checkLocalSearch(t, inst.name+"/exact", inst.exactD, inst.exactCosts, inst.pts, runs, rng)
+	}
+}
+
+// checkLocalSearch runs the property checks of TestLocalSearchProperties on
+// one instance.
+func checkLocalSearch[W Weight](t *testing.T, name string, D [][]W, costs []W, pts []Point, runs int, rng *rand.Rand) {
+	k := selectCount(len(D))
+	ws := newLSWorkspace[W](len(D))
+	for _, m := range propertyMethods {
+		t.Run(name+"/"+m.Name, func(t *testing.T) {
+			var cd CandData
+			if m.UseCand {
+				cd = BuildCandidateData(m.CandStrategy, D, costs, pts, m.CandK)
+			}
+			for r := 0; r < runs; r++ {
+				init := startRandom(D, costs, rng)
+				path := append([]int(nil), init.Path...)
+				ws.run(m, D, costs, path, cd)
+				if err := validateTour(len(D), k, path); err != nil {
+					t.Fatalf("from %s: %v", describeTour(init.Path), err)
+				}
+				if after := objective(D, costs, path); after > init.Objective {
+					t.Fatalf("worsened %s from %v to %v", describeTour(init.Path), init.Objective, after)
+				}
+			}
+		})
+	}
+}
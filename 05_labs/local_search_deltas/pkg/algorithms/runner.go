@@ -7,95 +7,128 @@ import (
 
 // localSearchSteepestBaseline performs steepest local search on the full
 // neighborhood (2-opt intra-route plus exchanges with unselected vertices).
-func localSearchSteepestBaseline(D [][]int, costs []int, init Solution) Solution {
+func localSearchSteepestBaseline[W Weight](D [][]W, costs []W, init Solution[W]) Solution[W] {
 	path := append([]int(nil), init.Path...)
+	newLSWorkspace[W](len(D)).steepestBaseline(D, costs, path)
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
+}
+
+// steepestBaseline is the workspace kernel of localSearchSteepestBaseline; it
+// improves path in place.
+func (ws *lsWorkspace[W]) steepestBaseline(D [][]W, costs []W, path []int) {
+	ws.load(D, costs, path)
 	n := len(path)
 
 	for {
-		bestDelta := 0
-		var bestMove func()
+		best := lsMove[W]{}
 
 		// intra-route move - two-edges exchange: 2-opt
 		for i := 0; i < n; i++ {
 			for j := i + 1; j < n; j++ {
-				dl := deltaTwoOpt(D, path, i, j)
-				if dl < bestDelta {
-					ii, jj := i, j
-					bestDelta = dl
-					bestMove = func() { applyTwoOpt(path, ii, jj) }
+				if dl := DeltaTwoOpt(D, path, i, j); dl < best.delta {
+					best = lsMove[W]{kind: MoveTwoOpt, i: i, j: j, delta: dl}
 				}
 			}
 		}
 
 		// inter-route moves - path[i] with u (u outside the current path)
-		inSel := make([]bool, len(D))
-		for _, v := range path {
-			inSel[v] = true
-		}
-		nonSel := make([]int, 0, len(D)-n)
-		for u := range D {
-			if !inSel[u] {
-				nonSel = append(nonSel, u)
-			}
-		}
 		for i := 0; i < n; i++ {
-			for _, u := range nonSel {
-				dl := deltaExchangeSelected(D, costs, path, i, u)
-				if dl < bestDelta {
-					ii, uu := i, u
-					bestDelta = dl
-					bestMove = func() { applyExchangeSelected(path, ii, uu) }
+			for _, u := range ws.nonSel {
+				if dl := DeltaExchangeSelected(D, costs, path, i, u); dl < best.delta {
+					best = lsMove[W]{kind: MoveExchangeSelected, i: i, j: u, delta: dl}
 				}
 			}
 		}
 
-		if bestDelta < 0 {
-			bestMove()
-		} else {
+		if !Improves(best.delta) {
 			break
 		}
+		ws.apply(best)
 	}
-	return Solution{Path: path, Objective: objective(D, costs, path)}
 }
 
 // RunLocalSearchBatch runs a batch of independently initialised local searches
 // for a given method specification and returns all final solutions together
-// with per-run durations.
-func RunLocalSearchBatch(
-	D [][]int,
-	costs []int,
+// with per-run durations. As in the earlier labs, every run builds its own
+// candidate lists inside the timed section, so the durations include their
+// construction; the node locations are only needed by the geometric candidate
+// strategies.
+func RunLocalSearchBatch[W Weight](
+	D [][]W,
+	costs []W,
+	pts []Point,
 	m MethodSpec,
 	numSolutions int,
-) ([]Solution, []time.Duration) {
+) ([]Solution[W], []time.Duration) {
 	if numSolutions <= 0 {
 		return nil, nil
 	}
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	results := make([]Solution, 0, numSolutions)
+	results := make([]Solution[W], 0, numSolutions)
 	durations := make([]time.Duration, 0, numSolutions)
 
+	// one workspace is reused by all runs of the batch
+	ws := newLSWorkspace[W](len(D))
+
 	for r := 0; r < numSolutions; r++ {
 		init := startRandom(D, costs, rng)
 
 		start := time.Now()
-		var sol Solution
+		var cd CandData
 		if m.UseCand {
-			// candidate moves (2-opt + inter) with precomputed candidate lists
-			K := m.CandK
-			if K <= 0 {
-				K = 10
-			}
-			cd := buildCandidates(D, costs, K)
-			sol = localSearchSteepestCandidates(D, costs, init, cd)
-		} else if m.UseLM {
-			// steepest local search with list-of-moves (delta reuse)
-			sol = localSearchSteepestLM(D, costs, init)
-		} else {
-			// baseline steepest local search (full neighborhood, no LM)
-			sol = localSearchSteepestBaseline(D, costs, init)
+			// precomputed candidate lists for the selected strategy
+			cd = BuildCandidateData(m.CandStrategy, D, costs, pts, m.CandK)
 		}
-		results = append(results, sol)
+		path := append([]int(nil), init.Path...)
+		ws.run(m, D, costs, path, cd)
+		results = append(results, Solution[W]{Path: path, Objective: objective(D, costs, path)})
 		durations = append(durations, time.Since(start))
 	}
 	return results, durations
 }
+
+// LocalSearcher improves paths with the local search selected by a method
+// specification, so that other labs can use these kernels as an improvement
+// step. Candidate lists are built once and one workspace is reused by all
+// calls. The zero LocalSearcher runs the baseline steepest local search over
+// the full 2-opt and exchange neighborhood. A LocalSearcher is not safe for
+// concurrent use.
+type LocalSearcher[W Weight] struct {
+	m  MethodSpec
+	cd CandData
+	ws lsWorkspace[W]
+}
+
+// NewLocalSearcher prepares the local search of m for the instance. The node
+// locations are only needed by the geometric candidate strategies.
+func NewLocalSearcher[W Weight](m MethodSpec, D [][]W, costs []W, pts []Point) *LocalSearcher[W] {
+	s := &LocalSearcher[W]{m: m}
+	s.ws.resize(len(D))
+	if m.UseCand {
+		s.cd = BuildCandidateData(m.CandStrategy, D, costs, pts, m.CandK)
+	}
+	return s
+}
+
+// Improve improves path in place until no improving move is left.
+func (s *LocalSearcher[W]) Improve(D [][]W, costs []W, path []int) {
+	s.ws.run(s.m, D, costs, path, s.cd)
+}
+
+// run improves path in place with the kernel selected by the method
+// specification.
+func (ws *lsWorkspace[W]) run(m MethodSpec, D [][]W, costs []W, path []int, cd CandData) {
+	if m.UseCand && m.UseLM {
+		// candidate moves stored in a list-of-moves with incremental updates
+		ws.steepestCandLM(D, costs, path, cd)
+	} else if m.UseCand {
+		// candidate moves (2-opt + inter) with precomputed candidate lists
+		ws.steepestCandidates(D, costs, path, cd)
+	} else if m.UseLM {
+		// steepest local search with list-of-moves (delta reuse)
+		ws.steepestLM(D, costs, path)
+	} else {
+		// baseline steepest local search (full neighborhood, no LM)
+		ws.steepestBaseline(D, costs, path)
+	}
+}
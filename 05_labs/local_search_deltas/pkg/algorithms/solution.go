@@ -4,7 +4,7 @@ package algorithms
 
 // Solution represents a single TSP solution, including the path and its
 // objective value (tour length plus node costs).
-type Solution struct {
+type Solution[W Weight] struct {
 	Path      []int
-	Objective int
+	Objective W
 }
@@ -0,0 +1,361 @@
+package algorithms
+
+import (
+	"math/rand"
+	"time"
+)
+
+// TenureType selects how the tabu tenure is chosen.
+type TenureType int
+
+const (
+	// TenureFixed keeps the tenure at TenureMin.
+	TenureFixed TenureType = iota
+	// TenureRandom draws a tenure from [TenureMin, TenureMax] for every move.
+	TenureRandom
+	// TenureReactive starts at TenureMin, grows when the search revisits a
+	// solution and shrinks again after a period without repetitions.
+	TenureReactive
+)
+
+// TabuConfig holds configuration for Tabu Search.
+type TabuConfig struct {
+	TimeLimit      time.Duration // Time limit for the algorithm
+	MaxEvaluations int           // Maximum number of move evaluations (0 = unlimited)
+	Tenure         TenureType    // How the tenure is chosen (default fixed)
+	TenureMin      int           // Tenure for fixed, lower bound otherwise (default 10)
+	TenureMax      int           // Upper bound for random and reactive tenure (default 3*TenureMin)
+	UseCand        bool          // Restrict the neighborhood to candidate moves
+	CandK          int           // Candidate list size (default 10)
+	CandStrategy   CandStrategy  // How candidate lists are built (default: K nearest)
+	Seed           int64
+}
+
+// TabuResult contains the result of Tabu Search execution.
+type TabuResult[W Weight] struct {
+	BestSolution Solution[W]
+	Iterations   int // number of applied moves
+	Evaluations  int // number of evaluated moves
+	Duration     time.Duration
+}
+
+// tabuState stores the attribute-based tabu lists. Every entry holds the
+// iteration until which the attribute stays tabu:
+//   - edgeUntil[a*dim+b]: the removed edge (a, b) may not be added back,
+//   - droppedUntil[v]: the vertex v removed from the tour may not be inserted,
+//   - addedUntil[v]: the vertex v inserted into the tour may not be removed.
+type tabuState struct {
+	dim          int
+	edgeUntil    []int
+	droppedUntil []int
+	addedUntil   []int
+}
+
+func (ts *tabuState) edgeTabu(a, b, iter int) bool {
+	return ts.edgeUntil[a*ts.dim+b] > iter
+}
+
+func (ts *tabuState) forbidEdge(a, b, until int) {
+	ts.edgeUntil[a*ts.dim+b] = until
+	ts.edgeUntil[b*ts.dim+a] = until
+}
+
+// reactiveTenure implements the reactive tabu search tenure rule: the tenure
+// is increased whenever the current solution was already visited within the
+// last cycleWindow iterations and decreased after decayPeriod iterations
+// without such a repetition. Visits older than the window are evicted, so
+// lastSeen holds at most reactiveCycleWindow solutions.
+type reactiveTenure struct {
+	tenure     float64
+	min, max   float64
+	lastSeen   map[uint64]int
+	visits     [reactiveCycleWindow]tourVisit // ring of the visits in lastSeen, oldest first
+	first      int
+	count      int
+	lastChange int
+}
+
+// tourVisit records that the solution with the given hash was visited in
+// iteration iter.
+type tourVisit struct {
+	hash uint64
+	iter int
+}
+
+const (
+	reactiveIncrease    = 1.2
+	reactiveDecrease    = 0.9
+	reactiveCycleWindow = 500
+	reactiveDecayPeriod = 100
+)
+
+func (rt *reactiveTenure) update(hash uint64, iter int) {
+	for rt.count > 0 && iter-rt.visits[rt.first].iter >= reactiveCycleWindow {
+		old := rt.visits[rt.first]
+		if rt.lastSeen[old.hash] == old.iter {
+			delete(rt.lastSeen, old.hash)
+		}
+		rt.first = (rt.first + 1) % reactiveCycleWindow
+		rt.count--
+	}
+
+	if _, ok := rt.lastSeen[hash]; ok {
+		rt.tenure = min(rt.max, rt.tenure*reactiveIncrease+1)
+		rt.lastChange = iter
+	} else if iter-rt.lastChange > reactiveDecayPeriod {
+		rt.tenure = max(rt.min, rt.tenure*reactiveDecrease)
+		rt.lastChange = iter
+	}
+
+	// iterations are increasing, so the eviction above left room for one more
+	rt.lastSeen[hash] = iter
+	rt.visits[(rt.first+rt.count)%reactiveCycleWindow] = tourVisit{hash, iter}
+	rt.count++
+}
+
+// TabuSearch runs tabu search over 2-opt and exchange moves starting from a
+// local optimum of a random solution. In every iteration the best admissible
+// move is applied even if it worsens the solution. A move is tabu if it adds
+// back a recently removed edge, re-inserts a recently removed vertex or
+// removes a recently inserted one; tabu moves are admissible only if they lead
+// to a solution better than the best found so far (aspiration by objective).
+// The search stops when the time limit or the evaluation budget is exhausted.
+func TabuSearch[W Weight](D [][]W, costs []W, pts []Point, config TabuConfig) TabuResult[W] {
+	startTime := time.Now()
+	rng := rand.New(rand.NewSource(config.Seed))
+
+	if config.TimeLimit == 0 && config.MaxEvaluations == 0 {
+		config.TimeLimit = time.Second
+	}
+	if config.TenureMin <= 0 {
+		config.TenureMin = 10
+	}
+	if config.TenureMax < config.TenureMin {
+		config.TenureMax = 3 * config.TenureMin
+	}
+
+	dim := len(D)
+	var cd CandData
+	if config.UseCand {
+		cd = BuildCandidateData(config.CandStrategy, D, costs, pts, config.CandK)
+	}
+
+	// Start from a local optimum so that the tabu phase starts where a plain
+	// descent would stop.
+	path := startRandom(D, costs, rng).Path
+	ws := newLSWorkspace[W](dim)
+	if config.UseCand {
+		ws.steepestCandidates(D, costs, path, cd)
+	} else {
+		ws.steepestBaseline(D, costs, path)
+	}
+
+	current := objective(D, costs, path)
+	best := Solution[W]{Path: append([]int(nil), path...), Objective: current}
+
+	ts := &tabuState{
+		dim:          dim,
+		edgeUntil:    make([]int, dim*dim),
+		droppedUntil: make([]int, dim),
+		addedUntil:   make([]int, dim),
+	}
+	rt := &reactiveTenure{
+		tenure:   float64(config.TenureMin),
+		min:      float64(config.TenureMin),
+		max:      float64(config.TenureMax),
+		lastSeen: make(map[uint64]int),
+	}
+	var edgeHash []uint64
+	if config.Tenure == TenureReactive {
+		edgeHash = make([]uint64, dim*dim)
+		for a := 0; a < dim; a++ {
+			for b := a + 1; b < dim; b++ {
+				h := rng.Uint64()
+				edgeHash[a*dim+b], edgeHash[b*dim+a] = h, h
+			}
+		}
+	}
+
+	iterations, evaluations := 0, 0
+	for iter := 1; ; iter++ {
+		if config.TimeLimit > 0 && time.Since(startTime) >= config.TimeLimit {
+			break
+		}
+		if config.MaxEvaluations > 0 && evaluations >= config.MaxEvaluations {
+			break
+		}
+
+		var move lsMove[W]
+		var found bool
+		var evals int
+		if config.UseCand {
+			move, found, evals = ws.bestTabuMoveCandidates(ts, cd, iter, current, best.Objective)
+		} else {
+			move, found, evals = ws.bestTabuMove(ts, iter, current, best.Objective)
+		}
+		evaluations += evals
+		if evals == 0 {
+			break // empty neighborhood
+		}
+		if !found {
+			// every move is tabu; let the tabu lists age
+			continue
+		}
+
+		tenure := config.TenureMin
+		switch config.Tenure {
+		case TenureRandom:
+			tenure += rng.Intn(config.TenureMax - config.TenureMin + 1)
+		case TenureReactive:
+			tenure = int(rt.tenure)
+		}
+		ts.record(ws.path, move.kind, move.i, move.j, iter+tenure)
+
+		ws.apply(move)
+		current += move.delta
+		iterations++
+
+		if current < best.Objective {
+			best = Solution[W]{Path: append(best.Path[:0], path...), Objective: current}
+		}
+		if config.Tenure == TenureReactive {
+			rt.update(tourHash(path, edgeHash, dim), iter)
+		}
+	}
+
+	return TabuResult[W]{
+		BestSolution: best,
+		Iterations:   iterations,
+		Evaluations:  evaluations,
+		Duration:     time.Since(startTime),
+	}
+}
+
+// record makes the attributes of the move kind(i, j) tabu until the given
+// iteration. It has to be called before the move is applied to path.
+func (ts *tabuState) record(path []int, kind MoveType, i, j, until int) {
+	n := len(path)
+	switch kind {
+	case MoveTwoOpt:
+		ts.forbidEdge(path[i], path[nextIdx(i, n)], until)
+		ts.forbidEdge(path[j], path[nextIdx(j, n)], until)
+	case MoveExchangeSelected:
+		v := path[i]
+		ts.forbidEdge(path[prevIdx(i, n)], v, until)
+		ts.forbidEdge(v, path[nextIdx(i, n)], until)
+		ts.droppedUntil[v] = until
+		ts.addedUntil[j] = until
+	}
+}
+
+// tabuTwoOpt reports whether 2-opt(i, j) would add back a tabu edge.
+func (ts *tabuState) tabuTwoOpt(path []int, i, j, iter int) bool {
+	n := len(path)
+	return ts.edgeTabu(path[i], path[j], iter) ||
+		ts.edgeTabu(path[nextIdx(i, n)], path[nextIdx(j, n)], iter)
+}
+
+// tabuExchange reports whether replacing path[i] with u is tabu.
+func (ts *tabuState) tabuExchange(path []int, i, u, iter int) bool {
+	n := len(path)
+	return ts.droppedUntil[u] > iter || ts.addedUntil[path[i]] > iter ||
+		ts.edgeTabu(path[prevIdx(i, n)], u, iter) ||
+		ts.edgeTabu(u, path[nextIdx(i, n)], iter)
+}
+
+// bestTabuMove scans the full 2-opt and exchange neighborhood and returns the
+// best admissible move together with the number of evaluated moves.
+func (ws *lsWorkspace[W]) bestTabuMove(ts *tabuState, iter int, current, bestObj W) (lsMove[W], bool, int) {
+	path := ws.path
+	n := len(path)
+	aspiration := bestObj - current // a tabu move is admissible below this delta
+	best, found, evals := lsMove[W]{}, false, 0
+
+	consider := func(m lsMove[W], tabu bool) {
+		if (!tabu || m.delta < aspiration) && (!found || m.delta < best.delta) {
+			best, found = m, true
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		for j := i + 2; j < n; j++ {
+			if i == 0 && j == n-1 {
+				continue // adjacent edges
+			}
+			evals++
+			dl := DeltaTwoOpt(ws.D, path, i, j)
+			consider(lsMove[W]{kind: MoveTwoOpt, i: i, j: j, delta: dl}, ts.tabuTwoOpt(path, i, j, iter))
+		}
+	}
+	for i := 0; i < n; i++ {
+		for _, u := range ws.nonSel {
+			evals++
+			dl := DeltaExchangeSelected(ws.D, ws.costs, path, i, u)
+			consider(lsMove[W]{kind: MoveExchangeSelected, i: i, j: u, delta: dl}, ts.tabuExchange(path, i, u, iter))
+		}
+	}
+	return best, found, evals
+}
+
+// bestTabuMoveCandidates is bestTabuMove restricted to moves introducing at
+// least one candidate edge.
+func (ws *lsWorkspace[W]) bestTabuMoveCandidates(ts *tabuState, cd CandData, iter int, current, bestObj W) (lsMove[W], bool, int) {
+	path := ws.path
+	n := len(path)
+	aspiration := bestObj - current
+	best, found, evals := lsMove[W]{}, false, 0
+
+	consider := func(m lsMove[W], tabu bool) {
+		if (!tabu || m.delta < aspiration) && (!found || m.delta < best.delta) {
+			best, found = m, true
+		}
+	}
+	twoOpt := func(i, j int) {
+		if i == j || nextIdx(i, n) == j || nextIdx(j, n) == i {
+			return
+		}
+		evals++
+		dl := DeltaTwoOpt(ws.D, path, i, j)
+		consider(lsMove[W]{kind: MoveTwoOpt, i: i, j: j, delta: dl}, ts.tabuTwoOpt(path, i, j, iter))
+	}
+
+	for i := 0; i < n; i++ {
+		for _, v := range cd.CandList[path[i]] {
+			j := ws.posOf[v]
+			if j < 0 {
+				continue
+			}
+			// both 2-opt moves introducing the candidate edge (path[i], v)
+			twoOpt(i, j)
+			twoOpt(prevIdx(i, n), prevIdx(j, n))
+		}
+	}
+	for i := 0; i < n; i++ {
+		a := path[prevIdx(i, n)]
+		b := path[nextIdx(i, n)]
+		epoch := ws.nextEpoch()
+		for _, list := range [2][]int{cd.CandList[a], cd.CandList[b]} {
+			for _, u := range list {
+				if ws.visitMark[u] == epoch || ws.posOf[u] >= 0 {
+					continue
+				}
+				ws.visitMark[u] = epoch
+				evals++
+				dl := DeltaExchangeSelected(ws.D, ws.costs, path, i, u)
+				consider(lsMove[W]{kind: MoveExchangeSelected, i: i, j: u, delta: dl}, ts.tabuExchange(path, i, u, iter))
+			}
+		}
+	}
+	return best, found, evals
+}
+
+// tourHash returns an order-independent hash of the edge set of path, used
+// by the reactive tenure to detect revisited solutions.
+func tourHash(path []int, edgeHash []uint64, dim int) uint64 {
+	n := len(path)
+	var h uint64
+	for i, v := range path {
+		h ^= edgeHash[v*dim+path[nextIdx(i, n)]]
+	}
+	return h
+}
@@ -0,0 +1,41 @@
+package algorithms
+
+import "testing"
+
+func newTestReactiveTenure() *reactiveTenure {
+	return &reactiveTenure{tenure: 10, min: 10, max: 30, lastSeen: make(map[uint64]int)}
+}
+
+// TestReactiveTenureEvictsOldVisits checks that lastSeen stays bounded by
+// the cycle window while the search keeps visiting new solutions.
+func TestReactiveTenureEvictsOldVisits(t *testing.T) {
+	rt := newTestReactiveTenure()
+	for iter := 0; iter < 10*reactiveCycleWindow; iter++ {
+		rt.update(uint64(iter), iter)
+		if len(rt.lastSeen) > reactiveCycleWindow {
+			t.Fatalf("iteration %d: %d visits remembered, want at most %d", iter, len(rt.lastSeen), reactiveCycleWindow)
+		}
+	}
+	if rt.tenure != rt.min {
+		t.Errorf("tenure %v without repetitions, want %v", rt.tenure, rt.min)
+	}
+}
+
+// TestReactiveTenureDetectsCycles checks that a revisit within the window
+// increases the tenure and one outside of it does not.
+func TestReactiveTenureDetectsCycles(t *testing.T) {
+	rt := newTestReactiveTenure()
+	rt.update(1, 0)
+	rt.update(2, 1)
+	rt.update(1, reactiveCycleWindow-1)
+	if rt.tenure <= rt.min {
+		t.Fatalf("tenure %v after a revisit within the window, want it increased", rt.tenure)
+	}
+
+	rt = newTestReactiveTenure()
+	rt.update(1, 0)
+	rt.update(1, reactiveCycleWindow)
+	if rt.tenure != rt.min {
+		t.Errorf("tenure %v after a revisit outside the window, want %v", rt.tenure, rt.min)
+	}
+}
@@ -0,0 +1,83 @@
+package algorithms
+
+import "fmt"
+
+// VerifyMoves enables the delta verification mode: after every move applied
+// by a local search kernel the objective is recomputed from scratch and
+// compared with the objective before the move plus the move's delta (for LM
+// kernels, the delta stored in the list of moves), and the tour, the position
+// index and the unselected set are checked for consistency. Any mismatch
+// panics with a description of the move. The checks make every move O(n), so
+// the mode is meant for debugging and for the property checks only.
+var VerifyMoves bool
+
+// objectiveBeforeMove returns the objective of the bound path when
+// VerifyMoves is set, so that verifyMove can compare it with the objective
+// after the move.
+func (ws *lsWorkspace[W]) objectiveBeforeMove() W {
+	if !VerifyMoves {
+		return 0
+	}
+	return objective(ws.D, ws.costs, ws.path)
+}
+
+// verifyMove verifies an applied move when VerifyMoves is set. For 2-opt i and
+// j are the cut indices; for exchanges path[i] was replaced by vertex j.
+func (ws *lsWorkspace[W]) verifyMove(before, delta W, kind MoveType, i, j int) {
+	if !VerifyMoves {
+		return
+	}
+	move := "2-opt"
+	if kind == MoveExchangeSelected {
+		move = "exchange"
+	}
+	after := objective(ws.D, ws.costs, ws.path)
+	if !sameObjective(after, before+delta) {
+		panic(fmt.Sprintf("verify: %s(%d, %d) on tour of %d nodes: delta %v, but objective changed from %v to %v (by %v)",
+			move, i, j, len(ws.path), delta, before, after, after-before))
+	}
+	if err := ws.validate(); err != nil {
+		panic(fmt.Sprintf("verify: %s(%d, %d): %v", move, i, j, err))
+	}
+}
+
+// validate checks that the bound path is a tour of distinct vertices and that
+// the position index and the unselected set agree with it.
+func (ws *lsWorkspace[W]) validate() error {
+	if err := validateTour(len(ws.D), len(ws.path), ws.path); err != nil {
+		return err
+	}
+	for i, v := range ws.path {
+		if ws.posOf[v] != i {
+			return fmt.Errorf("posOf[%d] = %d, but the vertex is at position %d", v, ws.posOf[v], i)
+		}
+	}
+	if len(ws.nonSel) != len(ws.D)-len(ws.path) {
+		return fmt.Errorf("%d unselected vertices recorded, want %d", len(ws.nonSel), len(ws.D)-len(ws.path))
+	}
+	for k, u := range ws.nonSel {
+		if ws.posOf[u] != -1 || ws.nonSelIdx[u] != k {
+			return fmt.Errorf("unselected vertex %d: posOf %d, nonSelIdx %d, stored at %d", u, ws.posOf[u], ws.nonSelIdx[u], k)
+		}
+	}
+	return nil
+}
+
+// validateTour checks that path visits exactly want distinct vertices of an
+// instance with dim vertices.
+func validateTour(dim, want int, path []int) error {
+	if len(path) != want {
+		return fmt.Errorf("tour has %d vertices, want %d", len(path), want)
+	}
+	seen := make([]bool, dim)
+	for i, v := range path {
+		if v < 0 || v >= dim {
+			return fmt.Errorf("vertex %d at position %d is out of range [0, %d)", v, i, dim)
+		}
+		if seen[v] {
+			return fmt.Errorf("vertex %d is visited more than once", v)
+		}
+		seen[v] = true
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package algorithms
+
+// Selection tracks the vertices selected by a path: the position of every
+// vertex and the set of unselected vertices. The state is kept in sync
+// incrementally while 2-opt and exchange moves are applied through it, and
+// the buffers are reused across loads. A Selection is bound to a single path
+// at a time; its zero value is ready to be loaded.
+type Selection struct {
+	posOf     []int // position of every vertex in path, -1 if unselected
+	nonSel    []int // unselected vertices, in arbitrary order
+	nonSelIdx []int // index of every unselected vertex in nonSel, -1 if selected
+}
+
+// lsWorkspace holds the state shared by the local search kernels: the
+// selection state of the path, the list of moves and scratch buffers. The
+// state is kept in sync incrementally while moves are applied, and the
+// buffers are reused across calls, so a warmed-up workspace performs no heap
+// allocations inside the search loops.
+//
+// A workspace is bound to a single path at a time and is not safe for
+// concurrent use.
+type lsWorkspace[W Weight] struct {
+	D     [][]W
+	costs []W
+	path  []int
+	cd    CandData
+
+	Selection
+
+	lm lmState[W]
+
+	visitMark []int // epoch marks for de-duplicating vertices or positions
+	epoch     int
+	touched   []int // scratch list of positions affected by the last move
+
+	moves int // improving moves applied since the last load
+}
+
+// lsMove is the best move found while scanning a neighborhood. For 2-opt
+// moves i and j are the cut indices; for exchanges path[i] is replaced by the
+// unselected vertex j.
+type lsMove[W Weight] struct {
+	kind  MoveType
+	i, j  int
+	delta W
+}
+
+// newLSWorkspace creates a workspace for instances with dim vertices.
+func newLSWorkspace[W Weight](dim int) *lsWorkspace[W] {
+	ws := &lsWorkspace[W]{}
+	ws.resize(dim)
+	return ws
+}
+
+// resize adjusts the scratch buffers to dim vertices. It only allocates when
+// the workspace is used with a larger instance than before.
+func (ws *lsWorkspace[W]) resize(dim int) {
+	ws.Selection.resize(dim)
+	if cap(ws.visitMark) < dim {
+		ws.visitMark = make([]int, dim)
+		ws.touched = make([]int, 0, dim)
+	}
+	ws.visitMark = ws.visitMark[:dim]
+}
+
+// load binds the workspace to an instance and to the path that the kernels
+// will modify in place, rebuilding the selection state.
+func (ws *lsWorkspace[W]) load(D [][]W, costs []W, path []int) {
+	ws.resize(len(D))
+	ws.D, ws.costs, ws.path = D, costs, path
+	ws.moves = 0
+	ws.Load(len(D), path)
+}
+
+// resize adjusts the per-vertex buffers to dim vertices.
+func (s *Selection) resize(dim int) {
+	if cap(s.posOf) < dim {
+		s.posOf = make([]int, dim)
+		s.nonSel = make([]int, 0, dim)
+		s.nonSelIdx = make([]int, dim)
+	}
+	s.posOf = s.posOf[:dim]
+	s.nonSelIdx = s.nonSelIdx[:dim]
+}
+
+// Load rebuilds the position index and the unselected set of path for an
+// instance with dim vertices.
+func (s *Selection) Load(dim int, path []int) {
+	s.resize(dim)
+	for v := range s.posOf {
+		s.posOf[v] = -1
+	}
+	for i, v := range path {
+		s.posOf[v] = i
+	}
+	s.nonSel = s.nonSel[:0]
+	for u, p := range s.posOf {
+		if p >= 0 {
+			s.nonSelIdx[u] = -1
+			continue
+		}
+		s.nonSelIdx[u] = len(s.nonSel)
+		s.nonSel = append(s.nonSel, u)
+	}
+}
+
+// Pos returns the position of v in the path, or -1 if v is unselected.
+func (s *Selection) Pos(v int) int { return s.posOf[v] }
+
+// Unselected returns the unselected vertices in arbitrary order. The slice
+// is owned by the Selection and changes with every exchange.
+func (s *Selection) Unselected() []int { return s.nonSel }
+
+// ApplyTwoOpt performs 2-opt(i, j) on path keeping the position index in
+// sync.
+func (s *Selection) ApplyTwoOpt(path []int, i, j int) {
+	applyTwoOptAndUpdatePos(path, s.posOf, i, j)
+}
+
+// ApplyExchange replaces path[i] with the unselected vertex u keeping the
+// position index and the unselected set in sync.
+func (s *Selection) ApplyExchange(path []int, i, u int) {
+	v := path[i]
+	ApplyExchangeSelected(path, i, u)
+	s.posOf[v], s.posOf[u] = -1, i
+	k := s.nonSelIdx[u]
+	s.nonSel[k] = v
+	s.nonSelIdx[v], s.nonSelIdx[u] = k, -1
+}
+
+// nextEpoch starts a new round of visitMark de-duplication.
+func (ws *lsWorkspace[W]) nextEpoch() int {
+	ws.epoch++
+	return ws.epoch
+}
+
+// applyTwoOpt performs 2-opt(i, j) keeping the selection state in sync.
+func (ws *lsWorkspace[W]) applyTwoOpt(i, j int) {
+	ws.ApplyTwoOpt(ws.path, i, j)
+	ws.moves++
+}
+
+// applyExchange replaces path[i] with the unselected vertex u keeping the
+// selection state in sync.
+func (ws *lsWorkspace[W]) applyExchange(i, u int) {
+	ws.ApplyExchange(ws.path, i, u)
+	ws.moves++
+}
+
+// apply performs a move found by one of the scanning kernels.
+func (ws *lsWorkspace[W]) apply(m lsMove[W]) {
+	before := ws.objectiveBeforeMove()
+	switch m.kind {
+	case MoveTwoOpt:
+		ws.applyTwoOpt(m.i, m.j)
+	case MoveExchangeSelected:
+		ws.applyExchange(m.i, m.j)
+	}
+	ws.verifyMove(before, m.delta, m.kind, m.i, m.j)
+}
@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+
+	"github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
 )
 
 const outputDir = "output/results"
@@ -32,15 +34,23 @@ func intsToDashString(nums []int) string {
 
 // WriteResultsCSV writes aggregated experiment results for a single instance
 // to a CSV file under output/results.
-func WriteResultsCSV(instanceName string, rows []Row) error {
+func WriteResultsCSV[W algorithms.Weight](instanceName string, rows []Row[W]) error {
+	return writeResultsCSV(fmt.Sprintf("results_instance_%s.csv", instanceName), instanceName, rows)
+}
+
+// WriteExperimentResultsCSV writes the results of a separate experiment (e.g.
+// "aco") for a single instance to output/results/results_<experiment>_instance_<name>.csv,
+// so that it does not overwrite the main local search results.
+func WriteExperimentResultsCSV[W algorithms.Weight](experiment, instanceName string, rows []Row[W]) error {
+	return writeResultsCSV(fmt.Sprintf("results_%s_instance_%s.csv", experiment, instanceName), instanceName, rows)
+}
+
+func writeResultsCSV[W algorithms.Weight](baseName, instanceName string, rows []Row[W]) error {
 	if err := os.MkdirAll(outputDir, 0o755); err != nil {
 		return fmt.Errorf("make dir %s: %w", outputDir, err)
 	}
 
-	filename := filepath.Join(
-		outputDir,
-		fmt.Sprintf("results_instance_%s.csv", instanceName),
-	)
+	filename := filepath.Join(outputDir, baseName)
 
 	f, err := os.Create(filename)
 	if err != nil {
@@ -60,6 +70,9 @@ func WriteResultsCSV(instanceName string, rows []Row) error {
 		"max_objective",
 		"avg_time_ms",
 		"best_objective",
+		"lower_bound",
+		"best_gap_%",
+		"avg_gap_%",
 		"best_path",
 	}
 	if err := w.Write(header); err != nil {
@@ -68,17 +81,26 @@ func WriteResultsCSV(instanceName string, rows []Row) error {
 
 	for _, r := range rows {
 		avg4 := fmt.Sprintf("%.4f", r.AvgV)
-		avgSummary := fmt.Sprintf("%.4f (%d, %d)", r.AvgV, r.MinV, r.MaxV)
+		avgSummary := fmt.Sprintf("%.4f (%v, %v)", r.AvgV, r.MinV, r.MaxV)
+		var bound, bestGap, avgGap string
+		if r.LowerBound > 0 {
+			bound = fmt.Sprint(r.LowerBound)
+			bestGap = fmt.Sprintf("%.4f", GapPercent(float64(r.BestValue), r.LowerBound))
+			avgGap = fmt.Sprintf("%.4f", GapPercent(r.AvgV, r.LowerBound))
+		}
 
 		rec := []string{
 			instanceName,
 			r.Name,
 			avg4,       // avg_objective
 			avgSummary, // av(min,max)
-			strconv.Itoa(r.MinV),
-			strconv.Itoa(r.MaxV),
+			fmt.Sprint(r.MinV),
+			fmt.Sprint(r.MaxV),
 			fmt.Sprintf("%.2f", r.AvgTms),
-			strconv.Itoa(r.BestValue),
+			fmt.Sprint(r.BestValue),
+			bound,
+			bestGap,
+			avgGap,
 			intsToDashString(r.BestPath),
 		}
 		if err := w.Write(rec); err != nil {
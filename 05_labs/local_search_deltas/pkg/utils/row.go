@@ -2,13 +2,18 @@
 // CSV output and filename handling.
 package utils
 
+import "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+
 // Row represents a single row of aggregated experiment results.
-type Row struct {
+type Row[W algorithms.Weight] struct {
 	Name      string
 	AvgV      float64
-	MinV      int
-	MaxV      int
+	MinV      W
+	MaxV      W
 	AvgTms    float64
 	BestPath  []int
-	BestValue int
+	BestValue W
+	// LowerBound is a lower bound on the objective of the instance, used to
+	// report optimality gaps (0 = unknown).
+	LowerBound W
 }
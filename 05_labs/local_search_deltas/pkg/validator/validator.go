@@ -0,0 +1,160 @@
+// Package validator checks TSP solutions against an instance: the number of
+// selected nodes, node indices, duplicates and the claimed objective value.
+// It replaces the manual checks of solution_checker.xlsx.
+package validator
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Instance is a problem instance given by its distance matrix and node costs.
+type Instance struct {
+	D     [][]int
+	Costs []int
+}
+
+// Result is the outcome of validating a single tour. Objective is the
+// recomputed objective and is only meaningful if every node index is in
+// range.
+type Result struct {
+	Objective int
+	Problems  []string
+}
+
+// Valid reports whether no problem was found.
+func (r Result) Valid() bool { return len(r.Problems) == 0 }
+
+// RowResult is the outcome of validating the best_path of one row of a
+// results CSV.
+type RowResult struct {
+	Line     int
+	Instance string
+	Method   string
+	Result
+}
+
+// selectCount returns the number of nodes to select (50% rounded up).
+func selectCount(n int) int { return (n + 1) / 2 }
+
+// Validate checks that path selects selectCount(n) distinct nodes of the
+// instance and, if claimed is not negative, that its objective equals
+// claimed. All problems are reported, not only the first one.
+func Validate(inst Instance, path []int, claimed int) Result {
+	var res Result
+	n := len(inst.D)
+	if want := selectCount(n); len(path) != want {
+		res.Problems = append(res.Problems, fmt.Sprintf("tour has %d nodes, want %d of %d", len(path), want, n))
+	}
+
+	inRange := true
+	firstPos := make(map[int]int, len(path))
+	for i, v := range path {
+		if v < 0 || v >= n {
+			res.Problems = append(res.Problems, fmt.Sprintf("node %d at position %d is out of range [0, %d)", v, i, n))
+			inRange = false
+			continue
+		}
+		if j, ok := firstPos[v]; ok {
+			res.Problems = append(res.Problems, fmt.Sprintf("node %d is visited twice, at positions %d and %d", v, j, i))
+			continue
+		}
+		firstPos[v] = i
+	}
+	if !inRange || len(path) == 0 {
+		return res
+	}
+
+	for i, v := range path {
+		res.Objective += inst.D[v][path[(i+1)%len(path)]] + inst.Costs[v]
+	}
+	if claimed >= 0 && res.Objective != claimed {
+		res.Problems = append(res.Problems, fmt.Sprintf("objective is %d, claimed %d (difference %d)", res.Objective, claimed, claimed-res.Objective))
+	}
+	return res
+}
+
+// ParsePath parses a tour written as node indices separated by spaces or
+// commas, optionally enclosed in square brackets, e.g. [1 4 7].
+func ParsePath(s string) ([]int, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' || r == '\t' })
+	path := make([]int, 0, len(fields))
+	for _, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node index %q", f)
+		}
+		path = append(path, v)
+	}
+	return path, nil
+}
+
+// ValidateResultsCSV validates the best_path of every row of a results CSV
+// written by utils.WriteResultsCSV against the instance named in its
+// instance column, comparing the objective with the best_objective column.
+// Rows with an empty best_path are skipped.
+func ValidateResultsCSV(filename string, instances map[string]Instance) ([]RowResult, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", filename, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s: empty file", filename)
+	}
+
+	column := make(map[string]int)
+	for i, name := range records[0] {
+		column[name] = i
+	}
+	for _, name := range []string{"instance", "method", "best_objective", "best_path"} {
+		if _, ok := column[name]; !ok {
+			return nil, fmt.Errorf("%s: missing column %q", filename, name)
+		}
+	}
+
+	var results []RowResult
+	for i, record := range records[1:] {
+		line := i + 2
+		row := RowResult{
+			Line:     line,
+			Instance: record[column["instance"]],
+			Method:   record[column["method"]],
+		}
+		if strings.TrimSpace(record[column["best_path"]]) == "" {
+			continue
+		}
+
+		inst, ok := instances[row.Instance]
+		if !ok {
+			row.Problems = []string{fmt.Sprintf("unknown instance %q", row.Instance)}
+			results = append(results, row)
+			continue
+		}
+		path, err := ParsePath(record[column["best_path"]])
+		if err != nil {
+			row.Problems = []string{fmt.Sprintf("best_path: %v", err)}
+			results = append(results, row)
+			continue
+		}
+		claimed, err := strconv.Atoi(strings.TrimSpace(record[column["best_objective"]]))
+		if err != nil {
+			row.Problems = []string{fmt.Sprintf("best_objective: invalid value %q", record[column["best_objective"]])}
+			results = append(results, row)
+			continue
+		}
+		row.Result = Validate(inst, path, claimed)
+		results = append(results, row)
+	}
+	return results, nil
+}
@@ -0,0 +1,149 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testInstance has four nodes on a unit square with distance 1 between
+// neighbours and 2 along the diagonals; two nodes are selected.
+var testInstance = Instance{
+	D: [][]int{
+		{0, 1, 2, 1},
+		{1, 0, 1, 2},
+		{2, 1, 0, 1},
+		{1, 2, 1, 0},
+	},
+	Costs: []int{10, 20, 30, 40},
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      []int
+		claimed   int
+		objective int
+		problems  []string // substrings of the expected problems, in order
+	}{
+		{"valid", []int{0, 1}, 32, 32, nil},
+		{"claim not checked", []int{0, 2}, -1, 44, nil},
+		{"too few nodes", []int{0}, -1, 10, []string{"tour has 1 nodes, want 2 of 4"}},
+		{"too many nodes", []int{0, 1, 2}, -1, 64, []string{"tour has 3 nodes, want 2 of 4"}},
+		{"empty", nil, -1, 0, []string{"tour has 0 nodes"}},
+		{"out of range", []int{0, 4}, -1, 0, []string{"node 4 at position 1 is out of range [0, 4)"}},
+		{"negative", []int{-1, 0}, -1, 0, []string{"node -1 at position 0 is out of range"}},
+		{"duplicate", []int{1, 1}, -1, 40, []string{"node 1 is visited twice, at positions 0 and 1"}},
+		{"objective mismatch", []int{0, 1}, 30, 32, []string{"objective is 32, claimed 30 (difference -2)"}},
+		{"several problems", []int{2, 2, 9}, 5, 0, []string{
+			"tour has 3 nodes",
+			"node 2 is visited twice",
+			"node 9 at position 2 is out of range",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Validate(testInstance, tt.path, tt.claimed)
+			if len(res.Problems) != len(tt.problems) {
+				t.Fatalf("problems %q, want %d matching %q", res.Problems, len(tt.problems), tt.problems)
+			}
+			for i, want := range tt.problems {
+				if !strings.Contains(res.Problems[i], want) {
+					t.Errorf("problem %d is %q, want it to contain %q", i, res.Problems[i], want)
+				}
+			}
+			if res.Valid() != (len(tt.problems) == 0) {
+				t.Errorf("Valid() = %v with problems %q", res.Valid(), res.Problems)
+			}
+			if res.Objective != tt.objective {
+				t.Errorf("objective %d, want %d", res.Objective, tt.objective)
+			}
+		})
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []int
+		wantErr bool
+	}{
+		{"[1 4 7]", []int{1, 4, 7}, false},
+		{"1,4,7", []int{1, 4, 7}, false},
+		{" [ 1\t4 , 7 ] ", []int{1, 4, 7}, false},
+		{"[]", []int{}, false},
+		{"[1 x 7]", nil, true},
+		{"[1.5]", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePath(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePath(%q) error %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !slices.Equal(got, tt.want) {
+			t.Errorf("ParsePath(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValidateResultsCSV(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "results.csv")
+	content := `instance,method,best_objective,best_path
+A,valid,32,[0 1]
+A,mismatch,31,[0 1]
+A,skipped,0,
+B,unknown,32,[0 1]
+A,bad_path,32,[0 x]
+A,bad_objective,abc,[0 1]
+A,duplicate,40,[1 1]
+`
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := ValidateResultsCSV(file, map[string]Instance{"A": testInstance})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		line    int
+		method  string
+		problem string
+	}{
+		{2, "valid", ""},
+		{3, "mismatch", "objective is 32, claimed 31"},
+		{5, "unknown", `unknown instance "B"`},
+		{6, "bad_path", `best_path: invalid node index "x"`},
+		{7, "bad_objective", `best_objective: invalid value "abc"`},
+		{8, "duplicate", "node 1 is visited twice"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("%d rows, want %d: %+v", len(rows), len(want), rows)
+	}
+	for i, w := range want {
+		row := rows[i]
+		if row.Line != w.line || row.Method != w.method {
+			t.Errorf("row %d is line %d %s, want line %d %s", i, row.Line, row.Method, w.line, w.method)
+		}
+		if w.problem == "" {
+			if !row.Valid() {
+				t.Errorf("line %d: unexpected problems %q", row.Line, row.Problems)
+			}
+		} else if len(row.Problems) != 1 || !strings.Contains(row.Problems[0], w.problem) {
+			t.Errorf("line %d: problems %q, want one containing %q", row.Line, row.Problems, w.problem)
+		}
+	}
+}
+
+func TestValidateResultsCSVMissingColumn(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "results.csv")
+	if err := os.WriteFile(file, []byte("instance,method,best_objective\nA,m,1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ValidateResultsCSV(file, nil); err == nil || !strings.Contains(err.Error(), `missing column "best_path"`) {
+		t.Errorf("error %v, want a missing best_path column", err)
+	}
+}
@@ -13,7 +13,7 @@ import (
 	"gonum.org/v1/plot/vg"
 	"gonum.org/v1/plot/vg/draw"
 
-	"github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/data"
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
 )
 
 // PlotSolution draws a TSP path with (0,0) axes, correct arrowheads, square
@@ -54,8 +54,8 @@ func PlotSolution(nodes []data.Node, path []int, title string, filename string,
 	// --- Prepare path points ---
 	pathPoints := make(plotter.XYs, len(path)+1)
 	for i, idx := range path {
-		pathPoints[i].X = float64(nodes[idx].X)
-		pathPoints[i].Y = float64(nodes[idx].Y)
+		pathPoints[i].X = nodes[idx].X
+		pathPoints[i].Y = nodes[idx].Y
 	}
 	if len(path) > 0 {
 		pathPoints[len(path)] = pathPoints[0]
@@ -64,8 +64,8 @@ func PlotSolution(nodes []data.Node, path []int, title string, filename string,
 	// --- Prepare all node points ---
 	allPoints := make(plotter.XYs, len(nodes))
 	for i, n := range nodes {
-		allPoints[i].X = float64(n.X)
-		allPoints[i].Y = float64(n.Y)
+		allPoints[i].X = n.X
+		allPoints[i].Y = n.Y
 	}
 
 	// ---- Cost range  ----
@@ -173,22 +173,22 @@ func PlotSolution(nodes []data.Node, path []int, title string, filename string,
 }
 
 // Size scaling: map int cost -> radius in [minR, maxR].
-func scaleCostToRadius(cost, minCost, maxCost int, minR, maxR vg.Length) vg.Length {
+func scaleCostToRadius(cost, minCost, maxCost float64, minR, maxR vg.Length) vg.Length {
 	if maxCost == minCost {
 		return (minR + maxR) / 2
 	}
-	n := float64(cost-minCost) / float64(maxCost-minCost)
+	n := (cost - minCost) / (maxCost - minCost)
 	return minR + vg.Length(n)*(maxR-minR)
 }
 
 // Single-hue blue gradient (light -> dark) low: #c6dbef, high: #084594.
-func costToBlue(cost, minCost, maxCost int) color.RGBA {
+func costToBlue(cost, minCost, maxCost float64) color.RGBA {
 	low := color.RGBA{R: 0xC6, G: 0xDB, B: 0xEF, A: 0xFF}  // light blue
 	high := color.RGBA{R: 0x08, G: 0x45, B: 0x94, A: 0xFF} // dark blue
 	if maxCost == minCost {
 		return low
 	}
-	n := float64(cost-minCost) / float64(maxCost-minCost) // 0..1
+	n := (cost - minCost) / (maxCost - minCost) // 0..1
 	return lerpRGBA(low, high, n)
 }
 
@@ -1,13 +1,15 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
 	"time"
 
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
 	"github.com/czajkowskis/evolutionary_computation/06_labs/local_search_extensions/pkg/algorithms"
-	"github.com/czajkowskis/evolutionary_computation/06_labs/local_search_extensions/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/06_labs/local_search_extensions/pkg/utils"
 	"github.com/czajkowskis/evolutionary_computation/06_labs/local_search_extensions/pkg/visualisation"
 )
@@ -17,38 +19,42 @@ const (
 	numMSLSRuns   = 20  // Number of MSLS runs per instance
 	numMSLSStarts = 200 // Number of local search starts per MSLS run
 	numILSRuns    = 20  // Number of ILS runs per instance
+
+	numEliteSolutions = 8 // Best distinct local optima of a run relinked pairwise
 )
 
 // InstanceResults stores all results for a single instance
-type InstanceResults struct {
+type InstanceResults[W algorithms.Weight] struct {
 	Instance    string
-	MSLSResults []algorithms.MSLSResult
-	ILSResults  []algorithms.ILSResult
+	MSLSResults []algorithms.MSLSResult[W]
+	ILSResults  []algorithms.ILSResult[W]
 	AvgMSLSTime time.Duration
 }
 
 // processInstance runs the full experimental pipeline for a single instance
-func processInstance(instanceName string, nodes []data.Node) {
+func processInstance[W algorithms.Weight](instanceName string, nodes []data.Node, policy data.DistancePolicy) {
 	log.Printf("Processing instance %s with %d nodes", instanceName, len(nodes))
 	fmt.Printf("Instance %s Statistics:\n", instanceName)
 
-	D := data.CalculateDistanceMatrix(nodes)
-	costs := make([]int, len(nodes))
-	for i, node := range nodes {
-		costs[i] = node.Cost
+	D, costs, err := data.Weights[W](nodes, policy)
+	if err != nil {
+		log.Fatalf("Error computing distances: %v", err)
 	}
 
+	lb := deltas.LagrangianLowerBound(D, costs, deltas.LowerBoundConfig{Seed: time.Now().UnixNano()})
+	log.Printf("Lagrangian lower bound for instance %s: %v (%d iterations, %v)", instanceName, lb.Bound, lb.Iterations, lb.Duration)
+
 	numMSLSRuns := 20
 	numMSLSStarts := 200
 	numILSRuns := 20
 
-	var rows []utils.Row
+	var rows []utils.Row[W]
 
 	// === PHASE 1: Run MSLS ===
 	log.Printf("Starting MSLS for instance %s", instanceName)
 	start := time.Now()
 
-	var mslsResults []algorithms.MSLSResult
+	var mslsResults []algorithms.MSLSResult[W]
 	for run := 0; run < numMSLSRuns; run++ {
 		mslsResult := algorithms.RunMSLS(D, costs, numMSLSStarts)
 		mslsResults = append(mslsResults, mslsResult)
@@ -58,7 +64,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 	avgMSLSTime := totalMSLSTime / time.Duration(numMSLSRuns)
 
 	// Collect MSLS solutions for statistics
-	mslsSolutions := make([]algorithms.Solution, len(mslsResults))
+	mslsSolutions := make([]algorithms.Solution[W], len(mslsResults))
 	for i, r := range mslsResults {
 		mslsSolutions[i] = r.BestSolution
 	}
@@ -67,23 +73,24 @@ func processInstance(instanceName string, nodes []data.Node) {
 	avgMSLSTimeMs := float64(avgMSLSTime.Nanoseconds()) / 1e6
 	bestMSLS := algorithms.FindBestSolution(mslsSolutions)
 
-	rows = append(rows, utils.Row{
-		Name:      "MSLS",
-		AvgV:      mslsAvg,
-		MinV:      mslsMin,
-		MaxV:      mslsMax,
-		AvgTms:    avgMSLSTimeMs,
-		BestPath:  bestMSLS.Path,
-		BestValue: bestMSLS.Objective,
+	rows = append(rows, utils.Row[W]{
+		Name:       "MSLS",
+		AvgV:       mslsAvg,
+		MinV:       mslsMin,
+		MaxV:       mslsMax,
+		AvgTms:     avgMSLSTimeMs,
+		BestPath:   bestMSLS.Path,
+		BestValue:  bestMSLS.Objective,
+		LowerBound: lb.Bound,
 	})
 
-	log.Printf("Completed MSLS: best value %d, avg time %.2f ms", bestMSLS.Objective, avgMSLSTimeMs)
+	log.Printf("Completed MSLS: best value %v, avg time %.2f ms", bestMSLS.Objective, avgMSLSTimeMs)
 
 	// === PHASE 2: Run ILS ===
 	log.Printf("Starting ILS for instance %s with time limit %v", instanceName, avgMSLSTime)
 	perturbType := algorithms.PerturbRandom4Opt
 
-	var ilsResults []algorithms.ILSResult
+	var ilsResults []algorithms.ILSResult[W]
 	totalILSIterations := 0
 	for run := 0; run < numILSRuns; run++ {
 		ilsResult := algorithms.RunILS(D, costs, avgMSLSTime, perturbType)
@@ -92,7 +99,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 	}
 
 	// Collect ILS solutions for statistics
-	ilsSolutions := make([]algorithms.Solution, len(ilsResults))
+	ilsSolutions := make([]algorithms.Solution[W], len(ilsResults))
 	for i, r := range ilsResults {
 		ilsSolutions[i] = r.BestSolution
 	}
@@ -102,22 +109,177 @@ func processInstance(instanceName string, nodes []data.Node) {
 	avgLSIterations := float64(totalILSIterations) / float64(numILSRuns)
 	bestILS := algorithms.FindBestSolution(ilsSolutions)
 
-	rows = append(rows, utils.Row{
-		Name:      "ILS",
-		AvgV:      ilsAvg,
-		MinV:      ilsMin,
-		MaxV:      ilsMax,
-		AvgTms:    avgILSTimeMs,
-		BestPath:  bestILS.Path,
-		BestValue: bestILS.Objective,
+	rows = append(rows, utils.Row[W]{
+		Name:       "ILS",
+		AvgV:       ilsAvg,
+		MinV:       ilsMin,
+		MaxV:       ilsMax,
+		AvgTms:     avgILSTimeMs,
+		BestPath:   bestILS.Path,
+		BestValue:  bestILS.Objective,
+		LowerBound: lb.Bound,
 	})
 
-	log.Printf("Completed ILS: best value %d, avg LS iterations %.1f", bestILS.Objective, avgLSIterations)
+	log.Printf("Completed ILS: best value %v, avg LS iterations %.1f", bestILS.Objective, avgLSIterations)
+
+	// === PHASE 3: Run ILS with acceptance criteria beyond strict improvement ===
+	acceptanceTypes := []algorithms.AcceptanceType{
+		algorithms.AcceptRecordToRecord,
+		algorithms.AcceptThreshold,
+		algorithms.AcceptLateAcceptance,
+		algorithms.AcceptGreatDeluge,
+		algorithms.AcceptSimulatedAnnealing,
+	}
+	for _, acceptance := range acceptanceTypes {
+		name := fmt.Sprintf("ILS (%s)", acceptance)
+		log.Printf("Starting %s for instance %s", name, instanceName)
+
+		var accResults []algorithms.ILSResult[W]
+		totalAccIterations := 0
+		for run := 0; run < numILSRuns; run++ {
+			accResult := algorithms.RunILSWithConfig(D, costs, algorithms.ILSConfig{
+				TimeLimit:    avgMSLSTime,
+				Perturbation: perturbType,
+				Acceptance:   algorithms.AcceptanceConfig{Type: acceptance},
+			})
+			accResults = append(accResults, accResult)
+			totalAccIterations += accResult.NumLSIterations
+		}
+
+		accSolutions := make([]algorithms.Solution[W], len(accResults))
+		for i, r := range accResults {
+			accSolutions[i] = r.BestSolution
+		}
+
+		accMin, accMax, accAvg := utils.CalculateStatistics(accSolutions)
+		bestAcc := algorithms.FindBestSolution(accSolutions)
+
+		rows = append(rows, utils.Row[W]{
+			Name:       name,
+			AvgV:       accAvg,
+			MinV:       accMin,
+			MaxV:       accMax,
+			AvgTms:     avgILSTimeMs,
+			BestPath:   bestAcc.Path,
+			BestValue:  bestAcc.Objective,
+			LowerBound: lb.Bound,
+		})
+
+		log.Printf("Completed %s: best value %v, avg LS iterations %.1f", name, bestAcc.Objective,
+			float64(totalAccIterations)/float64(numILSRuns))
+	}
+
+	// === PHASE 4: Run ILS with adaptive perturbation strength, mixed schedules and restarts ===
+	variants := []struct {
+		name   string
+		config algorithms.ILSConfig
+	}{
+		{"ILS (reactive strength)", algorithms.ILSConfig{Perturbation: perturbType, ReactiveStrength: true}},
+		{"ILS (cyclic mix)", algorithms.ILSConfig{Schedule: algorithms.ScheduleCyclic}},
+		{"ILS (random mix, reactive)", algorithms.ILSConfig{Schedule: algorithms.ScheduleRandom, ReactiveStrength: true}},
+		{"ILS (restart)", algorithms.ILSConfig{Perturbation: perturbType, RestartAfter: 500}},
+	}
+	for _, v := range variants {
+		log.Printf("Starting %s for instance %s", v.name, instanceName)
+
+		var varResults []algorithms.ILSResult[W]
+		totalVarIterations, totalRestarts, strengthSum, strengthCount := 0, 0, 0, 0
+		for run := 0; run < numILSRuns; run++ {
+			config := v.config
+			config.TimeLimit = avgMSLSTime
+			varResult := algorithms.RunILSWithConfig(D, costs, config)
+			varResults = append(varResults, varResult)
+			totalVarIterations += varResult.NumLSIterations
+			totalRestarts += varResult.Restarts
+			for _, st := range varResult.StrengthTrace {
+				strengthSum += st
+			}
+			strengthCount += len(varResult.StrengthTrace)
+		}
+
+		varSolutions := make([]algorithms.Solution[W], len(varResults))
+		for i, r := range varResults {
+			varSolutions[i] = r.BestSolution
+		}
+
+		varMin, varMax, varAvg := utils.CalculateStatistics(varSolutions)
+		bestVar := algorithms.FindBestSolution(varSolutions)
+
+		rows = append(rows, utils.Row[W]{
+			Name:       v.name,
+			AvgV:       varAvg,
+			MinV:       varMin,
+			MaxV:       varMax,
+			AvgTms:     avgILSTimeMs,
+			BestPath:   bestVar.Path,
+			BestValue:  bestVar.Objective,
+			LowerBound: lb.Bound,
+		})
+
+		avgStrength := 0.0
+		if strengthCount > 0 {
+			avgStrength = float64(strengthSum) / float64(strengthCount)
+		}
+		log.Printf("Completed %s: best value %v, avg LS iterations %.1f, avg strength %.2f, avg restarts %.1f",
+			v.name, bestVar.Objective, float64(totalVarIterations)/float64(numILSRuns), avgStrength,
+			float64(totalRestarts)/float64(numILSRuns))
+	}
+
+	// === PHASE 5: Path relinking between the elite local optima of every MSLS and ILS run ===
+	pools := []struct {
+		name  string
+		pools [][]algorithms.Solution[W]
+	}{
+		{"MSLS", make([][]algorithms.Solution[W], len(mslsResults))},
+		{"ILS", make([][]algorithms.Solution[W], len(ilsResults))},
+	}
+	for i, r := range mslsResults {
+		pools[0].pools[i] = r.AllSolutions
+	}
+	for i, r := range ilsResults {
+		pools[1].pools[i] = r.AllSolutions
+	}
+	strategies := []algorithms.PRStrategy{
+		algorithms.PRForward,
+		algorithms.PRBackward,
+		algorithms.PRMixed,
+		algorithms.PRTruncated,
+	}
+	for _, p := range pools {
+		for _, strategy := range strategies {
+			name := fmt.Sprintf("%s + PR (%s)", p.name, strategy)
+			log.Printf("Starting %s for instance %s", name, instanceName)
+
+			prSolutions := make([]algorithms.Solution[W], len(p.pools))
+			var totalPRTime time.Duration
+			for i, pool := range p.pools {
+				prResult := algorithms.RelinkElite(D, costs, pool, numEliteSolutions, algorithms.PRConfig{Strategy: strategy})
+				prSolutions[i] = prResult.BestSolution
+				totalPRTime += prResult.Duration
+			}
+
+			prMin, prMax, prAvg := utils.CalculateStatistics(prSolutions)
+			bestPR := algorithms.FindBestSolution(prSolutions)
+
+			rows = append(rows, utils.Row[W]{
+				Name:       name,
+				AvgV:       prAvg,
+				MinV:       prMin,
+				MaxV:       prMax,
+				AvgTms:     float64(totalPRTime.Nanoseconds()) / 1e6 / float64(len(p.pools)),
+				BestPath:   bestPR.Path,
+				BestValue:  bestPR.Objective,
+				LowerBound: lb.Bound,
+			})
+
+			log.Printf("Completed %s: best value %v", name, bestPR.Objective)
+		}
+	}
 
 	// Print console output
 	fmt.Println("Objective value: av (min, max)")
 	for _, r := range rows {
-		fmt.Printf("%-34s  %.2f (%d, %d)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
+		fmt.Printf("%-34s  %.2f (%v, %v)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
 	}
 	fmt.Println()
 
@@ -126,6 +288,12 @@ func processInstance(instanceName string, nodes []data.Node) {
 		fmt.Printf("%-34s  %.4f\n", r.Name, r.AvgTms)
 	}
 	fmt.Printf("ILS - Average LS iterations per run: %.1f\n", avgLSIterations)
+	fmt.Println()
+
+	fmt.Printf("Gap to the lower bound %v [%%]: best, avg\n", lb.Bound)
+	for _, r := range rows {
+		fmt.Printf("%-34s  %.2f, %.2f\n", r.Name, utils.GapPercent(float64(r.BestValue), lb.Bound), utils.GapPercent(r.AvgV, lb.Bound))
+	}
 
 	// Save CSV
 	if err := utils.WriteResultsCSV(instanceName, rows); err != nil {
@@ -149,6 +317,19 @@ func processInstance(instanceName string, nodes []data.Node) {
 }
 
 func main() {
+	rounding := flag.String("rounding", "round", "distance and cost rounding: round, ceil, floor or exact")
+	scale := flag.Float64("scale", 1, "fixed-point factor applied to distances and costs before rounding")
+	flag.Parse()
+
+	policy, err := data.ParseDistancePolicy(*rounding, *scale)
+	if err != nil {
+		log.Fatalf("Invalid distance policy: %v", err)
+	}
+	run := processInstance[int]
+	if policy.Rounding == data.RoundExact {
+		run = processInstance[float64]
+	}
+
 	rand.Seed(time.Now().UnixNano())
 	log.Println("Starting MSLS vs ILS local search experiments")
 
@@ -161,9 +342,9 @@ func main() {
 		log.Fatalf("Error reading TSPB.csv: %v", err)
 	}
 
-	processInstance("A", nodesA)
+	run("A", nodesA, policy)
 	fmt.Println()
-	processInstance("B", nodesB)
+	run("B", nodesB, policy)
 
 	log.Println("Program execution completed")
 }
@@ -0,0 +1,137 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+)
+
+// AcceptanceType selects the criterion deciding whether a new solution
+// replaces the current one in ILS and LNS.
+type AcceptanceType int
+
+const (
+	// AcceptImprovement accepts only strictly better solutions.
+	AcceptImprovement AcceptanceType = iota
+	// AcceptRecordToRecord accepts solutions within Deviation of the best
+	// (record) objective.
+	AcceptRecordToRecord
+	// AcceptThreshold accepts solutions worse than the current one by at most
+	// a relative threshold that decreases linearly from Threshold to 0 over
+	// the budget.
+	AcceptThreshold
+	// AcceptLateAcceptance accepts solutions not worse than the current one
+	// or than the current solution of HistoryLength iterations ago (late
+	// acceptance hill climbing).
+	AcceptLateAcceptance
+	// AcceptGreatDeluge accepts solutions below a water level that starts
+	// Deviation above the initial objective and falls towards the best
+	// objective by RainSpeed of the difference every iteration.
+	AcceptGreatDeluge
+	// AcceptSimulatedAnnealing accepts a worse solution with probability
+	// exp(-delta/T), with T cooled geometrically from InitialTemp to
+	// InitialTemp/100 over the budget.
+	AcceptSimulatedAnnealing
+)
+
+func (a AcceptanceType) String() string {
+	switch a {
+	case AcceptRecordToRecord:
+		return "rrt"
+	case AcceptThreshold:
+		return "threshold"
+	case AcceptLateAcceptance:
+		return "lahc"
+	case AcceptGreatDeluge:
+		return "great_deluge"
+	case AcceptSimulatedAnnealing:
+		return "sa"
+	default:
+		return "improvement"
+	}
+}
+
+// AcceptanceConfig holds the acceptance criterion and its parameters. Zero
+// values select the defaults given in the comments.
+type AcceptanceConfig struct {
+	Type          AcceptanceType
+	Deviation     float64 // Record-to-record deviation and initial great deluge level, relative (default 0.02)
+	Threshold     float64 // Initial relative threshold of threshold accepting (default 0.02)
+	HistoryLength int     // Late acceptance history length (default 50)
+	RainSpeed     float64 // Fraction of the gap between the level and the best objective removed per iteration (default 0.01)
+	InitialTemp   float64 // Initial SA temperature (default 0.5% of the initial objective)
+}
+
+// Acceptor applies an acceptance criterion and keeps the state it needs
+// between iterations. It is shared with the LNS of lab 07.
+type Acceptor[W Weight] struct {
+	config  AcceptanceConfig
+	history []W     // late acceptance history
+	iter    int     // number of decisions made
+	level   float64 // great deluge water level
+}
+
+// NewAcceptor creates an Acceptor for a search starting from a solution with
+// the initial objective.
+func NewAcceptor[W Weight](config AcceptanceConfig, initial W) *Acceptor[W] {
+	if config.Deviation <= 0 {
+		config.Deviation = 0.02
+	}
+	if config.Threshold <= 0 {
+		config.Threshold = 0.02
+	}
+	if config.HistoryLength <= 0 {
+		config.HistoryLength = 50
+	}
+	if config.RainSpeed <= 0 || config.RainSpeed >= 1 {
+		config.RainSpeed = 0.01
+	}
+	if config.InitialTemp <= 0 {
+		config.InitialTemp = 0.005 * float64(initial)
+	}
+
+	a := &Acceptor[W]{config: config}
+	switch config.Type {
+	case AcceptLateAcceptance:
+		a.history = make([]W, config.HistoryLength)
+		for i := range a.history {
+			a.history[i] = initial
+		}
+	case AcceptGreatDeluge:
+		a.level = float64(initial) * (1 + config.Deviation)
+	}
+	return a
+}
+
+// Accept reports whether candidate replaces current, given the best
+// objective found so far and the consumed fraction of the budget.
+func (a *Acceptor[W]) Accept(candidate, current, best W, progress float64, rng *rand.Rand) bool {
+	a.iter++
+	switch a.config.Type {
+	case AcceptRecordToRecord:
+		return float64(candidate) <= float64(best)*(1+a.config.Deviation)
+	case AcceptThreshold:
+		threshold := a.config.Threshold * math.Max(0, 1-progress)
+		return float64(candidate-current) <= threshold*float64(current)
+	case AcceptLateAcceptance:
+		idx := a.iter % len(a.history)
+		ok := candidate <= current || candidate <= a.history[idx]
+		if ok {
+			a.history[idx] = candidate
+		} else {
+			a.history[idx] = current
+		}
+		return ok
+	case AcceptGreatDeluge:
+		ok := candidate < current || float64(candidate) <= a.level
+		a.level -= a.config.RainSpeed * (a.level - float64(best))
+		return ok
+	case AcceptSimulatedAnnealing:
+		if candidate <= current {
+			return true
+		}
+		temp := a.config.InitialTemp * math.Pow(0.01, math.Min(1, progress))
+		return rng.Float64() < math.Exp(-float64(candidate-current)/temp)
+	default:
+		return candidate < current
+	}
+}
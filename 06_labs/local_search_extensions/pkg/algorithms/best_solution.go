@@ -1,8 +1,8 @@
 package algorithms
 
-func FindBestSolution(solutions []Solution) Solution {
+func FindBestSolution[W Weight](solutions []Solution[W]) Solution[W] {
 	if len(solutions) == 0 {
-		return Solution{}
+		return Solution[W]{}
 	}
 	bestSolution := solutions[0]
 	for _, sol := range solutions {
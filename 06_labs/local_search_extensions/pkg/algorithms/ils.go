@@ -3,14 +3,18 @@ package algorithms
 import (
 	"math/rand"
 	"time"
+
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
 )
 
 // ILSResult contains results from ILS run
-type ILSResult struct {
-	BestSolution    Solution
+type ILSResult[W Weight] struct {
+	BestSolution    Solution[W]
 	NumLSIterations int
 	Elapsed         time.Duration
-	AllSolutions    []Solution
+	AllSolutions    []Solution[W]
+	StrengthTrace   []int // perturbation strength used in every iteration
+	Restarts        int   // number of restarts on stagnation
 }
 
 // PerturbationType defines the type of perturbation
@@ -22,27 +26,41 @@ const (
 	PerturbPathDestroy
 )
 
-// applyPerturbation applies a perturbation to escape local optimum
-func applyPerturbation(D [][]int, costs []int, sol Solution, perturbType PerturbationType, rng *rand.Rand) Solution {
+// PerturbationSchedule defines how the perturbation type is chosen in every
+// iteration of ILS.
+type PerturbationSchedule int
+
+const (
+	// ScheduleFixed always applies ILSConfig.Perturbation.
+	ScheduleFixed PerturbationSchedule = iota
+	// ScheduleCyclic applies ILSConfig.Perturbations in turn.
+	ScheduleCyclic
+	// ScheduleRandom draws one of ILSConfig.Perturbations uniformly.
+	ScheduleRandom
+)
+
+// applyPerturbation applies a perturbation to escape local optimum. Strength
+// 1 is the standard perturbation; every further level makes it larger.
+func applyPerturbation[W Weight](D [][]W, costs []W, sol Solution[W], perturbType PerturbationType, strength int, rng *rand.Rand) Solution[W] {
 	switch perturbType {
 	case PerturbDoubleExchange:
-		return perturbDoubleExchange(D, costs, sol, rng)
+		return perturbDoubleExchange(D, costs, sol, strength, rng)
 	case PerturbRandom4Opt:
-		return perturbRandom4Opt(D, costs, sol, rng)
+		return perturbRandom4Opt(D, costs, sol, strength, rng)
 	case PerturbPathDestroy:
-		return perturbPathDestroy(D, costs, sol, rng)
+		return perturbPathDestroy(D, costs, sol, strength, rng)
 	default:
-		return perturbDoubleExchange(D, costs, sol, rng)
+		return perturbDoubleExchange(D, costs, sol, strength, rng)
 	}
 }
 
-// perturbDoubleExchange - Exchange 2 pairs of selected/non-selected nodes
-func perturbDoubleExchange(D [][]int, costs []int, sol Solution, rng *rand.Rand) Solution {
+// perturbDoubleExchange - Exchange 2*strength pairs of selected/non-selected nodes
+func perturbDoubleExchange[W Weight](D [][]W, costs []W, sol Solution[W], strength int, rng *rand.Rand) Solution[W] {
 	path := append([]int(nil), sol.Path...)
 	n := len(path)
 
 	if n < 2 {
-		return Solution{Path: path, Objective: objective(D, costs, path)}
+		return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 	}
 
 	inSel := make([]bool, len(D))
@@ -57,10 +75,10 @@ func perturbDoubleExchange(D [][]int, costs []int, sol Solution, rng *rand.Rand)
 	}
 
 	if len(nonSel) < 2 {
-		return Solution{Path: path, Objective: objective(D, costs, path)}
+		return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 	}
 
-	numExchanges := 2
+	numExchanges := 2 * strength
 	if n < 2 {
 		numExchanges = 1
 	}
@@ -85,19 +103,19 @@ func perturbDoubleExchange(D [][]int, costs []int, sol Solution, rng *rand.Rand)
 		nonSel = newNonSel
 	}
 
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
-// perturbRandom4Opt - Apply multiple random 2-opt moves
-func perturbRandom4Opt(D [][]int, costs []int, sol Solution, rng *rand.Rand) Solution {
+// perturbRandom4Opt - Apply multiple random 2-opt moves (2-3 per strength level)
+func perturbRandom4Opt[W Weight](D [][]W, costs []W, sol Solution[W], strength int, rng *rand.Rand) Solution[W] {
 	path := append([]int(nil), sol.Path...)
 	n := len(path)
 
 	if n < 4 {
-		return perturbDoubleExchange(D, costs, sol, rng)
+		return perturbDoubleExchange(D, costs, sol, strength, rng)
 	}
 
-	numMoves := 2 + rng.Intn(2)
+	numMoves := (2 + rng.Intn(2)) * strength
 
 	for m := 0; m < numMoves; m++ {
 		i := rng.Intn(n)
@@ -106,20 +124,21 @@ func perturbRandom4Opt(D [][]int, costs []int, sol Solution, rng *rand.Rand) Sol
 			i, j = j, i
 		}
 		if j-i > 1 && j-i < n-1 {
-			applyTwoOpt(path, i, j)
+			deltas.ApplyTwoOpt(path, i, j)
 		}
 	}
 
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
-// perturbPathDestroy - Destroy 25-30% of path and reconstruct randomly
-func perturbPathDestroy(D [][]int, costs []int, sol Solution, rng *rand.Rand) Solution {
+// perturbPathDestroy - Destroy 25% of path (5% more per further strength
+// level, up to 50%) and reconstruct randomly
+func perturbPathDestroy[W Weight](D [][]W, costs []W, sol Solution[W], strength int, rng *rand.Rand) Solution[W] {
 	path := append([]int(nil), sol.Path...)
 	n := len(path)
 
 	if n < 4 {
-		return perturbDoubleExchange(D, costs, sol, rng)
+		return perturbDoubleExchange(D, costs, sol, strength, rng)
 	}
 
 	numRemove := n / 4
@@ -129,6 +148,9 @@ func perturbPathDestroy(D [][]int, costs []int, sol Solution, rng *rand.Rand) So
 	if numRemove > n/3 {
 		numRemove = n / 3
 	}
+	if strength > 1 {
+		numRemove = min(numRemove+(strength-1)*n/20, n/2)
+	}
 
 	inSel := make([]bool, len(D))
 	for _, v := range path {
@@ -165,33 +187,124 @@ func perturbPathDestroy(D [][]int, costs []int, sol Solution, rng *rand.Rand) So
 		}
 	}
 
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
-// RunILS - Iterated Local Search
-func RunILS(D [][]int, costs []int, timeLimit time.Duration, perturbType PerturbationType) ILSResult {
+// ILSConfig holds configuration for Iterated Local Search.
+type ILSConfig struct {
+	TimeLimit     time.Duration        // Time limit for the algorithm
+	Perturbation  PerturbationType     // Perturbation applied to the current solution (ScheduleFixed)
+	Schedule      PerturbationSchedule // How the perturbation is chosen in every iteration
+	Perturbations []PerturbationType   // Perturbations of the cyclic and random schedules (default: all)
+	Acceptance    AcceptanceConfig     // When the new local optimum replaces the current solution (default: strict improvement)
+
+	// Reactive strength: the strength starts at MinStrength, grows by one
+	// after every StagnationLimit iterations without improving the current
+	// solution (up to MaxStrength) and is reset on improvement.
+	ReactiveStrength bool
+	MinStrength      int // Initial and fixed strength (default 1)
+	MaxStrength      int // Largest reactive strength (default 5)
+	StagnationLimit  int // Non-improving iterations before the strength grows (default 10)
+
+	RestartAfter int   // Restart from a new random local optimum after this many iterations without a new best (0 = never)
+	Seed         int64 // Random seed (0 = seed from the clock)
+}
+
+// RunILS - Iterated Local Search accepting only improving solutions
+func RunILS[W Weight](D [][]W, costs []W, timeLimit time.Duration, perturbType PerturbationType) ILSResult[W] {
+	return RunILSWithConfig(D, costs, ILSConfig{TimeLimit: timeLimit, Perturbation: perturbType})
+}
+
+// RunILSWithConfig runs Iterated Local Search: the current solution is
+// perturbed and improved by steepest local search, and the new local optimum
+// replaces the current solution if the configured acceptance criterion
+// accepts it. The best solution is tracked separately from the current one.
+// The perturbation type follows the configured schedule and its strength is
+// fixed or reactive; with RestartAfter set, the search restarts from a new
+// random local optimum when the best solution stagnates.
+func RunILSWithConfig[W Weight](D [][]W, costs []W, config ILSConfig) ILSResult[W] {
 	startTime := time.Now()
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+	timeLimit := config.TimeLimit
+
+	if len(config.Perturbations) == 0 {
+		config.Perturbations = []PerturbationType{PerturbDoubleExchange, PerturbRandom4Opt, PerturbPathDestroy}
+	}
+	if config.MinStrength <= 0 {
+		config.MinStrength = 1
+	}
+	if config.MaxStrength < config.MinStrength {
+		config.MaxStrength = max(config.MinStrength, 5)
+	}
+	if config.StagnationLimit <= 0 {
+		config.StagnationLimit = 10
+	}
 
 	current := startRandom(D, costs, rng)
 	current = localSearchSteepestBaseline(D, costs, current)
 
 	bestSolution := current
 	numLSIterations := 1
-	allSolutions := []Solution{current}
+	allSolutions := []Solution[W]{current}
+	acc := NewAcceptor(config.Acceptance, current.Objective)
+
+	strength := config.MinStrength
+	var strengthTrace []int
+	sinceImprovement, sinceBest, restarts := 0, 0, 0
+
+	for iter := 0; time.Since(startTime) < timeLimit; iter++ {
+		perturbType := config.Perturbation
+		switch config.Schedule {
+		case ScheduleCyclic:
+			perturbType = config.Perturbations[iter%len(config.Perturbations)]
+		case ScheduleRandom:
+			perturbType = config.Perturbations[rng.Intn(len(config.Perturbations))]
+		}
 
-	for time.Since(startTime) < timeLimit {
-		perturbed := applyPerturbation(D, costs, current, perturbType, rng)
+		perturbed := applyPerturbation(D, costs, current, perturbType, strength, rng)
 		localOpt := localSearchSteepestBaseline(D, costs, perturbed)
 		numLSIterations++
 		allSolutions = append(allSolutions, localOpt)
+		strengthTrace = append(strengthTrace, strength)
 
-		if localOpt.Objective < current.Objective {
+		improved := localOpt.Objective < current.Objective
+		progress := float64(time.Since(startTime)) / float64(timeLimit)
+		if acc.Accept(localOpt.Objective, current.Objective, bestSolution.Objective, progress, rng) {
 			current = localOpt
 		}
 
 		if localOpt.Objective < bestSolution.Objective {
 			bestSolution = localOpt
+			sinceBest = 0
+		} else {
+			sinceBest++
+		}
+
+		if config.ReactiveStrength {
+			if improved {
+				strength = config.MinStrength
+				sinceImprovement = 0
+			} else if sinceImprovement++; sinceImprovement >= config.StagnationLimit {
+				strength = min(strength+1, config.MaxStrength)
+				sinceImprovement = 0
+			}
+		}
+
+		if config.RestartAfter > 0 && sinceBest >= config.RestartAfter && time.Since(startTime) < timeLimit {
+			current = localSearchSteepestBaseline(D, costs, startRandom(D, costs, rng))
+			numLSIterations++
+			allSolutions = append(allSolutions, current)
+			acc = NewAcceptor(config.Acceptance, current.Objective)
+			strength = config.MinStrength
+			sinceImprovement, sinceBest = 0, 0
+			restarts++
+			if current.Objective < bestSolution.Objective {
+				bestSolution = current
+			}
 		}
 
 		if time.Since(startTime) >= timeLimit {
@@ -200,10 +313,12 @@ func RunILS(D [][]int, costs []int, timeLimit time.Duration, perturbType Perturb
 	}
 
 	elapsed := time.Since(startTime)
-	return ILSResult{
+	return ILSResult[W]{
 		BestSolution:    bestSolution,
 		NumLSIterations: numLSIterations,
 		Elapsed:         elapsed,
 		AllSolutions:    allSolutions,
+		StrengthTrace:   strengthTrace,
+		Restarts:        restarts,
 	}
 }
@@ -6,16 +6,16 @@ import (
 )
 
 // MSLSResult contains the results of MSLS algorithm
-type MSLSResult struct {
-	BestSolution    Solution
+type MSLSResult[W Weight] struct {
+	BestSolution    Solution[W]
 	NumLSIterations int
 	Elapsed         time.Duration
-	AllSolutions    []Solution
+	AllSolutions    []Solution[W]
 }
 
 // MSLS performs Multiple Start Local Search
 // It runs steepest local search multiple times (200 iterations) from random starting solutions
-func MSLS(D [][]int, costs []int, iterations int) MSLSResult {
+func MSLS[W Weight](D [][]W, costs []W, iterations int) MSLSResult[W] {
 	startTime := time.Now()
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 
@@ -24,7 +24,7 @@ func MSLS(D [][]int, costs []int, iterations int) MSLSResult {
 	initialSolution = localSearchSteepestBaseline(D, costs, initialSolution)
 
 	bestSolution := initialSolution
-	allSolutions := []Solution{initialSolution}
+	allSolutions := []Solution[W]{initialSolution}
 
 	// Run remaining iterations
 	for i := 1; i < iterations; i++ {
@@ -42,13 +42,13 @@ func MSLS(D [][]int, costs []int, iterations int) MSLSResult {
 
 	elapsed := time.Since(startTime)
 
-	return MSLSResult{
+	return MSLSResult[W]{
 		BestSolution:    bestSolution,
 		NumLSIterations: iterations,
 		Elapsed:         elapsed,
 		AllSolutions:    allSolutions,
 	}
 }
-func RunMSLS(D [][]int, costs []int, numMSLSStarts int) MSLSResult {
+func RunMSLS[W Weight](D [][]W, costs []W, numMSLSStarts int) MSLSResult[W] {
 	return MSLS(D, costs, numMSLSStarts)
 }
@@ -0,0 +1,299 @@
+package algorithms
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+)
+
+// PRStrategy selects the direction of path relinking between two solutions.
+type PRStrategy int
+
+const (
+	// PRForward walks from the worse solution toward the better one.
+	PRForward PRStrategy = iota
+	// PRBackward walks from the better solution toward the worse one, so
+	// the neighbourhood of the better solution is explored more closely.
+	PRBackward
+	// PRMixed walks alternately from both ends until they meet.
+	PRMixed
+	// PRTruncated is backward relinking stopped after Truncation of the
+	// initial distance.
+	PRTruncated
+)
+
+func (s PRStrategy) String() string {
+	switch s {
+	case PRBackward:
+		return "backward"
+	case PRMixed:
+		return "mixed"
+	case PRTruncated:
+		return "truncated"
+	default:
+		return "forward"
+	}
+}
+
+// PRConfig holds configuration for path relinking.
+type PRConfig struct {
+	Strategy       PRStrategy
+	Truncation     float64 // Fraction of the distance walked by PRTruncated (default 0.5)
+	LocalSearchTop int     // Best intermediate solutions improved by local search (default 3)
+}
+
+// PRResult contains the result of relinking a pool of solutions.
+type PRResult[W Weight] struct {
+	BestSolution Solution[W]
+	Pairs        int // number of relinked pairs
+	Duration     time.Duration
+}
+
+// prGuide describes the guiding solution: its nodes and, for each of them,
+// its two neighbours on the guiding cycle.
+type prGuide struct {
+	inSel      []bool
+	next, prev []int
+}
+
+func newPRGuide(dim int, path []int) prGuide {
+	g := prGuide{inSel: make([]bool, dim), next: make([]int, dim), prev: make([]int, dim)}
+	n := len(path)
+	for i, v := range path {
+		g.inSel[v] = true
+		g.next[v] = path[nextIdx(i, n)]
+		g.prev[v] = path[prevIdx(i, n)]
+	}
+	return g
+}
+
+func (g prGuide) hasEdge(a, b int) bool {
+	return g.inSel[a] && (g.next[a] == b || g.prev[a] == b)
+}
+
+// relinkDistance returns the number of nodes of a missing from b plus the
+// number of edges of b missing from a; it is zero only for equal cycles.
+func relinkDistance(dim int, a, b []int) int {
+	ga := newPRGuide(dim, a)
+	gb := newPRGuide(dim, b)
+	dist := 0
+	for _, v := range a {
+		if !gb.inSel[v] {
+			dist++
+		}
+	}
+	for i, v := range b {
+		if w := b[nextIdx(i, len(b))]; !ga.hasEdge(v, w) {
+			dist++
+		}
+	}
+	return dist
+}
+
+// relinkStep applies to path the move that brings it closer to guide with
+// the best objective delta. Exchanges replace a node missing from guide by a
+// guide node; 2-opt moves must increase the number of guide edges in path.
+// It returns false when path has reached guide.
+func relinkStep[W Weight](D [][]W, costs []W, path []int, guide prGuide, inSel []bool, pos []int) bool {
+	n := len(path)
+	for i, v := range path {
+		pos[v] = i
+	}
+
+	var bestDelta W
+	bestI, bestJ, bestExchange := -1, -1, false
+
+	// exchanges: path[i] not in guide -> u in guide but not in path
+	for i, v := range path {
+		if guide.inSel[v] {
+			continue
+		}
+		for u := range guide.inSel {
+			if !guide.inSel[u] || inSel[u] {
+				continue
+			}
+			if dl := deltas.DeltaExchangeSelected(D, costs, path, i, u); bestI < 0 || dl < bestDelta {
+				bestDelta, bestI, bestJ, bestExchange = dl, i, u, true
+			}
+		}
+	}
+
+	// 2-opt moves adding a guide edge (a, c), only once the node sets agree
+	if bestI < 0 && n >= 4 {
+		gain := func(i, j int) int {
+			a, b := path[i], path[nextIdx(i, n)]
+			c, d := path[j], path[nextIdx(j, n)]
+			g := 0
+			for _, e := range [4][3]int{{a, c, 1}, {b, d, 1}, {a, b, -1}, {c, d, -1}} {
+				if guide.hasEdge(e[0], e[1]) {
+					g += e[2]
+				}
+			}
+			return g
+		}
+		try := func(i, j int) {
+			if i == j || nextIdx(i, n) == j || nextIdx(j, n) == i {
+				return
+			}
+			if gain(i, j) <= 0 {
+				return
+			}
+			if dl := deltas.DeltaTwoOpt(D, path, i, j); bestI < 0 || dl < bestDelta {
+				bestDelta, bestI, bestJ, bestExchange = dl, i, j, false
+			}
+		}
+		for i, a := range path {
+			for _, c := range [2]int{guide.next[a], guide.prev[a]} {
+				if !inSel[c] || path[nextIdx(i, n)] == c || path[prevIdx(i, n)] == c {
+					continue
+				}
+				j := pos[c]
+				try(i, j)                         // adds (a, c) and (succ a, succ c)
+				try(prevIdx(i, n), prevIdx(j, n)) // adds (pred a, pred c) and (a, c)
+			}
+		}
+	}
+
+	if bestI < 0 {
+		return false
+	}
+	if bestExchange {
+		inSel[path[bestI]] = false
+		inSel[bestJ] = true
+		deltas.ApplyExchangeSelected(path, bestI, bestJ)
+	} else {
+		deltas.ApplyTwoOpt(path, bestI, bestJ)
+	}
+	return true
+}
+
+// pathRelink walks between init and guide according to config and returns
+// the best solution found: the better endpoint or one of the LocalSearchTop
+// best intermediate solutions after local search.
+func pathRelink[W Weight](D [][]W, costs []W, init, guide Solution[W], config PRConfig, localSearch func(Solution[W]) Solution[W]) Solution[W] {
+	if config.LocalSearchTop <= 0 {
+		config.LocalSearchTop = 3
+	}
+	best := init
+	if guide.Objective < best.Objective {
+		best = guide
+	}
+	intermediates := RelinkPath(D, costs, init, guide, config)
+	for i := 0; i < len(intermediates) && i < config.LocalSearchTop; i++ {
+		if sol := localSearch(intermediates[i]); sol.Objective < best.Objective {
+			best = sol
+		}
+	}
+	return best
+}
+
+// RelinkPath walks between init and guide according to config.Strategy and
+// returns the intermediate solutions visited on the way, best first, without
+// local search. The endpoints are not included. LocalSearchTop is ignored.
+func RelinkPath[W Weight](D [][]W, costs []W, init, guide Solution[W], config PRConfig) []Solution[W] {
+	if config.Truncation <= 0 || config.Truncation > 1 {
+		config.Truncation = 0.5
+	}
+	dim := len(D)
+
+	better, worse := init, guide
+	if worse.Objective < better.Objective {
+		better, worse = worse, better
+	}
+	from, to := worse, better
+	if config.Strategy != PRForward {
+		from, to = better, worse
+	}
+
+	// two walkers; only the first one moves unless the strategy is mixed
+	walkers := [2][]int{append([]int(nil), from.Path...), append([]int(nil), to.Path...)}
+	var inSel [2][]bool
+	for w := range walkers {
+		inSel[w] = make([]bool, dim)
+		for _, v := range walkers[w] {
+			inSel[w][v] = true
+		}
+	}
+	pos := make([]int, dim)
+
+	maxSteps := math.MaxInt
+	if config.Strategy == PRTruncated {
+		maxSteps = int(math.Ceil(config.Truncation * float64(relinkDistance(dim, from.Path, to.Path))))
+	}
+
+	var intermediates []Solution[W]
+	for step, w := 0, 0; step < maxSteps; step++ {
+		guidePath := walkers[1-w]
+		if !relinkStep(D, costs, walkers[w], newPRGuide(dim, guidePath), inSel[w], pos) {
+			break
+		}
+		if relinkDistance(dim, walkers[w], guidePath) == 0 {
+			break // reached the other end
+		}
+		path := append([]int(nil), walkers[w]...)
+		intermediates = append(intermediates, Solution[W]{Path: path, Objective: objective(D, costs, path)})
+		if config.Strategy == PRMixed {
+			w = 1 - w
+		}
+	}
+
+	sort.SliceStable(intermediates, func(i, j int) bool { return intermediates[i].Objective < intermediates[j].Objective })
+	return intermediates
+}
+
+// PathRelinking relinks two solutions and returns the best solution found
+// on the path between them, improved by steepest local search.
+func PathRelinking[W Weight](D [][]W, costs []W, init, guide Solution[W], config PRConfig) Solution[W] {
+	return pathRelink(D, costs, init, guide, config, func(s Solution[W]) Solution[W] {
+		return localSearchSteepestBaseline(D, costs, s)
+	})
+}
+
+// eliteSolutions returns up to size best solutions of pool that differ from
+// each other (relink distance greater than zero).
+func eliteSolutions[W Weight](dim int, pool []Solution[W], size int) []Solution[W] {
+	sorted := append([]Solution[W](nil), pool...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Objective < sorted[j].Objective })
+	elite := make([]Solution[W], 0, size)
+	for _, sol := range sorted {
+		if len(elite) == size {
+			break
+		}
+		distinct := true
+		for _, e := range elite {
+			if e.Objective == sol.Objective && relinkDistance(dim, e.Path, sol.Path) == 0 {
+				distinct = false
+				break
+			}
+		}
+		if distinct {
+			elite = append(elite, sol)
+		}
+	}
+	return elite
+}
+
+// RelinkElite is a post-optimisation step for a pool of local optima, such as
+// MSLSResult.AllSolutions or ILSResult.AllSolutions: it relinks every pair of
+// the eliteSize best distinct solutions and returns the best solution found.
+func RelinkElite[W Weight](D [][]W, costs []W, pool []Solution[W], eliteSize int, config PRConfig) PRResult[W] {
+	startTime := time.Now()
+	elite := eliteSolutions(len(D), pool, eliteSize)
+	if len(elite) == 0 {
+		return PRResult[W]{Duration: time.Since(startTime)}
+	}
+
+	best := elite[0]
+	pairs := 0
+	for i := 0; i < len(elite); i++ {
+		for j := i + 1; j < len(elite); j++ {
+			if sol := PathRelinking(D, costs, elite[i], elite[j], config); sol.Objective < best.Objective {
+				best = sol
+			}
+			pairs++
+		}
+	}
+	return PRResult[W]{BestSolution: best, Pairs: pairs, Duration: time.Since(startTime)}
+}
@@ -1,11 +1,16 @@
 package utils
 
-type Row struct {
+import "github.com/czajkowskis/evolutionary_computation/06_labs/local_search_extensions/pkg/algorithms"
+
+type Row[W algorithms.Weight] struct {
 	Name      string
 	AvgV      float64
-	MinV      int
-	MaxV      int
+	MinV      W
+	MaxV      W
 	AvgTms    float64
 	BestPath  []int
-	BestValue int
+	BestValue W
+	// LowerBound is a lower bound on the objective of the instance, used to
+	// report optimality gaps (0 = unknown).
+	LowerBound W
 }
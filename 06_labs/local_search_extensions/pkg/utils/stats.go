@@ -1,18 +1,16 @@
 package utils
 
 import (
-	"math"
-
 	"github.com/czajkowskis/evolutionary_computation/06_labs/local_search_extensions/pkg/algorithms"
 )
 
-func CalculateStatistics(solutions []algorithms.Solution) (int, int, float64) {
+func CalculateStatistics[W algorithms.Weight](solutions []algorithms.Solution[W]) (W, W, float64) {
 	if len(solutions) == 0 {
 		return 0, 0, 0
 	}
-	minObj := math.MaxInt32
-	maxObj := 0
-	sum := 0
+	minObj := solutions[0].Objective
+	maxObj := solutions[0].Objective
+	var sum W
 	for _, sol := range solutions {
 		obj := sol.Objective
 		if obj < minObj {
@@ -26,3 +24,9 @@ func CalculateStatistics(solutions []algorithms.Solution) (int, int, float64) {
 	avg := float64(sum) / float64(len(solutions))
 	return minObj, maxObj, avg
 }
+
+// GapPercent returns how far value lies above the lower bound, in percent of
+// the bound.
+func GapPercent[W algorithms.Weight](value float64, bound W) float64 {
+	return 100 * (value - float64(bound)) / float64(bound)
+}
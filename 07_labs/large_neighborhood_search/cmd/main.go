@@ -1,13 +1,16 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
 	"time"
 
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+	ils "github.com/czajkowskis/evolutionary_computation/06_labs/local_search_extensions/pkg/algorithms"
 	"github.com/czajkowskis/evolutionary_computation/07_labs/large_neighborhood_search/pkg/algorithms"
-	"github.com/czajkowskis/evolutionary_computation/07_labs/large_neighborhood_search/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/07_labs/large_neighborhood_search/pkg/utils"
 	"github.com/czajkowskis/evolutionary_computation/07_labs/large_neighborhood_search/pkg/visualisation"
 )
@@ -20,13 +23,13 @@ const (
 )
 
 // InstanceResults stores all results for a single instance
-type InstanceResults struct {
+type InstanceResults[W algorithms.Weight] struct {
 	Instance   string
-	LNSResults []algorithms.LNSResult
+	LNSResults []algorithms.LNSResult[W]
 }
 
 // processInstance runs the full experimental pipeline for a single instance
-func processInstance(instanceName string, nodes []data.Node) {
+func processInstance[W algorithms.Weight](instanceName string, nodes []data.Node, policy data.DistancePolicy) {
 	log.Printf("Processing instance %s with %d nodes", instanceName, len(nodes))
 	fmt.Printf("Instance %s Statistics:\n", instanceName)
 
@@ -37,28 +40,86 @@ func processInstance(instanceName string, nodes []data.Node) {
 		timeLimit = time.Duration(timeLimitB * float64(time.Millisecond))
 	}
 
-	D := data.CalculateDistanceMatrix(nodes)
-	costs := make([]int, len(nodes))
-	for i, node := range nodes {
-		costs[i] = node.Cost
+	D, costs, err := data.Weights[W](nodes, policy)
+	if err != nil {
+		log.Fatalf("Error computing distances: %v", err)
 	}
 
-	var rows []utils.Row
+	lb := deltas.LagrangianLowerBound(D, costs, deltas.LowerBoundConfig{Seed: time.Now().UnixNano()})
+	log.Printf("Lagrangian lower bound for instance %s: %v (%d iterations, %v)", instanceName, lb.Bound, lb.Iterations, lb.Duration)
+
+	var rows []utils.Row[W]
 
 	// Define destroy methods to test
 	destroyMethods := []string{"random_subpath"}
+	// Define repair methods to test with local search
+	repairMethods := algorithms.RepairMethods
 
 	// === PHASE 1: Run LNS using local search after destroy-repair operators ===
 	for _, method := range destroyMethods {
-		log.Printf("Starting LNS with LS using %s destroy method for instance %s", method, instanceName)
+		for _, repairMethod := range repairMethods {
+			name := fmt.Sprintf("LNS+LS (%s)", method)
+			if repairMethod != "nearest_neighbor" {
+				name = fmt.Sprintf("LNS+LS (%s, %s)", method, repairMethod)
+			}
+			log.Printf("Starting LNS with LS using %s destroy and %s repair methods for instance %s", method, repairMethod, instanceName)
+			start := time.Now()
+
+			var lnsResults []algorithms.LNSResult[W]
+			totalLNSIterations := 0
+			for run := 0; run < numLNSRuns; run++ {
+				lnsResult := algorithms.LargeNeighborhoodSearch(D, costs, algorithms.LNSConfig{
+					DestroyFraction: 0.3,
+					UseLocalSearch:  true,
+					TimeLimit:       timeLimit,
+					DestroyMethod:   method,
+					RepairMethod:    repairMethod,
+				})
+				lnsResults = append(lnsResults, lnsResult)
+				totalLNSIterations += lnsResult.Iterations
+			}
+
+			totalLNSTime := time.Since(start)
+			avgLNSTime := totalLNSTime / time.Duration(numLNSRuns)
+
+			// Collect LNS solutions for statistics
+			lnsSolutions := make([]algorithms.Solution[W], len(lnsResults))
+			for i, r := range lnsResults {
+				lnsSolutions[i] = r.BestSolution
+			}
+
+			lnsMin, lnsMax, lnsAvg := utils.CalculateStatistics(lnsSolutions)
+			avgLNSTimeMs := float64(avgLNSTime.Nanoseconds()) / 1e6
+			avgLNSIterations := float64(totalLNSIterations) / float64(numLNSRuns)
+			bestLNS := algorithms.FindBestSolution(lnsSolutions)
+
+			rows = append(rows, utils.Row[W]{
+				Name:        name,
+				AvgV:        lnsAvg,
+				MinV:        lnsMin,
+				MaxV:        lnsMax,
+				AvgTms:      avgLNSTimeMs,
+				AvgLNSIters: avgLNSIterations,
+				BestPath:    bestLNS.Path,
+				BestValue:   bestLNS.Objective,
+				LowerBound:  lb.Bound,
+			})
+
+			log.Printf("Completed %s: best value %v, avg time %.2f ms, avg iterations %.1f", name, bestLNS.Objective, avgLNSTimeMs, avgLNSIterations)
+		}
+	}
+
+	// === PHASE 2: Run LNS not using local search after destroy-repair operators ===
+	for _, method := range destroyMethods {
+		log.Printf("Starting LNS without LS using %s destroy method for instance %s", method, instanceName)
 		start := time.Now()
 
-		var lnsResults []algorithms.LNSResult
+		var lnsResults []algorithms.LNSResult[W]
 		totalLNSIterations := 0
 		for run := 0; run < numLNSRuns; run++ {
 			lnsResult := algorithms.LargeNeighborhoodSearch(D, costs, algorithms.LNSConfig{
 				DestroyFraction: 0.3,
-				UseLocalSearch:  true,
+				UseLocalSearch:  false,
 				TimeLimit:       timeLimit,
 				DestroyMethod:   method,
 			})
@@ -70,7 +131,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 		avgLNSTime := totalLNSTime / time.Duration(numLNSRuns)
 
 		// Collect LNS solutions for statistics
-		lnsSolutions := make([]algorithms.Solution, len(lnsResults))
+		lnsSolutions := make([]algorithms.Solution[W], len(lnsResults))
 		for i, r := range lnsResults {
 			lnsSolutions[i] = r.BestSolution
 		}
@@ -80,8 +141,8 @@ func processInstance(instanceName string, nodes []data.Node) {
 		avgLNSIterations := float64(totalLNSIterations) / float64(numLNSRuns)
 		bestLNS := algorithms.FindBestSolution(lnsSolutions)
 
-		rows = append(rows, utils.Row{
-			Name:        fmt.Sprintf("LNS+LS (%s)", method),
+		rows = append(rows, utils.Row[W]{
+			Name:        fmt.Sprintf("LNS-LS (%s)", method),
 			AvgV:        lnsAvg,
 			MinV:        lnsMin,
 			MaxV:        lnsMax,
@@ -89,61 +150,218 @@ func processInstance(instanceName string, nodes []data.Node) {
 			AvgLNSIters: avgLNSIterations,
 			BestPath:    bestLNS.Path,
 			BestValue:   bestLNS.Objective,
+			LowerBound:  lb.Bound,
 		})
 
-		log.Printf("Completed LNS+LS (%s): best value %d, avg time %.2f ms, avg iterations %.1f", method, bestLNS.Objective, avgLNSTimeMs, avgLNSIterations)
+		log.Printf("Completed LNS-LS (%s): best value %v, avg time %.2f ms, avg iterations %.1f", method, bestLNS.Objective, avgLNSTimeMs, avgLNSIterations)
 	}
 
-	// === PHASE 2: Run LNS not using local search after destroy-repair operators ===
-	for _, method := range destroyMethods {
-		log.Printf("Starting LNS without LS using %s destroy method for instance %s", method, instanceName)
+	// === PHASE 3: Run simulated annealing with different cooling schedules ===
+	schedules := []algorithms.CoolingSchedule{
+		algorithms.CoolingGeometric,
+		algorithms.CoolingLinear,
+		algorithms.CoolingLam,
+		algorithms.CoolingReheat,
+	}
+	for _, schedule := range schedules {
+		log.Printf("Starting SA with %s cooling for instance %s", schedule, instanceName)
 		start := time.Now()
 
-		var lnsResults []algorithms.LNSResult
-		totalLNSIterations := 0
+		var saResults []algorithms.SAResult[W]
+		totalSAIterations := 0
 		for run := 0; run < numLNSRuns; run++ {
-			lnsResult := algorithms.LargeNeighborhoodSearch(D, costs, algorithms.LNSConfig{
+			saResult := algorithms.SimulatedAnnealing(D, costs, algorithms.SAConfig{
+				Schedule:  schedule,
+				TimeLimit: timeLimit,
+				Seed:      time.Now().UnixNano(),
+			})
+			saResults = append(saResults, saResult)
+			totalSAIterations += saResult.Iterations
+		}
+
+		totalSATime := time.Since(start)
+		avgSATime := totalSATime / time.Duration(numLNSRuns)
+
+		// Collect SA solutions for statistics
+		saSolutions := make([]algorithms.Solution[W], len(saResults))
+		for i, r := range saResults {
+			saSolutions[i] = r.BestSolution
+		}
+
+		saMin, saMax, saAvg := utils.CalculateStatistics(saSolutions)
+		avgSATimeMs := float64(avgSATime.Nanoseconds()) / 1e6
+		avgSAIterations := float64(totalSAIterations) / float64(numLNSRuns)
+		bestSA := algorithms.FindBestSolution(saSolutions)
+
+		rows = append(rows, utils.Row[W]{
+			Name:        fmt.Sprintf("SA (%s)", schedule),
+			AvgV:        saAvg,
+			MinV:        saMin,
+			MaxV:        saMax,
+			AvgTms:      avgSATimeMs,
+			AvgLNSIters: avgSAIterations,
+			BestPath:    bestSA.Path,
+			BestValue:   bestSA.Objective,
+			LowerBound:  lb.Bound,
+		})
+
+		log.Printf("Completed SA (%s): best value %v, avg time %.2f ms, avg iterations %.1f", schedule, bestSA.Objective, avgSATimeMs, avgSAIterations)
+	}
+
+	// === PHASE 4: Run guided local search ===
+	{
+		log.Printf("Starting GLS for instance %s", instanceName)
+		start := time.Now()
+
+		var glsResults []algorithms.GLSResult[W]
+		totalGLSIterations := 0
+		for run := 0; run < numLNSRuns; run++ {
+			glsResult := algorithms.GuidedLocalSearch(D, costs, algorithms.GLSConfig{
+				TimeLimit: timeLimit,
+				Seed:      time.Now().UnixNano(),
+			})
+			glsResults = append(glsResults, glsResult)
+			totalGLSIterations += glsResult.Iterations
+		}
+
+		totalGLSTime := time.Since(start)
+		avgGLSTime := totalGLSTime / time.Duration(numLNSRuns)
+
+		// Collect GLS solutions for statistics
+		glsSolutions := make([]algorithms.Solution[W], len(glsResults))
+		for i, r := range glsResults {
+			glsSolutions[i] = r.BestSolution
+		}
+
+		glsMin, glsMax, glsAvg := utils.CalculateStatistics(glsSolutions)
+		avgGLSTimeMs := float64(avgGLSTime.Nanoseconds()) / 1e6
+		avgGLSIterations := float64(totalGLSIterations) / float64(numLNSRuns)
+		bestGLS := algorithms.FindBestSolution(glsSolutions)
+
+		rows = append(rows, utils.Row[W]{
+			Name:        "GLS",
+			AvgV:        glsAvg,
+			MinV:        glsMin,
+			MaxV:        glsMax,
+			AvgTms:      avgGLSTimeMs,
+			AvgLNSIters: avgGLSIterations,
+			BestPath:    bestGLS.Path,
+			BestValue:   bestGLS.Objective,
+			LowerBound:  lb.Bound,
+		})
+
+		log.Printf("Completed GLS: best value %v, avg time %.2f ms, avg iterations %.1f", bestGLS.Objective, avgGLSTimeMs, avgGLSIterations)
+	}
+
+	// === PHASE 5: Run adaptive LNS with all destroy and repair operators ===
+	for _, useLS := range []bool{true, false} {
+		name := "ALNS+LS"
+		if !useLS {
+			name = "ALNS-LS"
+		}
+		log.Printf("Starting %s for instance %s", name, instanceName)
+		start := time.Now()
+
+		var alnsResults []algorithms.LNSResult[W]
+		totalALNSIterations := 0
+		for run := 0; run < numLNSRuns; run++ {
+			alnsResult := algorithms.LargeNeighborhoodSearch(D, costs, algorithms.LNSConfig{
+				UseLocalSearch: useLS,
+				TimeLimit:      timeLimit,
+				Adaptive:       true,
+			})
+			alnsResults = append(alnsResults, alnsResult)
+			totalALNSIterations += alnsResult.Iterations
+		}
+
+		totalALNSTime := time.Since(start)
+		avgALNSTime := totalALNSTime / time.Duration(numLNSRuns)
+
+		// Collect ALNS solutions for statistics
+		alnsSolutions := make([]algorithms.Solution[W], len(alnsResults))
+		for i, r := range alnsResults {
+			alnsSolutions[i] = r.BestSolution
+		}
+
+		alnsMin, alnsMax, alnsAvg := utils.CalculateStatistics(alnsSolutions)
+		avgALNSTimeMs := float64(avgALNSTime.Nanoseconds()) / 1e6
+		avgALNSIterations := float64(totalALNSIterations) / float64(numLNSRuns)
+		bestALNS := algorithms.FindBestSolution(alnsSolutions)
+
+		rows = append(rows, utils.Row[W]{
+			Name:        name,
+			AvgV:        alnsAvg,
+			MinV:        alnsMin,
+			MaxV:        alnsMax,
+			AvgTms:      avgALNSTimeMs,
+			AvgLNSIters: avgALNSIterations,
+			BestPath:    bestALNS.Path,
+			BestValue:   bestALNS.Objective,
+			LowerBound:  lb.Bound,
+		})
+
+		log.Printf("Completed %s: best value %v, avg time %.2f ms, avg iterations %.1f", name, bestALNS.Objective, avgALNSTimeMs, avgALNSIterations)
+		printOperatorStats(name, alnsResults)
+	}
+
+	// === PHASE 6: Run LNS with acceptance criteria beyond strict improvement ===
+	acceptanceTypes := []ils.AcceptanceType{
+		ils.AcceptRecordToRecord,
+		ils.AcceptThreshold,
+		ils.AcceptLateAcceptance,
+		ils.AcceptGreatDeluge,
+		ils.AcceptSimulatedAnnealing,
+	}
+	for _, acceptance := range acceptanceTypes {
+		name := fmt.Sprintf("LNS+LS (random_subpath, %s)", acceptance)
+		log.Printf("Starting %s for instance %s", name, instanceName)
+		start := time.Now()
+
+		var accResults []algorithms.LNSResult[W]
+		totalAccIterations := 0
+		for run := 0; run < numLNSRuns; run++ {
+			accResult := algorithms.LargeNeighborhoodSearch(D, costs, algorithms.LNSConfig{
 				DestroyFraction: 0.3,
-				UseLocalSearch:  false,
+				UseLocalSearch:  true,
 				TimeLimit:       timeLimit,
-				DestroyMethod:   method,
+				DestroyMethod:   "random_subpath",
+				Acceptance:      ils.AcceptanceConfig{Type: acceptance},
 			})
-			lnsResults = append(lnsResults, lnsResult)
-			totalLNSIterations += lnsResult.Iterations
+			accResults = append(accResults, accResult)
+			totalAccIterations += accResult.Iterations
 		}
 
-		totalLNSTime := time.Since(start)
-		avgLNSTime := totalLNSTime / time.Duration(numLNSRuns)
+		avgAccTime := time.Since(start) / time.Duration(numLNSRuns)
 
-		// Collect LNS solutions for statistics
-		lnsSolutions := make([]algorithms.Solution, len(lnsResults))
-		for i, r := range lnsResults {
-			lnsSolutions[i] = r.BestSolution
+		accSolutions := make([]algorithms.Solution[W], len(accResults))
+		for i, r := range accResults {
+			accSolutions[i] = r.BestSolution
 		}
 
-		lnsMin, lnsMax, lnsAvg := utils.CalculateStatistics(lnsSolutions)
-		avgLNSTimeMs := float64(avgLNSTime.Nanoseconds()) / 1e6
-		avgLNSIterations := float64(totalLNSIterations) / float64(numLNSRuns)
-		bestLNS := algorithms.FindBestSolution(lnsSolutions)
-
-		rows = append(rows, utils.Row{
-			Name:        fmt.Sprintf("LNS-LS (%s)", method),
-			AvgV:        lnsAvg,
-			MinV:        lnsMin,
-			MaxV:        lnsMax,
-			AvgTms:      avgLNSTimeMs,
-			AvgLNSIters: avgLNSIterations,
-			BestPath:    bestLNS.Path,
-			BestValue:   bestLNS.Objective,
+		accMin, accMax, accAvg := utils.CalculateStatistics(accSolutions)
+		avgAccTimeMs := float64(avgAccTime.Nanoseconds()) / 1e6
+		avgAccIterations := float64(totalAccIterations) / float64(numLNSRuns)
+		bestAcc := algorithms.FindBestSolution(accSolutions)
+
+		rows = append(rows, utils.Row[W]{
+			Name:        name,
+			AvgV:        accAvg,
+			MinV:        accMin,
+			MaxV:        accMax,
+			AvgTms:      avgAccTimeMs,
+			AvgLNSIters: avgAccIterations,
+			BestPath:    bestAcc.Path,
+			BestValue:   bestAcc.Objective,
+			LowerBound:  lb.Bound,
 		})
 
-		log.Printf("Completed LNS-LS (%s): best value %d, avg time %.2f ms, avg iterations %.1f", method, bestLNS.Objective, avgLNSTimeMs, avgLNSIterations)
+		log.Printf("Completed %s: best value %v, avg time %.2f ms, avg iterations %.1f", name, bestAcc.Objective, avgAccTimeMs, avgAccIterations)
 	}
 
 	// Print console output
 	fmt.Println("\nObjective value: av (min, max)")
 	for _, r := range rows {
-		fmt.Printf("%-34s  %.2f (%d, %d)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
+		fmt.Printf("%-34s  %.2f (%v, %v)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
 	}
 	fmt.Println()
 
@@ -157,6 +375,12 @@ func processInstance(instanceName string, nodes []data.Node) {
 	for _, r := range rows {
 		fmt.Printf("%-34s  %.1f\n", r.Name, r.AvgLNSIters)
 	}
+	fmt.Println()
+
+	fmt.Printf("Gap to the lower bound %v [%%]: best, avg\n", lb.Bound)
+	for _, r := range rows {
+		fmt.Printf("%-34s  %.2f, %.2f\n", r.Name, utils.GapPercent(float64(r.BestValue), lb.Bound), utils.GapPercent(r.AvgV, lb.Bound))
+	}
 
 	// Save CSV
 	if err := utils.WriteResultsCSV(instanceName, rows); err != nil {
@@ -167,7 +391,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 
 	// Plot best solutions for each method
 	for i, r := range rows {
-		title := fmt.Sprintf("%s for Instance %s (Value: %d)", r.Name, instanceName, r.BestValue)
+		title := fmt.Sprintf("%s for Instance %s (Value: %v)", r.Name, instanceName, r.BestValue)
 		fileName := utils.SanitizeFileName(fmt.Sprintf("%s_Instance_%s_%d", r.Name, instanceName, i))
 		if err := visualisation.PlotSolution(nodes, r.BestPath, title, fileName, 0, 4000, 0, 2000); err != nil {
 			log.Printf("plot error for %s/%s: %v", instanceName, r.Name, err)
@@ -175,7 +399,46 @@ func processInstance(instanceName string, nodes []data.Node) {
 	}
 }
 
+// printOperatorStats prints the ALNS operator weights, usage counts and
+// destroy fraction ranges averaged over all runs.
+func printOperatorStats[W algorithms.Weight](name string, results []algorithms.LNSResult[W]) {
+	fmt.Printf("%s operators: avg weight, avg uses, avg fraction range\n", name)
+	for _, pick := range []func(algorithms.LNSResult[W]) []algorithms.OperatorStats{
+		func(r algorithms.LNSResult[W]) []algorithms.OperatorStats { return r.DestroyStats },
+		func(r algorithms.LNSResult[W]) []algorithms.OperatorStats { return r.RepairStats },
+	} {
+		sums := make([]algorithms.OperatorStats, len(pick(results[0])))
+		for _, r := range results {
+			for i, op := range pick(r) {
+				sums[i].Name = op.Name
+				sums[i].Weight += op.Weight
+				sums[i].Uses += op.Uses
+				sums[i].FractionMin += op.FractionMin
+				sums[i].FractionMax += op.FractionMax
+			}
+		}
+		runs := float64(len(results))
+		for _, op := range sums {
+			fmt.Printf("  %-18s  %6.2f  %9.1f  [%.2f, %.2f]\n", op.Name, op.Weight/runs, float64(op.Uses)/runs,
+				op.FractionMin/runs, op.FractionMax/runs)
+		}
+	}
+}
+
 func main() {
+	rounding := flag.String("rounding", "round", "distance and cost rounding: round, ceil, floor or exact")
+	scale := flag.Float64("scale", 1, "fixed-point factor applied to distances and costs before rounding")
+	flag.Parse()
+
+	policy, err := data.ParseDistancePolicy(*rounding, *scale)
+	if err != nil {
+		log.Fatalf("Invalid distance policy: %v", err)
+	}
+	run := processInstance[int]
+	if policy.Rounding == data.RoundExact {
+		run = processInstance[float64]
+	}
+
 	rand.Seed(time.Now().UnixNano())
 	log.Println("Starting LNS local search experiments")
 
@@ -188,9 +451,9 @@ func main() {
 		log.Fatalf("Error reading TSPB.csv: %v", err)
 	}
 
-	processInstance("A", nodesA)
+	run("A", nodesA, policy)
 	fmt.Println()
-	processInstance("B", nodesB)
+	run("B", nodesB, policy)
 
 	log.Println("Program execution completed")
 }
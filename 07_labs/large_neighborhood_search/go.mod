@@ -15,3 +15,15 @@ require (
 	golang.org/x/text v0.23.0 // indirect
 	gonum.org/v1/plot v0.16.0 // indirect
 )
+
+require (
+	github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics v0.0.0
+	github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas v0.0.0
+	github.com/czajkowskis/evolutionary_computation/06_labs/local_search_extensions v0.0.0
+)
+
+replace (
+	github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics => ../../01_labs/greedy_heuristics
+	github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas => ../../05_labs/local_search_deltas
+	github.com/czajkowskis/evolutionary_computation/06_labs/local_search_extensions => ../../06_labs/local_search_extensions
+)
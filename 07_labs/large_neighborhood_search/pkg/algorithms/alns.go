@@ -0,0 +1,211 @@
+package algorithms
+
+import (
+	"math/rand"
+	"time"
+
+	ils "github.com/czajkowskis/evolutionary_computation/06_labs/local_search_extensions/pkg/algorithms"
+)
+
+// ALNSConfig holds the parameters of Adaptive Large Neighborhood Search.
+// Zero values select the defaults given in the comments.
+type ALNSConfig struct {
+	DestroyMethods []string // Destroy operators in the roulette wheel (default DestroyMethods)
+	RepairMethods  []string // Repair operators in the roulette wheel (default RepairMethods)
+	SegmentLength  int      // Iterations between weight updates (default 100)
+	ReactionFactor float64  // Weight of the last segment in the new weight (default 0.1)
+	ScoreBest      float64  // Score for a new best solution (default 33)
+	ScoreImproved  float64  // Score for improving the current solution (default 9)
+	ScoreAccepted  float64  // Score for an accepted, non-improving solution (default 13)
+	MinFraction    float64  // Smallest destroy fraction (default 0.1)
+	MaxFraction    float64  // Largest destroy fraction (default 0.5)
+}
+
+// OperatorStats reports the final state of an ALNS operator.
+type OperatorStats struct {
+	Name        string
+	Weight      float64
+	Uses        int
+	FractionMin float64 // destroy fraction range (destroy operators only)
+	FractionMax float64
+}
+
+const (
+	alnsMinWeight     = 0.1  // keeps every operator selectable
+	alnsFractionShift = 0.9  // shrink (success) or widen (no success) factor of the fraction range
+	alnsMinWidth      = 0.02 // narrowest destroy fraction range
+)
+
+// alnsOperator keeps the adaptive state of one operator: its weight, the
+// scores collected in the current segment and, for destroy operators, the
+// range the destroy fraction is drawn from.
+type alnsOperator struct {
+	OperatorStats
+	segScore float64
+	segUses  int
+	// fractions that led to a new best or improved solution in this segment
+	segSuccessSum   float64
+	segSuccessCount int
+}
+
+func newALNSOperators(names []string, lo, hi float64) []*alnsOperator {
+	ops := make([]*alnsOperator, len(names))
+	for i, name := range names {
+		ops[i] = &alnsOperator{OperatorStats: OperatorStats{Name: name, Weight: 1, FractionMin: lo, FractionMax: hi}}
+	}
+	return ops
+}
+
+// selectOperator draws an operator by roulette wheel over the weights.
+func selectOperator(ops []*alnsOperator, rng *rand.Rand) *alnsOperator {
+	total := 0.0
+	for _, op := range ops {
+		total += op.Weight
+	}
+	r := rng.Float64() * total
+	for _, op := range ops {
+		if r < op.Weight {
+			return op
+		}
+		r -= op.Weight
+	}
+	return ops[len(ops)-1]
+}
+
+// endSegment blends the average score of the segment into the weights.
+func endSegment(ops []*alnsOperator, reaction float64) {
+	for _, op := range ops {
+		if op.segUses > 0 {
+			op.Weight = (1-reaction)*op.Weight + reaction*op.segScore/float64(op.segUses)
+			op.Weight = max(op.Weight, alnsMinWeight)
+		}
+		op.segScore, op.segUses = 0, 0
+	}
+}
+
+// adaptFractions moves the destroy fraction range of every destroy operator:
+// after a segment with successes the range shrinks around the mean successful
+// fraction, otherwise it widens, always staying within [lo, hi].
+func adaptFractions(ops []*alnsOperator, lo, hi float64) {
+	for _, op := range ops {
+		width := op.FractionMax - op.FractionMin
+		center := (op.FractionMin + op.FractionMax) / 2
+		if op.segSuccessCount > 0 {
+			width = max(width*alnsFractionShift, alnsMinWidth)
+			center = op.segSuccessSum / float64(op.segSuccessCount)
+		} else {
+			width = min(width/alnsFractionShift, hi-lo)
+		}
+		// shift the range back inside [lo, hi] keeping its width
+		center = min(max(center, lo+width/2), hi-width/2)
+		op.FractionMin = center - width/2
+		op.FractionMax = center + width/2
+		op.segSuccessSum, op.segSuccessCount = 0, 0
+	}
+}
+
+func operatorStats(ops []*alnsOperator) []OperatorStats {
+	stats := make([]OperatorStats, len(ops))
+	for i, op := range ops {
+		stats[i] = op.OperatorStats
+	}
+	return stats
+}
+
+// adaptiveLNS is LargeNeighborhoodSearch in ALNS mode. In every iteration a
+// destroy and a repair operator are drawn by roulette wheel and the destroy
+// fraction is drawn from the range of the destroy operator. Both operators
+// are scored with ScoreBest, ScoreImproved or ScoreAccepted when the result
+// is a new best solution, improves the current one, or is accepted by the
+// acceptance criterion without improving; at the end of each segment the weights are updated with
+// the average segment scores and the fraction ranges are adapted.
+func adaptiveLNS[W Weight](D [][]W, costs []W, config LNSConfig, ws *lsWorkspace[W], rng *rand.Rand, startTime time.Time) LNSResult[W] {
+	ac := config.ALNS
+	if len(ac.DestroyMethods) == 0 {
+		ac.DestroyMethods = DestroyMethods
+	}
+	if len(ac.RepairMethods) == 0 {
+		ac.RepairMethods = RepairMethods
+	}
+	if ac.SegmentLength <= 0 {
+		ac.SegmentLength = 100
+	}
+	if ac.ReactionFactor <= 0 || ac.ReactionFactor > 1 {
+		ac.ReactionFactor = 0.1
+	}
+	if ac.ScoreBest == 0 && ac.ScoreImproved == 0 && ac.ScoreAccepted == 0 {
+		ac.ScoreBest, ac.ScoreImproved, ac.ScoreAccepted = 33, 9, 13
+	}
+	if ac.MinFraction <= 0 {
+		ac.MinFraction = 0.1
+	}
+	if ac.MaxFraction <= ac.MinFraction || ac.MaxFraction >= 1 {
+		ac.MaxFraction = max(ac.MinFraction, 0.5)
+	}
+
+	k := (len(costs) + 1) / 2
+	destroyOps := newALNSOperators(ac.DestroyMethods, ac.MinFraction, ac.MaxFraction)
+	repairOps := newALNSOperators(ac.RepairMethods, 0, 0)
+
+	current := ws.localSearchSteepest(D, costs, startRandom(D, costs, rng))
+	best := Solution[W]{Path: append([]int(nil), current.Path...), Objective: current.Objective}
+	acc := ils.NewAcceptor(config.Acceptance, current.Objective)
+
+	iterations := 0
+	for time.Since(startTime) < config.TimeLimit {
+		iterations++
+
+		d := selectOperator(destroyOps, rng)
+		r := selectOperator(repairOps, rng)
+		fraction := d.FractionMin + rng.Float64()*(d.FractionMax-d.FractionMin)
+
+		partial := applyDestroy(d.Name, current.Path, fraction, D, costs, rng)
+		candidate := ws.applyRepair(r.Name, partial, D, costs, k, config.RepairNoise, rng)
+		if config.UseLocalSearch {
+			candidate = ws.localSearchSteepest(D, costs, candidate)
+		}
+
+		improved := candidate.Objective < current.Objective
+		progress := float64(time.Since(startTime)) / float64(config.TimeLimit)
+		accepted := acc.Accept(candidate.Objective, current.Objective, best.Objective, progress, rng)
+		score := 0.0
+		switch {
+		case candidate.Objective < best.Objective:
+			score = ac.ScoreBest
+		case improved && accepted:
+			score = ac.ScoreImproved
+		case accepted:
+			score = ac.ScoreAccepted
+		}
+		if accepted {
+			current = candidate
+		}
+		if candidate.Objective < best.Objective {
+			best = Solution[W]{Path: append(best.Path[:0], candidate.Path...), Objective: candidate.Objective}
+		}
+		if improved {
+			d.segSuccessSum += fraction
+			d.segSuccessCount++
+		}
+
+		for _, op := range [2]*alnsOperator{d, r} {
+			op.Uses++
+			op.segUses++
+			op.segScore += score
+		}
+
+		if iterations%ac.SegmentLength == 0 {
+			endSegment(destroyOps, ac.ReactionFactor)
+			endSegment(repairOps, ac.ReactionFactor)
+			adaptFractions(destroyOps, ac.MinFraction, ac.MaxFraction)
+		}
+	}
+
+	return LNSResult[W]{
+		BestSolution: best,
+		Iterations:   iterations,
+		Duration:     time.Since(startTime),
+		DestroyStats: operatorStats(destroyOps),
+		RepairStats:  operatorStats(repairOps),
+	}
+}
@@ -0,0 +1,106 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// TestAdaptiveLNS checks that ALNS returns a valid solution with a correct
+// objective, that every iteration uses one destroy and one repair operator,
+// and that the final weights and destroy fraction ranges respect their limits.
+func TestAdaptiveLNS(t *testing.T) {
+	D, costs := randomInstance(60, rand.New(rand.NewSource(1)))
+	for _, useLS := range []bool{false, true} {
+		t.Run(fmt.Sprintf("ls=%v", useLS), func(t *testing.T) {
+			config := LNSConfig{
+				UseLocalSearch: useLS,
+				TimeLimit:      100 * time.Millisecond,
+				Adaptive:       true,
+				ALNS:           ALNSConfig{SegmentLength: 5},
+			}
+			res := LargeNeighborhoodSearch(D, costs, config)
+
+			path := res.BestSolution.Path
+			if len(path) != selectCount(len(D)) || res.BestSolution.Objective != objective(D, costs, path) {
+				t.Fatalf("invalid solution %v with objective %d", path, res.BestSolution.Objective)
+			}
+			seen := make([]bool, len(D))
+			for _, v := range path {
+				if seen[v] {
+					t.Fatalf("node %d visited twice in %v", v, path)
+				}
+				seen[v] = true
+			}
+
+			for _, stats := range [][]OperatorStats{res.DestroyStats, res.RepairStats} {
+				uses := 0
+				for _, op := range stats {
+					uses += op.Uses
+					if op.Weight < alnsMinWeight {
+						t.Errorf("%s: weight %v below %v", op.Name, op.Weight, alnsMinWeight)
+					}
+				}
+				if uses != res.Iterations {
+					t.Errorf("operators used %d times in %d iterations", uses, res.Iterations)
+				}
+			}
+			for _, op := range res.DestroyStats {
+				if op.FractionMin < 0.1-1e-9 || op.FractionMax > 0.5+1e-9 || op.FractionMin > op.FractionMax {
+					t.Errorf("%s: fraction range [%v, %v] outside [0.1, 0.5]", op.Name, op.FractionMin, op.FractionMax)
+				}
+			}
+		})
+	}
+}
+
+// TestEndSegment checks the weight update: the average segment score is
+// blended in with the reaction factor, the weight never drops below
+// alnsMinWeight, operators unused in the segment keep their weight and the
+// segment scores are reset.
+func TestEndSegment(t *testing.T) {
+	ops := newALNSOperators([]string{"used", "unused", "failing"}, 0, 0)
+	ops[0].segScore, ops[0].segUses = 66, 3 // average 22
+	ops[2].Weight, ops[2].segScore, ops[2].segUses = 0.1, 0, 4
+
+	endSegment(ops, 0.1)
+
+	for i, want := range []float64{0.9*1 + 0.1*22, 1, alnsMinWeight} {
+		if math.Abs(ops[i].Weight-want) > 1e-12 {
+			t.Errorf("%s: weight %v, want %v", ops[i].Name, ops[i].Weight, want)
+		}
+		if ops[i].segScore != 0 || ops[i].segUses != 0 {
+			t.Errorf("%s: segment not reset", ops[i].Name)
+		}
+	}
+}
+
+// TestAdaptFractions checks that a destroy fraction range shrinks around the
+// mean successful fraction after a successful segment, widens otherwise, and
+// stays within [lo, hi].
+func TestAdaptFractions(t *testing.T) {
+	const lo, hi = 0.1, 0.5
+	ops := newALNSOperators([]string{"success", "failure"}, 0.2, 0.4)
+	ops[0].segSuccessSum, ops[0].segSuccessCount = 0.9, 3 // mean 0.3
+
+	adaptFractions(ops, lo, hi)
+
+	if w := ops[0].FractionMax - ops[0].FractionMin; math.Abs(w-0.2*alnsFractionShift) > 1e-12 {
+		t.Errorf("range width %v after a success, want %v", w, 0.2*alnsFractionShift)
+	}
+	if c := (ops[0].FractionMin + ops[0].FractionMax) / 2; math.Abs(c-0.3) > 1e-12 {
+		t.Errorf("range centre %v after a success, want 0.3", c)
+	}
+	if w := ops[1].FractionMax - ops[1].FractionMin; math.Abs(w-0.2/alnsFractionShift) > 1e-12 {
+		t.Errorf("range width %v after a failure, want %v", w, 0.2/alnsFractionShift)
+	}
+
+	for it := 0; it < 50; it++ {
+		adaptFractions(ops, lo, hi)
+	}
+	if ops[1].FractionMin < lo-1e-12 || ops[1].FractionMax > hi+1e-12 {
+		t.Errorf("range [%v, %v] outside [%v, %v]", ops[1].FractionMin, ops[1].FractionMax, lo, hi)
+	}
+}
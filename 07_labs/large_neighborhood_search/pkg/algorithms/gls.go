@@ -0,0 +1,136 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+)
+
+// GLSConfig holds configuration for Guided Local Search.
+type GLSConfig struct {
+	TimeLimit     time.Duration // Time limit for the algorithm
+	MaxIterations int           // Maximum number of local searches (0 = unlimited)
+	Alpha         float64       // Penalty weight factor a in lambda = a * f(first local optimum) / features (default 0.3)
+	Seed          int64
+}
+
+// GLSResult contains the result of Guided Local Search execution.
+type GLSResult[W Weight] struct {
+	BestSolution Solution[W]
+	Iterations   int // number of local searches
+	Duration     time.Duration
+}
+
+// glsState keeps the penalties of the features and the augmented instance
+// seen by the local search: D[a][b] + lambda*p(a, b) for edges and
+// costs[v] + lambda*p(v) for selected nodes.
+type glsState[W Weight] struct {
+	lambda      W
+	edgePenalty [][]int
+	nodePenalty []int
+	augD        [][]W
+	augCosts    []W
+}
+
+func newGLSState[W Weight](D [][]W, costs []W) *glsState[W] {
+	dim := len(D)
+	gs := &glsState[W]{
+		edgePenalty: make([][]int, dim),
+		nodePenalty: make([]int, dim),
+		augD:        make([][]W, dim),
+		augCosts:    append([]W(nil), costs...),
+	}
+	for i := range D {
+		gs.edgePenalty[i] = make([]int, dim)
+		gs.augD[i] = append([]W(nil), D[i]...)
+	}
+	return gs
+}
+
+// penalize increases the penalty of every feature of path with maximal
+// utility: cost / (1 + penalty), where the cost of an edge is its length and
+// the cost of a node is its node cost.
+func (gs *glsState[W]) penalize(D [][]W, costs []W, path []int) {
+	n := len(path)
+	maxUtil := math.Inf(-1)
+	for i, a := range path {
+		b := path[nextIdx(i, n)]
+		maxUtil = math.Max(maxUtil, float64(D[a][b])/float64(1+gs.edgePenalty[a][b]))
+		maxUtil = math.Max(maxUtil, float64(costs[a])/float64(1+gs.nodePenalty[a]))
+	}
+	for i, a := range path {
+		b := path[nextIdx(i, n)]
+		if float64(D[a][b])/float64(1+gs.edgePenalty[a][b]) == maxUtil {
+			gs.edgePenalty[a][b]++
+			gs.edgePenalty[b][a]++
+			gs.augD[a][b] += gs.lambda
+			gs.augD[b][a] += gs.lambda
+		}
+		if float64(costs[a])/float64(1+gs.nodePenalty[a]) == maxUtil {
+			gs.nodePenalty[a]++
+			gs.augCosts[a] += gs.lambda
+		}
+	}
+}
+
+// GuidedLocalSearch runs Guided Local Search: steepest local search is
+// applied to the augmented objective, which adds lambda times the penalty of
+// every tour edge and selected node, and at each local optimum the features
+// with maximal utility are penalised so that the next descent is pushed away
+// from them. The best solution is tracked under the real objective. The run
+// stops at the time limit or after MaxIterations local searches, whichever
+// comes first; without either, the time limit defaults to one second.
+func GuidedLocalSearch[W Weight](D [][]W, costs []W, config GLSConfig) GLSResult[W] {
+	startTime := time.Now()
+	rng := rand.New(rand.NewSource(config.Seed))
+
+	if config.TimeLimit == 0 && config.MaxIterations == 0 {
+		config.TimeLimit = time.Second
+	}
+	if config.Alpha <= 0 {
+		config.Alpha = 0.3
+	}
+
+	ws := newLSWorkspace[W]()
+	gs := newGLSState(D, costs)
+
+	// Plain local search first; its objective scales lambda.
+	current := ws.localSearchSteepest(D, costs, startRandom(D, costs, rng))
+	best := Solution[W]{Path: append([]int(nil), current.Path...), Objective: current.Objective}
+	path := current.Path
+	iterations := 1
+
+	// features of a solution: its len(path) edges and len(path) nodes
+	lambda := config.Alpha * float64(current.Objective) / float64(2*len(path))
+	if deltas.Integral[W]() {
+		lambda = math.Round(lambda)
+	}
+	gs.lambda = W(lambda)
+	if gs.lambda < 1 {
+		gs.lambda = 1
+	}
+
+	for {
+		if config.TimeLimit > 0 && time.Since(startTime) >= config.TimeLimit {
+			break
+		}
+		if config.MaxIterations > 0 && iterations >= config.MaxIterations {
+			break
+		}
+		gs.penalize(D, costs, path)
+		ws.steepest(gs.augD, gs.augCosts, path)
+		iterations++
+
+		if obj := objective(D, costs, path); obj < best.Objective {
+			best = Solution[W]{Path: append(best.Path[:0], path...), Objective: obj}
+		}
+	}
+
+	return GLSResult[W]{
+		BestSolution: best,
+		Iterations:   iterations,
+		Duration:     time.Since(startTime),
+	}
+}
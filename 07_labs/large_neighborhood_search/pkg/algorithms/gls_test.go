@@ -0,0 +1,53 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// TestGuidedLocalSearchIterationBudget checks that a run without a time limit
+// performs exactly MaxIterations local searches and returns a valid tour with
+// its real, unpenalised objective.
+func TestGuidedLocalSearchIterationBudget(t *testing.T) {
+	D, costs := randomInstance(50, rand.New(rand.NewSource(1)))
+	for _, budget := range []int{1, 2, 10} {
+		res := GuidedLocalSearch(D, costs, GLSConfig{MaxIterations: budget, Seed: 1})
+		if res.Iterations != budget {
+			t.Errorf("%d local searches, want %d", res.Iterations, budget)
+		}
+		path := res.BestSolution.Path
+		if len(path) != selectCount(len(D)) || res.BestSolution.Objective != objective(D, costs, path) {
+			t.Fatalf("invalid solution %v with objective %d", path, res.BestSolution.Objective)
+		}
+		seen := make([]bool, len(D))
+		for _, v := range path {
+			if seen[v] {
+				t.Fatalf("node %d visited twice in %v", v, path)
+			}
+			seen[v] = true
+		}
+	}
+}
+
+// TestExactWeights runs every method on float64 distances and checks that
+// the reported objective matches the returned tour.
+func TestExactWeights(t *testing.T) {
+	D, costs := exactInstance(40, rand.New(rand.NewSource(1)))
+	limit := 20 * time.Millisecond
+	results := map[string]Solution[float64]{
+		"LNS":  LargeNeighborhoodSearch(D, costs, LNSConfig{UseLocalSearch: true, TimeLimit: limit}).BestSolution,
+		"ALNS": LargeNeighborhoodSearch(D, costs, LNSConfig{Adaptive: true, TimeLimit: limit}).BestSolution,
+		"SA":   SimulatedAnnealing(D, costs, SAConfig{MaxEvaluations: 20000, Seed: 1}).BestSolution,
+		"GLS":  GuidedLocalSearch(D, costs, GLSConfig{MaxIterations: 5, Seed: 1}).BestSolution,
+	}
+	for name, sol := range results {
+		if len(sol.Path) != selectCount(len(D)) {
+			t.Errorf("%s: %d nodes selected, want %d", name, len(sol.Path), selectCount(len(D)))
+		}
+		if got := objective(D, costs, sol.Path); math.Abs(got-sol.Objective) > 1e-6 {
+			t.Errorf("%s: objective %f, tour evaluates to %f", name, sol.Objective, got)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+)
+
+// randomInstance builds a random Euclidean instance with dim nodes placed on
+// a 1000x1000 grid, with rounded distances and node costs in [0, 500).
+func randomInstance(dim int, rng *rand.Rand) ([][]int, []int) {
+	xs := make([]int, dim)
+	ys := make([]int, dim)
+	costs := make([]int, dim)
+	for i := 0; i < dim; i++ {
+		xs[i], ys[i], costs[i] = rng.Intn(1000), rng.Intn(1000), rng.Intn(500)
+	}
+	D := make([][]int, dim)
+	for i := range D {
+		D[i] = make([]int, dim)
+		for j := range D[i] {
+			if i != j {
+				D[i][j] = int(math.Round(math.Hypot(float64(xs[i]-xs[j]), float64(ys[i]-ys[j]))))
+			}
+		}
+	}
+	return D, costs
+}
+
+// randomTour selects between minLen and len(D) nodes at random and returns
+// them in random order.
+func randomTour(D [][]int, minLen int, rng *rand.Rand) []int {
+	dim := len(D)
+	k := minLen
+	if dim > minLen {
+		k += rng.Intn(dim - minLen + 1)
+	}
+	return rng.Perm(dim)[:k]
+}
+
+// exactInstance builds a random Euclidean instance like randomInstance, but
+// with unrounded float64 distances.
+func exactInstance(dim int, rng *rand.Rand) ([][]float64, []float64) {
+	D := make([][]float64, dim)
+	costs := make([]float64, dim)
+	xs := make([]float64, dim)
+	ys := make([]float64, dim)
+	for i := 0; i < dim; i++ {
+		xs[i], ys[i], costs[i] = rng.Float64()*1000, rng.Float64()*1000, float64(rng.Intn(500))
+	}
+	for i := range D {
+		D[i] = make([]float64, dim)
+		for j := range D[i] {
+			D[i][j] = math.Hypot(xs[i]-xs[j], ys[i]-ys[j])
+		}
+	}
+	return D, costs
+}
@@ -4,25 +4,35 @@ import (
 	"math"
 	"math/rand"
 	"time"
+
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+	ils "github.com/czajkowskis/evolutionary_computation/06_labs/local_search_extensions/pkg/algorithms"
 )
 
 // LNSConfig holds configuration for Large Neighborhood Search
 type LNSConfig struct {
-	DestroyFraction float64       // Fraction of nodes to destroy (default 0.3)
-	UseLocalSearch  bool          // Whether to use local search after repair
-	TimeLimit       time.Duration // Time limit for the algorithm
-	DestroyMethod   string        // Method: "weighted", "worst_edges", "shaw", "random_subpath"
+	DestroyFraction float64              // Fraction of nodes to destroy (default 0.3)
+	UseLocalSearch  bool                 // Whether to use local search after repair
+	TimeLimit       time.Duration        // Time limit for the algorithm
+	DestroyMethod   string               // Method: "weighted", "worst_edges", "shaw", "random_subpath"
+	RepairMethod    string               // Method: "nearest_neighbor" (default), "greedy_cycle", "regret2", "regret3", "noisy"
+	RepairNoise     float64              // Noise level of the "noisy" repair, relative to the largest distance (default 0.025)
+	Adaptive        bool                 // Use ALNS: choose destroy and repair operators adaptively
+	Acceptance      ils.AcceptanceConfig // When the repaired solution replaces the current one (default: strict improvement)
+	ALNS            ALNSConfig           // Parameters of the adaptive mode
 }
 
 // LNSResult contains the result of LNS execution
-type LNSResult struct {
-	BestSolution Solution
+type LNSResult[W Weight] struct {
+	BestSolution Solution[W]
 	Iterations   int
 	Duration     time.Duration
+	DestroyStats []OperatorStats // final ALNS destroy operator statistics (adaptive mode only)
+	RepairStats  []OperatorStats // final ALNS repair operator statistics (adaptive mode only)
 }
 
 // LargeNeighborhoodSearch implements LNS algorithm
-func LargeNeighborhoodSearch(D [][]int, costs []int, config LNSConfig) LNSResult {
+func LargeNeighborhoodSearch[W Weight](D [][]W, costs []W, config LNSConfig) LNSResult[W] {
 	startTime := time.Now()
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 
@@ -35,12 +45,27 @@ func LargeNeighborhoodSearch(D [][]int, costs []int, config LNSConfig) LNSResult
 	if config.DestroyMethod == "" {
 		config.DestroyMethod = "worst_edges" // Default to best performing method
 	}
+	if config.RepairMethod == "" {
+		config.RepairMethod = "nearest_neighbor"
+	}
+	if config.RepairNoise <= 0 {
+		config.RepairNoise = 0.025
+	}
+
+	// One local search workspace is reused by all iterations
+	ws := newLSWorkspace[W]()
+
+	if config.Adaptive {
+		return adaptiveLNS(D, costs, config, ws, rng, startTime)
+	}
 
 	// Generate initial random solution
 	currentSolution := startRandom(D, costs, rng)
 
 	// Apply local search to initial solution
-	currentSolution = localSearchSteepest(D, costs, currentSolution)
+	currentSolution = ws.localSearchSteepest(D, costs, currentSolution)
+	bestSolution := Solution[W]{Path: append([]int(nil), currentSolution.Path...), Objective: currentSolution.Objective}
+	acc := ils.NewAcceptor(config.Acceptance, currentSolution.Objective)
 
 	iterations := 0
 
@@ -48,44 +73,77 @@ func LargeNeighborhoodSearch(D [][]int, costs []int, config LNSConfig) LNSResult
 		iterations++
 
 		// Destroy: remove nodes from current solution using selected method
-		var partialPath []int
-		switch config.DestroyMethod {
-		case "worst_edges":
-			partialPath = destroyWorstEdges(currentSolution.Path, config.DestroyFraction, D, costs, rng)
-		case "shaw":
-			partialPath = destroyShaw(currentSolution.Path, config.DestroyFraction, D, costs, rng)
-		case "random_subpath":
-			partialPath = destroyRandomSubpath(currentSolution.Path, config.DestroyFraction, rng)
-		case "weighted":
-			partialPath = destroy(currentSolution.Path, config.DestroyFraction, D, costs, rng)
-		default:
-			partialPath = destroyWorstEdges(currentSolution.Path, config.DestroyFraction, D, costs, rng)
-		}
+		partialPath := applyDestroy(config.DestroyMethod, currentSolution.Path, config.DestroyFraction, D, costs, rng)
 
-		// Repair: rebuild solution using nearest neighbor any position
-		repairedSolution := repair(partialPath, D, costs, k, rng)
+		// Repair: rebuild solution using selected method
+		repairedSolution := ws.applyRepair(config.RepairMethod, partialPath, D, costs, k, config.RepairNoise, rng)
 
 		// Optional local search after repair
 		if config.UseLocalSearch {
-			repairedSolution = localSearchSteepest(D, costs, repairedSolution)
+			repairedSolution = ws.localSearchSteepest(D, costs, repairedSolution)
 		}
 
-		// Accept if improved
-		if repairedSolution.Objective < currentSolution.Objective {
+		// Accept according to the acceptance criterion; the best solution is
+		// kept separately since the current one may get worse
+		progress := float64(time.Since(startTime)) / float64(config.TimeLimit)
+		if acc.Accept(repairedSolution.Objective, currentSolution.Objective, bestSolution.Objective, progress, rng) {
 			currentSolution = repairedSolution
 		}
+		if repairedSolution.Objective < bestSolution.Objective {
+			bestSolution = Solution[W]{Path: append(bestSolution.Path[:0], repairedSolution.Path...), Objective: repairedSolution.Objective}
+		}
 	}
 	elapsed := time.Since(startTime)
-	return LNSResult{
-		BestSolution: currentSolution,
+	return LNSResult[W]{
+		BestSolution: bestSolution,
 		Iterations:   iterations,
 		Duration:     elapsed,
 	}
 }
 
-// destroyWorstEdges removes nodes incident to the longest/most expensive edges
+// DestroyMethods lists the names of all destroy operators.
+var DestroyMethods = []string{"worst_edges", "shaw", "random_subpath", "weighted"}
+
+// RepairMethods lists the names of all repair operators.
+var RepairMethods = []string{"nearest_neighbor", "greedy_cycle", "regret2", "regret3", "noisy"}
+
+// applyDestroy removes nodes from path with the named destroy operator; an
+// unknown name falls back to worst_edges.
+func applyDestroy[W Weight](method string, path []int, fraction float64, D [][]W, costs []W, rng *rand.Rand) []int {
+	switch method {
+	case "shaw":
+		return DestroyShaw(path, fraction, D, costs, rng)
+	case "random_subpath":
+		return destroyRandomSubpath(path, fraction, rng)
+	case "weighted":
+		return destroy(path, fraction, D, costs, rng)
+	default:
+		return DestroyWorstEdges(path, fraction, D, costs, rng)
+	}
+}
+
+// applyRepair completes partialPath to targetSize nodes with the named repair
+// operator; an unknown name falls back to nearest_neighbor. noise is used by
+// the "noisy" repair only. The insertion-based repairs reuse the insertion
+// cache of the workspace.
+func (ws *lsWorkspace[W]) applyRepair(method string, partialPath []int, D [][]W, costs []W, targetSize int, noise float64, rng *rand.Rand) Solution[W] {
+	switch method {
+	case "greedy_cycle":
+		return ws.repairGreedyCycle(partialPath, D, costs, targetSize)
+	case "regret2":
+		return ws.repairRegret(partialPath, D, costs, targetSize, 2)
+	case "regret3":
+		return ws.repairRegret(partialPath, D, costs, targetSize, 3)
+	case "noisy":
+		return ws.repairNoisy(partialPath, D, costs, targetSize, noise, rng)
+	default:
+		return repair(partialPath, D, costs, targetSize, rng)
+	}
+}
+
+// DestroyWorstEdges removes nodes incident to the longest/most expensive edges
 // This is typically the most effective for TSP-like problems
-func destroyWorstEdges(path []int, fraction float64, D [][]int, costs []int, rng *rand.Rand) []int {
+func DestroyWorstEdges[W Weight](path []int, fraction float64, D [][]W, costs []W, rng *rand.Rand) []int {
 	numToRemove := int(math.Ceil(float64(len(path)) * fraction))
 	if numToRemove >= len(path) {
 		numToRemove = len(path) - 1
@@ -161,9 +219,9 @@ func destroyWorstEdges(path []int, fraction float64, D [][]int, costs []int, rng
 	return partial
 }
 
-// destroyShaw removes related nodes based on Shaw removal heuristic
+// DestroyShaw removes related nodes based on Shaw removal heuristic
 // Nodes that are similar (close in space and have similar costs) are removed together
-func destroyShaw(path []int, fraction float64, D [][]int, costs []int, rng *rand.Rand) []int {
+func DestroyShaw[W Weight](path []int, fraction float64, D [][]W, costs []W, rng *rand.Rand) []int {
 	numToRemove := int(math.Ceil(float64(len(path)) * fraction))
 	if numToRemove >= len(path) {
 		numToRemove = len(path) - 1
@@ -266,7 +324,7 @@ func destroyRandomSubpath(path []int, fraction float64, rng *rand.Rand) []int {
 
 // destroy removes a fraction of nodes from the solution
 // Nodes with higher costs and longer edges have higher probability of removal
-func destroy(path []int, fraction float64, D [][]int, costs []int, rng *rand.Rand) []int {
+func destroy[W Weight](path []int, fraction float64, D [][]W, costs []W, rng *rand.Rand) []int {
 	numToRemove := int(math.Ceil(float64(len(path)) * fraction))
 	if numToRemove >= len(path) {
 		numToRemove = len(path) - 1
@@ -330,20 +388,21 @@ func destroy(path []int, fraction float64, D [][]int, costs []int, rng *rand.Ran
 }
 
 // repair rebuilds the solution using nearest neighbor any position heuristic
-func repair(partialPath []int, D [][]int, costs []int, targetSize int, rng *rand.Rand) Solution {
+func repair[W Weight](partialPath []int, D [][]W, costs []W, targetSize int, rng *rand.Rand) Solution[W] {
 	n := len(costs)
 
 	// Create set of nodes already in solution
-	inSolution := make(map[int]bool)
+	inSolution := make([]bool, n)
 	for _, node := range partialPath {
 		inSolution[node] = true
 	}
 
-	// Create list of unvisited nodes
-	unvisited := make(map[int]bool)
+	// Create list of unvisited nodes (in index order, so ties are broken
+	// deterministically)
+	unvisited := make([]int, 0, n)
 	for i := 0; i < n; i++ {
 		if !inSolution[i] {
-			unvisited[i] = true
+			unvisited = append(unvisited, i)
 		}
 	}
 
@@ -352,12 +411,13 @@ func repair(partialPath []int, D [][]int, costs []int, targetSize int, rng *rand
 
 	// Add nodes using greedy nearest neighbor any position
 	for len(path) < targetSize && len(unvisited) > 0 {
-		minIncrease := math.MaxInt32
+		minIncrease := deltas.MaxWeight[W]()
 		bestNode := -1
+		bestNodeIdx := -1
 		bestPosition := -1
 
-		for node := range unvisited {
-			localMinIncrease := math.MaxInt32
+		for idx, node := range unvisited {
+			localMinIncrease := deltas.MaxWeight[W]()
 			localBestPos := -1
 
 			if len(path) == 0 {
@@ -378,7 +438,7 @@ func repair(partialPath []int, D [][]int, costs []int, targetSize int, rng *rand
 			} else {
 				// Try all positions
 				for pos := 0; pos <= len(path); pos++ {
-					var inc int
+					var inc W
 					if pos == 0 {
 						// Insert at beginning
 						inc = D[node][path[0]] + costs[node]
@@ -403,6 +463,7 @@ func repair(partialPath []int, D [][]int, costs []int, targetSize int, rng *rand
 			if localMinIncrease < minIncrease {
 				minIncrease = localMinIncrease
 				bestNode = node
+				bestNodeIdx = idx
 				bestPosition = localBestPos
 			}
 		}
@@ -414,11 +475,11 @@ func repair(partialPath []int, D [][]int, costs []int, targetSize int, rng *rand
 			} else {
 				path = append(path[:bestPosition], append([]int{bestNode}, path[bestPosition:]...)...)
 			}
-			delete(unvisited, bestNode)
+			unvisited = append(unvisited[:bestNodeIdx], unvisited[bestNodeIdx+1:]...)
 		} else {
 			break
 		}
 	}
 
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
@@ -0,0 +1,251 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+)
+
+// insertionCache supports repair operators that insert nodes into the
+// partial solution treated as a cycle. For every free node v and every cycle
+// node a it caches the cost of inserting v between a and its successor,
+// cost[v*dim+a], together with the three cheapest insertions of v. After
+// inserting a node x after a only the entries of the edges (a, x) and (x, b)
+// change, so a full rescan of v's entries is needed only when the removed
+// edge (a, b) was among the three cheapest insertions of v.
+type insertionCache[W Weight] struct {
+	D       [][]W
+	costs   []W
+	dim     int
+	succ    []int // successor of every cycle node, -1 for free nodes
+	cycle   []int // cycle nodes in insertion order
+	free    []int // free nodes
+	freeIdx []int // position of every free node in free, -1 for cycle nodes
+	cost    []W
+	top     [][3]int // cycle nodes a with the cheapest insertions after a, by cost
+	topLen  []int
+}
+
+// reset prepares the cache for completing partialPath. The buffers are reused
+// across repairs and only allocated when the instance is larger than before.
+func (c *insertionCache[W]) reset(D [][]W, costs []W, partialPath []int) {
+	dim := len(D)
+	if cap(c.succ) < dim {
+		c.succ = make([]int, dim)
+		c.cycle = make([]int, 0, dim)
+		c.free = make([]int, 0, dim)
+		c.freeIdx = make([]int, dim)
+		c.cost = make([]W, dim*dim)
+		c.top = make([][3]int, dim)
+		c.topLen = make([]int, dim)
+	}
+	c.D, c.costs, c.dim = D, costs, dim
+	c.succ = c.succ[:dim]
+	c.cycle = c.cycle[:0]
+	c.free = c.free[:0]
+	c.freeIdx = c.freeIdx[:dim]
+	c.cost = c.cost[:dim*dim]
+	c.top = c.top[:dim]
+	c.topLen = c.topLen[:dim]
+
+	for v := range c.succ {
+		c.succ[v] = -1
+	}
+	for i, a := range partialPath {
+		c.succ[a] = partialPath[nextIdx(i, len(partialPath))]
+		c.cycle = append(c.cycle, a)
+	}
+	for v := 0; v < dim; v++ {
+		c.freeIdx[v] = -1
+		if c.succ[v] < 0 {
+			c.freeIdx[v] = len(c.free)
+			c.free = append(c.free, v)
+		}
+	}
+	for _, v := range c.free {
+		c.rescan(v)
+	}
+}
+
+// insertionCost returns the cost of inserting v between a and succ[a].
+func (c *insertionCache[W]) insertionCost(v, a int) W {
+	b := c.succ[a]
+	return c.D[a][v] + c.D[v][b] - c.D[a][b] + c.costs[v]
+}
+
+// offer puts the insertion after a into the three cheapest insertions of v
+// if it belongs there.
+func (c *insertionCache[W]) offer(v, a int) {
+	row := c.cost[v*c.dim:]
+	top := &c.top[v]
+	n := c.topLen[v]
+	if n == 3 && row[a] >= row[top[2]] {
+		return
+	}
+	if n < 3 {
+		n++
+		c.topLen[v] = n
+	}
+	i := n - 1
+	for ; i > 0 && row[top[i-1]] > row[a]; i-- {
+		top[i] = top[i-1]
+	}
+	top[i] = a
+}
+
+// rescan recomputes all insertion costs of v and its three cheapest ones.
+func (c *insertionCache[W]) rescan(v int) {
+	c.topLen[v] = 0
+	row := c.cost[v*c.dim:]
+	for _, a := range c.cycle {
+		row[a] = c.insertionCost(v, a)
+		c.offer(v, a)
+	}
+}
+
+// bestCosts returns the cost of the cheapest insertion of v and the sum of
+// the differences between the next k-1 cheapest insertions and it (the
+// regret-k value). Missing insertions count as the most expensive one known.
+func (c *insertionCache[W]) bestCosts(v, k int) (best, regret W) {
+	row := c.cost[v*c.dim:]
+	n := c.topLen[v]
+	if n == 0 {
+		return c.costs[v], 0
+	}
+	best = row[c.top[v][0]]
+	for i := 1; i < k; i++ {
+		regret += row[c.top[v][min(i, n-1)]] - best
+	}
+	return best, regret
+}
+
+// insert puts free node x into the cycle after a (its cheapest insertion)
+// and updates the cached costs of the remaining free nodes.
+func (c *insertionCache[W]) insert(x int) {
+	// remove x from the free nodes
+	i := c.freeIdx[x]
+	last := c.free[len(c.free)-1]
+	c.free[i], c.freeIdx[last] = last, i
+	c.free = c.free[:len(c.free)-1]
+	c.freeIdx[x] = -1
+
+	if len(c.cycle) == 0 {
+		c.succ[x] = x
+		c.cycle = append(c.cycle, x)
+		for _, v := range c.free {
+			c.rescan(v)
+		}
+		return
+	}
+
+	a := c.top[x][0]
+	b := c.succ[a]
+	c.succ[a], c.succ[x] = x, b
+	c.cycle = append(c.cycle, x)
+
+	for _, v := range c.free {
+		row := c.cost[v*c.dim:]
+		stale := false
+		for t := 0; t < c.topLen[v]; t++ {
+			if c.top[v][t] == a {
+				stale = true
+				break
+			}
+		}
+		if stale {
+			c.rescan(v)
+			continue
+		}
+		row[a] = c.insertionCost(v, a)
+		row[x] = c.insertionCost(v, x)
+		c.offer(v, a)
+		c.offer(v, x)
+	}
+}
+
+// solution returns the cycle as a path starting from its first node.
+func (c *insertionCache[W]) solution() Solution[W] {
+	path := make([]int, 0, len(c.cycle))
+	if len(c.cycle) > 0 {
+		start := c.cycle[0]
+		for v := start; ; {
+			path = append(path, v)
+			v = c.succ[v]
+			if v == start {
+				break
+			}
+		}
+	}
+	return Solution[W]{Path: path, Objective: objective(c.D, c.costs, path)}
+}
+
+// repairGreedyCycle rebuilds the solution with the greedy cycle heuristic:
+// the partial path is treated as a cycle and the node with the cheapest
+// insertion between two consecutive nodes (including the closing edge) is
+// inserted until targetSize nodes are selected.
+func (ws *lsWorkspace[W]) repairGreedyCycle(partialPath []int, D [][]W, costs []W, targetSize int) Solution[W] {
+	c := &ws.ins
+	c.reset(D, costs, partialPath)
+	for len(c.cycle) < targetSize && len(c.free) > 0 {
+		bestNode, bestCost := -1, deltas.MaxWeight[W]()
+		for _, v := range c.free {
+			if cost, _ := c.bestCosts(v, 1); cost < bestCost || (cost == bestCost && v < bestNode) {
+				bestNode, bestCost = v, cost
+			}
+		}
+		c.insert(bestNode)
+	}
+	return c.solution()
+}
+
+// repairRegret rebuilds the solution with the weighted regret-k heuristic of
+// lab 02: in every step the node maximising regret-k minus its cheapest
+// insertion cost is inserted at its cheapest position, so that nodes which
+// would become expensive to insert later are inserted first while expensive
+// nodes are still avoided.
+func (ws *lsWorkspace[W]) repairRegret(partialPath []int, D [][]W, costs []W, targetSize, k int) Solution[W] {
+	c := &ws.ins
+	c.reset(D, costs, partialPath)
+	for len(c.cycle) < targetSize && len(c.free) > 0 {
+		bestNode, bestScore, bestCost := -1, -deltas.MaxWeight[W](), W(0)
+		for _, v := range c.free {
+			cost, regret := c.bestCosts(v, k)
+			score := regret - cost
+			if score > bestScore || (score == bestScore && (cost < bestCost || (cost == bestCost && v < bestNode))) {
+				bestNode, bestScore, bestCost = v, score, cost
+			}
+		}
+		c.insert(bestNode)
+	}
+	return c.solution()
+}
+
+// repairNoisy rebuilds the solution with randomised cheapest insertion: the
+// cheapest insertion cost of every node is perturbed by uniform noise in
+// [-noise*maxD, noise*maxD], where maxD is the largest distance, before the
+// cheapest node is chosen, so that repeated repairs of the same partial
+// solution explore different completions.
+func (ws *lsWorkspace[W]) repairNoisy(partialPath []int, D [][]W, costs []W, targetSize int, noise float64, rng *rand.Rand) Solution[W] {
+	var maxD W
+	for i := range D {
+		for _, d := range D[i] {
+			maxD = max(maxD, d)
+		}
+	}
+	amplitude := noise * float64(maxD)
+
+	c := &ws.ins
+	c.reset(D, costs, partialPath)
+	for len(c.cycle) < targetSize && len(c.free) > 0 {
+		bestNode, bestCost := -1, math.Inf(1)
+		for _, v := range c.free {
+			cost, _ := c.bestCosts(v, 1)
+			if noisy := float64(cost) + amplitude*(2*rng.Float64()-1); noisy < bestCost {
+				bestNode, bestCost = v, noisy
+			}
+		}
+		c.insert(bestNode)
+	}
+	return c.solution()
+}
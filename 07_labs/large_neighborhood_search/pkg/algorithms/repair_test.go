@@ -0,0 +1,59 @@
+package algorithms
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+var insertionRepairs = []string{"greedy_cycle", "regret2", "regret3", "noisy"}
+
+// TestRepairReusesInsertionCache checks that repairs on a reused workspace
+// give the same solutions as repairs on a fresh one, including after a
+// repair on a larger instance.
+func TestRepairReusesInsertionCache(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	small, smallCosts := randomInstance(30, rng)
+	large, largeCosts := randomInstance(60, rng)
+
+	shared := newLSWorkspace[int]()
+	for trial := 0; trial < 50; trial++ {
+		D, costs := small, smallCosts
+		if trial%3 == 1 {
+			D, costs = large, largeCosts
+		}
+		k := selectCount(len(D))
+		partial := rng.Perm(len(D))[:rng.Intn(k)]
+		for _, method := range insertionRepairs {
+			seed := rng.Int63()
+			got := shared.applyRepair(method, partial, D, costs, k, 0.1, rand.New(rand.NewSource(seed)))
+			want := newLSWorkspace[int]().applyRepair(method, partial, D, costs, k, 0.1, rand.New(rand.NewSource(seed)))
+			if got.Objective != want.Objective || !slices.Equal(got.Path, want.Path) {
+				t.Fatalf("%s of %v: reused cache gave %v (%d), fresh cache %v (%d)",
+					method, partial, got.Path, got.Objective, want.Path, want.Objective)
+			}
+			if len(got.Path) != k {
+				t.Fatalf("%s of %v: %d nodes, want %d", method, partial, len(got.Path), k)
+			}
+		}
+	}
+}
+
+// TestRepairAllocations checks that a warm workspace allocates only the
+// returned path during an insertion-based repair.
+func TestRepairAllocations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	D, costs := randomInstance(100, rng)
+	k := selectCount(len(D))
+	partial := rng.Perm(len(D))[:k/2]
+	ws := newLSWorkspace[int]()
+	for _, method := range insertionRepairs {
+		ws.applyRepair(method, partial, D, costs, k, 0.1, rng)
+		allocs := testing.AllocsPerRun(10, func() {
+			ws.applyRepair(method, partial, D, costs, k, 0.1, rng)
+		})
+		if allocs > 1 {
+			t.Errorf("%s: %v allocations per repair, want at most 1", method, allocs)
+		}
+	}
+}
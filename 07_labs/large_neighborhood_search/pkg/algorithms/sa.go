@@ -0,0 +1,263 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+)
+
+// CoolingSchedule selects how the temperature of simulated annealing changes.
+type CoolingSchedule int
+
+const (
+	// CoolingGeometric decreases the temperature exponentially from the
+	// initial to the final temperature over the budget.
+	CoolingGeometric CoolingSchedule = iota
+	// CoolingLinear decreases the temperature linearly over the budget.
+	CoolingLinear
+	// CoolingLam adapts the temperature so that the acceptance rate follows
+	// the target acceptance curve of the Lam schedule.
+	CoolingLam
+	// CoolingReheat cools geometrically and raises the temperature again when
+	// the best solution has not improved for ReheatAfter evaluations.
+	CoolingReheat
+)
+
+func (s CoolingSchedule) String() string {
+	switch s {
+	case CoolingLinear:
+		return "linear"
+	case CoolingLam:
+		return "lam"
+	case CoolingReheat:
+		return "reheat"
+	default:
+		return "geometric"
+	}
+}
+
+// SAConfig holds configuration for Simulated Annealing.
+type SAConfig struct {
+	Schedule          CoolingSchedule
+	TimeLimit         time.Duration // Time limit for the algorithm
+	MaxEvaluations    int           // Maximum number of evaluated moves (0 = unlimited)
+	InitialTemp       float64       // Initial temperature (0 = estimate from sampled deltas)
+	FinalTemp         float64       // Final temperature (default InitialTemp/1000)
+	InitialAcceptance float64       // Acceptance probability of an average worsening move at the start (default 0.5)
+	ReheatAfter       int           // Evaluations without a new best before reheating (default 100000)
+	ReheatFraction    float64       // Reheated temperature as a fraction of the initial one (default 0.3)
+	Seed              int64
+}
+
+// SAResult contains the result of Simulated Annealing execution.
+type SAResult[W Weight] struct {
+	BestSolution Solution[W]
+	Iterations   int // number of evaluated moves
+	Accepted     int // number of accepted moves
+	Duration     time.Duration
+}
+
+const (
+	saTempSamples      = 1000 // random moves sampled to estimate the initial temperature
+	saTimeCheckPeriod  = 256  // evaluations between checks of the elapsed time
+	lamAdjust          = 0.999
+	lamRateSmoothing   = 0.002 // weight of the latest move in the acceptance rate average
+	lamInitialRate     = 0.44
+	lamFastPhaseEnd    = 0.15
+	lamSlowPhaseStart  = 0.65
+	lamFastPhaseFactor = 560.0
+	lamSlowPhaseFactor = 440.0
+)
+
+// saMove describes a random move drawn by simulated annealing. For 2-opt
+// moves i and j are the cut indices; for exchanges path[i] is replaced by the
+// unselected vertex j.
+type saMove struct {
+	exchange bool
+	i, j     int
+}
+
+// randomMove draws a 2-opt or an exchange move with equal probability.
+func randomMove(sel *deltas.Selection, path []int, rng *rand.Rand) saMove {
+	n := len(path)
+	if nonSel := sel.Unselected(); len(nonSel) > 0 && (n < 4 || rng.Intn(2) == 0) {
+		return saMove{exchange: true, i: rng.Intn(n), j: nonSel[rng.Intn(len(nonSel))]}
+	}
+	i := rng.Intn(n)
+	j := (i + 2 + rng.Intn(n-3)) % n // any position not adjacent to i
+	return saMove{i: i, j: j}
+}
+
+func deltaSAMove[W Weight](D [][]W, costs []W, path []int, m saMove) W {
+	if m.exchange {
+		return deltas.DeltaExchangeSelected(D, costs, path, m.i, m.j)
+	}
+	return deltas.DeltaTwoOpt(D, path, m.i, m.j)
+}
+
+// applyMove applies m to path keeping the selection state in sync.
+func applyMove(sel *deltas.Selection, path []int, m saMove) {
+	if m.exchange {
+		sel.ApplyExchange(path, m.i, m.j)
+	} else {
+		sel.ApplyTwoOpt(path, m.i, m.j)
+	}
+}
+
+// estimateInitialTemp samples random moves around path and returns the
+// temperature at which an average worsening move is accepted with
+// probability acceptance.
+func estimateInitialTemp[W Weight](sel *deltas.Selection, D [][]W, costs []W, path []int, acceptance float64, rng *rand.Rand) float64 {
+	var sum W
+	count := 0
+	for s := 0; s < saTempSamples; s++ {
+		if dl := deltaSAMove(D, costs, path, randomMove(sel, path, rng)); dl > 0 {
+			sum += dl
+			count++
+		}
+	}
+	if count == 0 {
+		return 1
+	}
+	return -float64(sum) / float64(count) / math.Log(acceptance)
+}
+
+// lamTargetRate returns the target acceptance rate of the Lam schedule at the
+// given fraction of the budget.
+func lamTargetRate(progress float64) float64 {
+	switch {
+	case progress < lamFastPhaseEnd:
+		return lamInitialRate + (1-lamInitialRate)*math.Pow(lamFastPhaseFactor, -progress/lamFastPhaseEnd)
+	case progress < lamSlowPhaseStart:
+		return lamInitialRate
+	default:
+		return lamInitialRate * math.Pow(lamSlowPhaseFactor, -(progress-lamSlowPhaseStart)/(1-lamSlowPhaseStart))
+	}
+}
+
+// SimulatedAnnealing runs simulated annealing over random 2-opt and exchange
+// moves from a random solution. Improving moves are always accepted and a
+// worsening move with delta d is accepted with probability exp(-d/T), so the
+// search is not elitist; the best solution visited is kept separately and
+// returned as found. The temperature follows the
+// configured schedule as a function of the consumed fraction of the time or
+// evaluation budget (whichever runs out first).
+func SimulatedAnnealing[W Weight](D [][]W, costs []W, config SAConfig) SAResult[W] {
+	startTime := time.Now()
+	rng := rand.New(rand.NewSource(config.Seed))
+
+	if config.TimeLimit == 0 && config.MaxEvaluations == 0 {
+		config.TimeLimit = time.Second
+	}
+	if config.InitialAcceptance <= 0 || config.InitialAcceptance >= 1 {
+		config.InitialAcceptance = 0.5
+	}
+	if config.ReheatAfter <= 0 {
+		config.ReheatAfter = 100000
+	}
+	if config.ReheatFraction <= 0 {
+		config.ReheatFraction = 0.3
+	}
+
+	path := startRandom(D, costs, rng).Path
+	var sel deltas.Selection
+	sel.Load(len(D), path)
+	current := objective(D, costs, path)
+	best := Solution[W]{Path: append([]int(nil), path...), Objective: current}
+
+	if len(path) < 4 {
+		return SAResult[W]{BestSolution: best, Duration: time.Since(startTime)}
+	}
+
+	t0 := config.InitialTemp
+	if t0 <= 0 {
+		t0 = estimateInitialTemp(&sel, D, costs, path, config.InitialAcceptance, rng)
+	}
+	tf := config.FinalTemp
+	if tf <= 0 || tf >= t0 {
+		tf = t0 / 1000
+	}
+
+	// progress returns the consumed fraction of the budget.
+	progress := func(evals int) float64 {
+		p := 0.0
+		if config.TimeLimit > 0 {
+			p = float64(time.Since(startTime)) / float64(config.TimeLimit)
+		}
+		if config.MaxEvaluations > 0 {
+			p = math.Max(p, float64(evals)/float64(config.MaxEvaluations))
+		}
+		return p
+	}
+
+	temp := t0
+	cycleTemp, cycleStart := t0, 0.0 // start of the current cooling cycle (reheat)
+	acceptRate := lamInitialRate
+	lastBest := 0
+	p := 0.0
+	evals, accepted := 0, 0
+
+	for p < 1 {
+		// the evaluation budget is cheap to check and must not be overrun
+		if config.MaxEvaluations > 0 && evals >= config.MaxEvaluations {
+			break
+		}
+		if evals%saTimeCheckPeriod == 0 {
+			p = progress(evals)
+			switch config.Schedule {
+			case CoolingGeometric:
+				temp = t0 * math.Pow(tf/t0, p)
+			case CoolingLinear:
+				temp = t0 + (tf-t0)*p
+			case CoolingReheat:
+				if evals-lastBest >= config.ReheatAfter {
+					cycleTemp, cycleStart = config.ReheatFraction*t0, p
+					lastBest = evals
+				}
+				temp = cycleTemp * math.Pow(tf/cycleTemp, (p-cycleStart)/(1-cycleStart))
+			}
+			if p >= 1 {
+				break
+			}
+		}
+
+		m := randomMove(&sel, path, rng)
+		dl := deltaSAMove(D, costs, path, m)
+		evals++
+
+		accept := dl <= 0 || rng.Float64() < math.Exp(-float64(dl)/temp)
+		if accept {
+			applyMove(&sel, path, m)
+			current += dl
+			accepted++
+			if current < best.Objective {
+				best = Solution[W]{Path: append(best.Path[:0], path...), Objective: current}
+				lastBest = evals
+			}
+		}
+
+		if config.Schedule == CoolingLam {
+			rate := 0.0
+			if accept {
+				rate = 1
+			}
+			acceptRate += lamRateSmoothing * (rate - acceptRate)
+			if acceptRate > lamTargetRate(p) {
+				temp *= lamAdjust
+			} else {
+				temp /= lamAdjust
+			}
+		}
+	}
+
+	// the running objective accumulates the rounding errors of float64 deltas
+	best.Objective = objective(D, costs, best.Path)
+	return SAResult[W]{
+		BestSolution: best,
+		Iterations:   evals,
+		Accepted:     accepted,
+		Duration:     time.Since(startTime),
+	}
+}
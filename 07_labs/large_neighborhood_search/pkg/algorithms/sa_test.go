@@ -0,0 +1,71 @@
+package algorithms
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+)
+
+// TestSimulatedAnnealingEvaluationBudget checks that every schedule stops
+// exactly at MaxEvaluations, also for budgets that are not a multiple of the
+// time check period.
+func TestSimulatedAnnealingEvaluationBudget(t *testing.T) {
+	D, costs := randomInstance(50, rand.New(rand.NewSource(1)))
+	for _, schedule := range []CoolingSchedule{CoolingGeometric, CoolingLinear, CoolingLam, CoolingReheat} {
+		for _, budget := range []int{1, saTimeCheckPeriod - 1, saTimeCheckPeriod + 1, 1000} {
+			t.Run(fmt.Sprintf("%v/%d", schedule, budget), func(t *testing.T) {
+				res := SimulatedAnnealing(D, costs, SAConfig{Schedule: schedule, MaxEvaluations: budget, Seed: 1})
+				if res.Iterations != budget {
+					t.Errorf("%d evaluations, want %d", res.Iterations, budget)
+				}
+			})
+		}
+	}
+}
+
+// TestSAMoveDeltas checks the moves drawn by simulated annealing: the delta
+// has to match the change of the objective and applying the move has to keep
+// the selection state of the workspace in sync with the path.
+func TestSAMoveDeltas(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, dim := range []int{5, 10, 25, 100} {
+		t.Run(fmt.Sprintf("random-%d", dim), func(t *testing.T) {
+			D, costs := randomInstance(dim, rng)
+			var sel deltas.Selection
+			for trial := 0; trial < 2000; trial++ {
+				path := randomTour(D, 4, rng)
+				sel.Load(dim, path)
+				before := objective(D, costs, path)
+				orig := append([]int(nil), path...)
+
+				m := randomMove(&sel, path, rng)
+				delta := deltaSAMove(D, costs, path, m)
+				applyMove(&sel, path, m)
+				if got := objective(D, costs, path) - before; got != delta {
+					t.Fatalf("%+v on %v: delta %d, objective changed by %d", m, orig, delta, got)
+				}
+
+				inSel := make([]bool, dim)
+				for _, v := range path {
+					inSel[v] = true
+				}
+				for k, v := range path {
+					if sel.Pos(v) != k {
+						t.Fatalf("%+v on %v: position of %d out of sync", m, orig, v)
+					}
+				}
+				nonSel := sel.Unselected()
+				if len(nonSel) != dim-len(path) {
+					t.Fatalf("%+v on %v: %d unselected vertices recorded, want %d", m, orig, len(nonSel), dim-len(path))
+				}
+				for _, u := range nonSel {
+					if inSel[u] || sel.Pos(u) != -1 {
+						t.Fatalf("%+v on %v: unselected vertex %d out of sync", m, orig, u)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package algorithms
+
+import (
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+)
+
+// lsWorkspace holds the buffers reused across the iterations of the
+// large neighborhood searches: the steepest local search of lab 05 and the
+// insertion cache of the repair operators. A warmed-up workspace performs no
+// heap allocations inside the local search loop.
+//
+// A workspace is bound to a single path at a time and is not safe for
+// concurrent use.
+type lsWorkspace[W Weight] struct {
+	ls  deltas.LocalSearcher[W] // baseline steepest local search over 2-opt and exchange moves
+	ins insertionCache[W]       // buffers of the insertion-based repair operators
+}
+
+// newLSWorkspace creates a workspace; its buffers grow with the first
+// instance it is used with.
+func newLSWorkspace[W Weight]() *lsWorkspace[W] {
+	return &lsWorkspace[W]{}
+}
+
+// localSearchSteepest performs steepest local search from init using the
+// workspace buffers. The returned solution owns a fresh copy of the path.
+func (ws *lsWorkspace[W]) localSearchSteepest(D [][]W, costs []W, init Solution[W]) Solution[W] {
+	path := append([]int(nil), init.Path...)
+	ws.steepest(D, costs, path)
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
+}
+
+// steepest improves path in place until no improving 2-opt or exchange move
+// remains.
+func (ws *lsWorkspace[W]) steepest(D [][]W, costs []W, path []int) {
+	ws.ls.Improve(D, costs, path)
+}
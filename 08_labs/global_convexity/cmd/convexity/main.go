@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"log"
 	"math"
@@ -10,12 +11,25 @@ import (
 	"os/exec"
 	"time"
 
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/03_labs/local_search/pkg/algorithms"
-	"github.com/czajkowskis/evolutionary_computation/03_labs/local_search/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/03_labs/local_search/pkg/utils"
 )
 
 func main() {
+	rounding := flag.String("rounding", "round", "distance and cost rounding: round, ceil, floor or exact")
+	scale := flag.Float64("scale", 1, "fixed-point factor applied to distances and costs before rounding")
+	flag.Parse()
+
+	policy, err := data.ParseDistancePolicy(*rounding, *scale)
+	if err != nil {
+		log.Fatalf("Invalid distance policy: %v", err)
+	}
+	run := processInstance[int]
+	if policy.Rounding == data.RoundExact {
+		run = processInstance[float64]
+	}
+
 	rand.Seed(time.Now().UnixNano())
 
 	// Load instances
@@ -34,230 +48,236 @@ func main() {
 			log.Fatalf("Error reading %s: %v", inst.Path, err)
 		}
 
-		D := data.CalculateDistanceMatrix(nodes)
-		costs := make([]int, len(nodes))
-		for i, node := range nodes {
-			costs[i] = node.Cost
-		}
+		run(inst.Name, nodes, policy)
+	}
 
-		// 1. Generate 1000 random local optima
-		log.Println("Generating 1000 random local optima...")
-		numSolutions := 1000
-
-		// Method for generating random local optima: Random Start + Greedy LS + Swap (as per assignment "random local optima obtained from random solutions using greedy local search")
-		// "greedy local search" usually implies Steepest or Greedy. The assignment says "greedy local search".
-		// In the provided code `LS_Greedy` exists. I will use `LS_Greedy`.
-		// Intra operator is not specified, I'll use IntraSwap as it's standard.
-		genMethod := algorithms.MethodSpec{
-			LS:    algorithms.LS_Greedy,
-			Intra: algorithms.IntraSwap,
-			Start: algorithms.StartRandom,
-			Name:  "Generator",
-		}
+	// Automatically regenerate plots
+	log.Println("Regenerating plots...")
+	cmd := exec.Command("python3", "plot_convexity.py")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		log.Printf("Error running plot script: %v", err)
+	} else {
+		log.Println("Plots regenerated successfully.")
+	}
+}
 
-		// We don't need startNodeIndices for StartRandom
-		solutions := algorithms.RunLocalSearchBatch(D, costs, nil, genMethod, numSolutions)
+// processInstance generates the local optima of one instance, computes their
+// similarities to each other and to the best solutions and reports the
+// correlations with the objective.
+func processInstance[W algorithms.Weight](name string, nodes []data.Node, policy data.DistancePolicy) {
+	D, costs, err := data.Weights[W](nodes, policy)
+	if err != nil {
+		log.Fatalf("Error computing distances: %v", err)
+	}
 
-		// 2. Identify "Best of 1000"
-		bestOf1000 := solutions[0]
-		for _, s := range solutions {
-			if s.Objective < bestOf1000.Objective {
-				bestOf1000 = s
-			}
+	// 1. Generate 1000 random local optima
+	log.Println("Generating 1000 random local optima...")
+	numSolutions := 1000
+
+	// Method for generating random local optima: Random Start + Greedy LS + Swap (as per assignment "random local optima obtained from random solutions using greedy local search")
+	// "greedy local search" usually implies Steepest or Greedy. The assignment says "greedy local search".
+	// In the provided code `LS_Greedy` exists. I will use `LS_Greedy`.
+	// Intra operator is not specified, I'll use IntraSwap as it's standard.
+	genMethod := algorithms.MethodSpec{
+		LS:    algorithms.LS_Greedy,
+		Intra: algorithms.IntraSwap,
+		Start: algorithms.StartRandom,
+		Name:  "Generator",
+	}
+
+	// We don't need startNodeIndices for StartRandom
+	solutions := algorithms.RunLocalSearchBatch(D, costs, nil, genMethod, numSolutions)
+
+	// 2. Identify "Best of 1000"
+	bestOf1000 := solutions[0]
+	for _, s := range solutions {
+		if s.Objective < bestOf1000.Objective {
+			bestOf1000 = s
 		}
-		log.Printf("Best of 1000 Objective: %d", bestOf1000.Objective)
+	}
+	log.Printf("Best of 1000 Objective: %v", bestOf1000.Objective)
 
-		// 3. Generate "Best Known" (Very good solution)
-		log.Println("Using provided Best Known solution...")
-		var bestKnown algorithms.Solution
+	// 3. Generate "Best Known" (Very good solution)
+	log.Println("Using provided Best Known solution...")
+	var bestKnown algorithms.Solution[W]
 
-		if inst.Name == "A" {
-			path := []int{127, 123, 162, 133, 151, 51, 118, 59, 65, 116, 43, 42, 184, 35, 84, 112, 4, 190, 10, 177, 54, 48, 160, 34, 181, 146, 22, 18, 108, 69, 159, 193, 41, 139, 115, 46, 68, 140, 93, 117, 0, 143, 183, 89, 186, 23, 137, 176, 80, 79, 63, 94, 124, 148, 9, 62, 102, 144, 14, 49, 178, 106, 52, 55, 185, 40, 119, 165, 90, 81, 196, 179, 57, 129, 92, 145, 78, 31, 56, 113, 175, 171, 16, 25, 44, 120, 2, 152, 97, 1, 101, 75, 86, 26, 100, 53, 180, 154, 135, 70}
-			bestKnown = algorithms.Solution{
-				Path:      path,
-				Objective: calculateObjective(D, costs, path),
-			}
-		} else if inst.Name == "B" {
-			path := []int{29, 0, 109, 35, 143, 106, 124, 62, 18, 55, 34, 170, 152, 183, 140, 4, 149, 28, 20, 60, 148, 47, 94, 66, 179, 22, 99, 130, 95, 185, 86, 166, 194, 176, 113, 114, 137, 127, 89, 103, 163, 187, 153, 81, 77, 141, 91, 61, 36, 177, 5, 45, 142, 78, 175, 80, 190, 136, 73, 54, 31, 193, 117, 198, 156, 1, 16, 27, 38, 135, 63, 40, 107, 133, 122, 131, 121, 51, 90, 147, 6, 188, 169, 132, 70, 3, 15, 145, 13, 195, 168, 139, 11, 138, 33, 160, 144, 104, 8, 111}
-			bestKnown = algorithms.Solution{
-				Path:      path,
-				Objective: calculateObjective(D, costs, path),
-			}
-		} else {
-			log.Println("Generating Best Known solution with Strong LS...")
-			strongMethod := algorithms.MethodSpec{
-				LS:    algorithms.LS_Steepest,
-				Intra: algorithms.Intra2Opt,
-				Start: algorithms.StartGreedy,
-				Name:  "Strong",
-			}
-			// Run a small batch to get a good one
-			strongSolutions := algorithms.RunLocalSearchBatch(D, costs, utils.GenerateStartNodeIndices(len(nodes)), strongMethod, 200)
-			bestKnown = algorithms.FindBestSolution(strongSolutions)
+	if name == "A" {
+		path := []int{127, 123, 162, 133, 151, 51, 118, 59, 65, 116, 43, 42, 184, 35, 84, 112, 4, 190, 10, 177, 54, 48, 160, 34, 181, 146, 22, 18, 108, 69, 159, 193, 41, 139, 115, 46, 68, 140, 93, 117, 0, 143, 183, 89, 186, 23, 137, 176, 80, 79, 63, 94, 124, 148, 9, 62, 102, 144, 14, 49, 178, 106, 52, 55, 185, 40, 119, 165, 90, 81, 196, 179, 57, 129, 92, 145, 78, 31, 56, 113, 175, 171, 16, 25, 44, 120, 2, 152, 97, 1, 101, 75, 86, 26, 100, 53, 180, 154, 135, 70}
+		bestKnown = algorithms.Solution[W]{
+			Path:      path,
+			Objective: calculateObjective(D, costs, path),
 		}
-		log.Printf("Best Known Objective: %d", bestKnown.Objective)
-
-		// 4. Calculate Similarities and Correlations
-		// We need 6 combinations per instance:
-		// Target: Average, BestOf1000, BestKnown
-		// Measure: Edges, Nodes
-
-		var results []ResultRow
-
-		// Pre-calculate edges/nodes sets for all solutions to speed up "Average" calculation?
-		// O(N^2) comparisons might be slow for 1000x1000. 1000x1000 = 1M comparisons.
-		// Each comparison takes O(K). K ~ 100-200. 1M * 100 operations is fine (100M ops ~ 1-2 seconds).
-
-		log.Println("Calculating similarities...")
-
-		// Helper to get edges map
-		getEdges := func(path []int) map[[2]int]bool {
-			edges := make(map[[2]int]bool)
-			n := len(path)
-			for i := 0; i < n; i++ {
-				u, v := path[i], path[(i+1)%n]
-				if u > v {
-					u, v = v, u
-				}
-				edges[[2]int{u, v}] = true
-			}
-			return edges
+	} else if name == "B" {
+		path := []int{29, 0, 109, 35, 143, 106, 124, 62, 18, 55, 34, 170, 152, 183, 140, 4, 149, 28, 20, 60, 148, 47, 94, 66, 179, 22, 99, 130, 95, 185, 86, 166, 194, 176, 113, 114, 137, 127, 89, 103, 163, 187, 153, 81, 77, 141, 91, 61, 36, 177, 5, 45, 142, 78, 175, 80, 190, 136, 73, 54, 31, 193, 117, 198, 156, 1, 16, 27, 38, 135, 63, 40, 107, 133, 122, 131, 121, 51, 90, 147, 6, 188, 169, 132, 70, 3, 15, 145, 13, 195, 168, 139, 11, 138, 33, 160, 144, 104, 8, 111}
+		bestKnown = algorithms.Solution[W]{
+			Path:      path,
+			Objective: calculateObjective(D, costs, path),
 		}
+	} else {
+		log.Println("Generating Best Known solution with Strong LS...")
+		strongMethod := algorithms.MethodSpec{
+			LS:    algorithms.LS_Steepest,
+			Intra: algorithms.Intra2Opt,
+			Start: algorithms.StartGreedy,
+			Name:  "Strong",
+		}
+		// Run a small batch to get a good one
+		strongSolutions := algorithms.RunLocalSearchBatch(D, costs, utils.GenerateStartNodeIndices(len(nodes)), strongMethod, 200)
+		bestKnown = algorithms.FindBestSolution(strongSolutions)
+	}
+	log.Printf("Best Known Objective: %v", bestKnown.Objective)
 
-		// Helper to get nodes map
-		getNodes := func(path []int) map[int]bool {
-			nodes := make(map[int]bool)
-			for _, u := range path {
-				nodes[u] = true
+	// 4. Calculate Similarities and Correlations
+	// We need 6 combinations per instance:
+	// Target: Average, BestOf1000, BestKnown
+	// Measure: Edges, Nodes
+
+	var results []ResultRow[W]
+
+	// Pre-calculate edges/nodes sets for all solutions to speed up "Average" calculation?
+	// O(N^2) comparisons might be slow for 1000x1000. 1000x1000 = 1M comparisons.
+	// Each comparison takes O(K). K ~ 100-200. 1M * 100 operations is fine (100M ops ~ 1-2 seconds).
+
+	log.Println("Calculating similarities...")
+
+	// Helper to get edges map
+	getEdges := func(path []int) map[[2]int]bool {
+		edges := make(map[[2]int]bool)
+		n := len(path)
+		for i := 0; i < n; i++ {
+			u, v := path[i], path[(i+1)%n]
+			if u > v {
+				u, v = v, u
 			}
-			return nodes
+			edges[[2]int{u, v}] = true
 		}
+		return edges
+	}
 
-		// Precompute maps for all solutions
-		allEdges := make([]map[[2]int]bool, len(solutions))
-		allNodes := make([]map[int]bool, len(solutions))
-		for i, s := range solutions {
-			allEdges[i] = getEdges(s.Path)
-			allNodes[i] = getNodes(s.Path)
+	// Helper to get nodes map
+	getNodes := func(path []int) map[int]bool {
+		nodes := make(map[int]bool)
+		for _, u := range path {
+			nodes[u] = true
 		}
+		return nodes
+	}
 
-		best1000Edges := getEdges(bestOf1000.Path)
-		best1000Nodes := getNodes(bestOf1000.Path)
-		bestKnownEdges := getEdges(bestKnown.Path)
-		bestKnownNodes := getNodes(bestKnown.Path)
-
-		for i, s := range solutions {
-			// Skip the bestOf1000 itself when calculating similarity to it?
-			// Assignment: "In the results with similarity to a single good solution do not include this solution itself"
-			// We will filter later or handle it here.
-			// Actually, if s == bestOf1000, we should probably exclude it from that specific chart.
-			// But for the "Average" chart, it's just one of 1000.
-			// Let's collect all data and filter during correlation/plotting.
-
-			// Sim to Best1000
-			sBest1000E := commonEdges(allEdges[i], best1000Edges)
-			sBest1000N := commonNodes(allNodes[i], best1000Nodes)
-
-			// Sim to BestKnown
-			sBestKnownE := commonEdges(allEdges[i], bestKnownEdges)
-			sBestKnownN := commonNodes(allNodes[i], bestKnownNodes)
-
-			// Avg Sim
-			sumEdges := 0
-			sumNodes := 0
-			count := 0
-			for j := range solutions {
-				if i == j {
-					continue
-				}
-				sumEdges += commonEdges(allEdges[i], allEdges[j])
-				sumNodes += commonNodes(allNodes[i], allNodes[j])
-				count++
-			}
-			avgEdges := float64(sumEdges) / float64(count)
-			avgNodes := float64(sumNodes) / float64(count)
-
-			results = append(results, ResultRow{
-				Objective:          s.Objective,
-				SimAvgEdges:        avgEdges,
-				SimAvgNodes:        avgNodes,
-				SimBest1000Edges:   sBest1000E,
-				SimBest1000Nodes:   sBest1000N,
-				SimBestKnownEdges:  sBestKnownE,
-				SimBestKnownNodes:  sBestKnownN,
-				BestKnownObjective: bestKnown.Objective,
-			})
-		}
+	// Precompute maps for all solutions
+	allEdges := make([]map[[2]int]bool, len(solutions))
+	allNodes := make([]map[int]bool, len(solutions))
+	for i, s := range solutions {
+		allEdges[i] = getEdges(s.Path)
+		allNodes[i] = getNodes(s.Path)
+	}
 
-		// Save to CSV
-		csvFile, err := os.Create(fmt.Sprintf("convexity_results_%s.csv", inst.Name))
-		if err != nil {
-			log.Fatal(err)
+	best1000Edges := getEdges(bestOf1000.Path)
+	best1000Nodes := getNodes(bestOf1000.Path)
+	bestKnownEdges := getEdges(bestKnown.Path)
+	bestKnownNodes := getNodes(bestKnown.Path)
+
+	for i, s := range solutions {
+		// Skip the bestOf1000 itself when calculating similarity to it?
+		// Assignment: "In the results with similarity to a single good solution do not include this solution itself"
+		// We will filter later or handle it here.
+		// Actually, if s == bestOf1000, we should probably exclude it from that specific chart.
+		// But for the "Average" chart, it's just one of 1000.
+		// Let's collect all data and filter during correlation/plotting.
+
+		// Sim to Best1000
+		sBest1000E := commonEdges(allEdges[i], best1000Edges)
+		sBest1000N := commonNodes(allNodes[i], best1000Nodes)
+
+		// Sim to BestKnown
+		sBestKnownE := commonEdges(allEdges[i], bestKnownEdges)
+		sBestKnownN := commonNodes(allNodes[i], bestKnownNodes)
+
+		// Avg Sim
+		sumEdges := 0
+		sumNodes := 0
+		count := 0
+		for j := range solutions {
+			if i == j {
+				continue
+			}
+			sumEdges += commonEdges(allEdges[i], allEdges[j])
+			sumNodes += commonNodes(allNodes[i], allNodes[j])
+			count++
 		}
-		writer := csv.NewWriter(csvFile)
-		writer.Write([]string{
-			"Objective",
-			"SimAvgEdges", "SimAvgNodes",
-			"SimBest1000Edges", "SimBest1000Nodes",
-			"SimBestKnownEdges", "SimBestKnownNodes",
-			"IsBestOf1000", // Flag to help filtering
-			"BestKnownObjective",
+		avgEdges := float64(sumEdges) / float64(count)
+		avgNodes := float64(sumNodes) / float64(count)
+
+		results = append(results, ResultRow[W]{
+			Objective:          s.Objective,
+			SimAvgEdges:        avgEdges,
+			SimAvgNodes:        avgNodes,
+			SimBest1000Edges:   sBest1000E,
+			SimBest1000Nodes:   sBest1000N,
+			SimBestKnownEdges:  sBestKnownE,
+			SimBestKnownNodes:  sBestKnownN,
+			BestKnownObjective: bestKnown.Objective,
 		})
+	}
 
-		for i, r := range results {
-			isBest := (solutions[i].Objective == bestOf1000.Objective) // Approximate check
-			// Better check: compare pointers or index if we tracked it, but objective equality is likely unique enough or fine if multiple
-			// Actually, let's just mark the one we used.
-			// But wait, bestOf1000 is a copy.
-			// Let's just use objective.
-
-			writer.Write([]string{
-				fmt.Sprintf("%d", r.Objective),
-				fmt.Sprintf("%.4f", r.SimAvgEdges),
-				fmt.Sprintf("%.4f", r.SimAvgNodes),
-				fmt.Sprintf("%d", r.SimBest1000Edges),
-				fmt.Sprintf("%d", r.SimBest1000Nodes),
-				fmt.Sprintf("%d", r.SimBestKnownEdges),
-				fmt.Sprintf("%d", r.SimBestKnownNodes),
-				fmt.Sprintf("%t", isBest),
-				fmt.Sprintf("%d", r.BestKnownObjective),
-			})
-		}
-		writer.Flush()
-		csvFile.Close()
+	// Save to CSV
+	csvFile, err := os.Create(fmt.Sprintf("convexity_results_%s.csv", name))
+	if err != nil {
+		log.Fatal(err)
+	}
+	writer := csv.NewWriter(csvFile)
+	writer.Write([]string{
+		"Objective",
+		"SimAvgEdges", "SimAvgNodes",
+		"SimBest1000Edges", "SimBest1000Nodes",
+		"SimBestKnownEdges", "SimBestKnownNodes",
+		"IsBestOf1000", // Flag to help filtering
+		"BestKnownObjective",
+	})
+
+	for i, r := range results {
+		isBest := (solutions[i].Objective == bestOf1000.Objective) // Approximate check
+		// Better check: compare pointers or index if we tracked it, but objective equality is likely unique enough or fine if multiple
+		// Actually, let's just mark the one we used.
+		// But wait, bestOf1000 is a copy.
+		// Let's just use objective.
 
-		// Calculate Correlations
-		// 1. Avg Edges
-		printCorrelation(results, "Avg Edges", func(r ResultRow) float64 { return r.SimAvgEdges }, nil)
-		// 2. Avg Nodes
-		printCorrelation(results, "Avg Nodes", func(r ResultRow) float64 { return r.SimAvgNodes }, nil)
+		writer.Write([]string{
+			fmt.Sprint(r.Objective),
+			fmt.Sprintf("%.4f", r.SimAvgEdges),
+			fmt.Sprintf("%.4f", r.SimAvgNodes),
+			fmt.Sprintf("%d", r.SimBest1000Edges),
+			fmt.Sprintf("%d", r.SimBest1000Nodes),
+			fmt.Sprintf("%d", r.SimBestKnownEdges),
+			fmt.Sprintf("%d", r.SimBestKnownNodes),
+			fmt.Sprintf("%t", isBest),
+			fmt.Sprint(r.BestKnownObjective),
+		})
+	}
+	writer.Flush()
+	csvFile.Close()
 
-		// Filter out BestOf1000 for its own correlations
-		filterBest := func(r ResultRow) bool { return r.Objective == bestOf1000.Objective } // Simple filter
+	// Calculate Correlations
+	// 1. Avg Edges
+	printCorrelation(results, "Avg Edges", func(r ResultRow[W]) float64 { return r.SimAvgEdges }, nil)
+	// 2. Avg Nodes
+	printCorrelation(results, "Avg Nodes", func(r ResultRow[W]) float64 { return r.SimAvgNodes }, nil)
 
-		// 3. Best1000 Edges
-		printCorrelation(results, "Best1000 Edges", func(r ResultRow) float64 { return float64(r.SimBest1000Edges) }, filterBest)
-		// 4. Best1000 Nodes
-		printCorrelation(results, "Best1000 Nodes", func(r ResultRow) float64 { return float64(r.SimBest1000Nodes) }, filterBest)
+	// Filter out BestOf1000 for its own correlations
+	filterBest := func(r ResultRow[W]) bool { return r.Objective == bestOf1000.Objective } // Simple filter
 
-		// 5. BestKnown Edges
-		printCorrelation(results, "BestKnown Edges", func(r ResultRow) float64 { return float64(r.SimBestKnownEdges) }, nil) // Don't filter best known (it's likely not in the set, or if it is, it's rare)
-		// 6. BestKnown Nodes
-		printCorrelation(results, "BestKnown Nodes", func(r ResultRow) float64 { return float64(r.SimBestKnownNodes) }, nil)
+	// 3. Best1000 Edges
+	printCorrelation(results, "Best1000 Edges", func(r ResultRow[W]) float64 { return float64(r.SimBest1000Edges) }, filterBest)
+	// 4. Best1000 Nodes
+	printCorrelation(results, "Best1000 Nodes", func(r ResultRow[W]) float64 { return float64(r.SimBest1000Nodes) }, filterBest)
 
-		fmt.Println("------------------------------------------------")
-	}
+	// 5. BestKnown Edges
+	printCorrelation(results, "BestKnown Edges", func(r ResultRow[W]) float64 { return float64(r.SimBestKnownEdges) }, nil) // Don't filter best known (it's likely not in the set, or if it is, it's rare)
+	// 6. BestKnown Nodes
+	printCorrelation(results, "BestKnown Nodes", func(r ResultRow[W]) float64 { return float64(r.SimBestKnownNodes) }, nil)
 
-	// Automatically regenerate plots
-	log.Println("Regenerating plots...")
-	cmd := exec.Command("python3", "plot_convexity.py")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		log.Printf("Error running plot script: %v", err)
-	} else {
-		log.Println("Plots regenerated successfully.")
-	}
+	fmt.Println("------------------------------------------------")
 }
 
 func commonEdges(e1, e2 map[[2]int]bool) int {
@@ -280,18 +300,18 @@ func commonNodes(n1, n2 map[int]bool) int {
 	return count
 }
 
-type ResultRow struct {
-	Objective          int
+type ResultRow[W algorithms.Weight] struct {
+	Objective          W
 	SimAvgEdges        float64
 	SimAvgNodes        float64
 	SimBest1000Edges   int
 	SimBest1000Nodes   int
 	SimBestKnownEdges  int
 	SimBestKnownNodes  int
-	BestKnownObjective int
+	BestKnownObjective W
 }
 
-func printCorrelation(results []ResultRow, name string, valueExtractor func(r ResultRow) float64, excludeFilter func(r ResultRow) bool) {
+func printCorrelation[W algorithms.Weight](results []ResultRow[W], name string, valueExtractor func(r ResultRow[W]) float64, excludeFilter func(r ResultRow[W]) bool) {
 
 	var xs, ys []float64
 	for _, r := range results {
@@ -340,11 +360,11 @@ func correlation(xs, ys []float64) float64 {
 	return num / math.Sqrt(denX*denY)
 }
 
-func calculateObjective(D [][]int, costs []int, path []int) int {
+func calculateObjective[W algorithms.Weight](D [][]W, costs []W, path []int) W {
 	if len(path) == 0 {
 		return math.MaxInt32 / 4
 	}
-	sum := 0
+	var sum W
 	n := len(path)
 	for i := 0; i < n; i++ {
 		a := path[i]
@@ -1,31 +1,30 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
 	"time"
 
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/03_labs/local_search/pkg/algorithms"
-	"github.com/czajkowskis/evolutionary_computation/03_labs/local_search/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/03_labs/local_search/pkg/utils"
 	"github.com/czajkowskis/evolutionary_computation/03_labs/local_search/pkg/visualisation"
 )
 
-func processInstance(instanceName string, nodes []data.Node) {
+func processInstance[W algorithms.Weight](instanceName string, nodes []data.Node, policy data.DistancePolicy) {
 	log.Printf("Processing instance %s with %d nodes", instanceName, len(nodes))
 
 	fmt.Printf("Instance %s Statistics:\n", instanceName)
 
-	D := data.CalculateDistanceMatrix(nodes)
+	D, costs, err := data.Weights[W](nodes, policy)
+	if err != nil {
+		log.Fatalf("Error computing distances: %v", err)
+	}
 	startNodeIndices := utils.GenerateStartNodeIndices(len(nodes))
 	numSolutions := 200
 
-	costs := make([]int, len(nodes))
-	for i, node := range nodes {
-		costs[i] = node.Cost
-	}
-
 	methods := []algorithms.MethodSpec{
 		{LS: algorithms.LS_Steepest, Intra: algorithms.IntraSwap, Start: algorithms.StartRandom, Name: "Steepest_Swap_Random"},
 		{LS: algorithms.LS_Steepest, Intra: algorithms.IntraSwap, Start: algorithms.StartGreedy, Name: "Steepest_Swap_GreedyStart"},
@@ -37,7 +36,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 		{LS: algorithms.LS_Greedy, Intra: algorithms.Intra2Opt, Start: algorithms.StartGreedy, Name: "Greedy_2-opt_GreedyStart"},
 	}
 
-	var rows []utils.Row
+	var rows []utils.Row[W]
 
 	for _, m := range methods {
 		log.Printf("Starting method: %s for instance %s", m.Name, instanceName)
@@ -55,7 +54,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 
 		best := algorithms.FindBestSolution(solutions)
 
-		rows = append(rows, utils.Row{
+		rows = append(rows, utils.Row[W]{
 			Name:      m.Name,
 			AvgV:      avgVal,
 			MinV:      minVal,
@@ -65,7 +64,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 			BestValue: best.Objective,
 		})
 
-		log.Printf("Completed method %s: best value %d, avg time %.2f ms", m.Name, best.Objective, avgTimeMs)
+		log.Printf("Completed method %s: best value %v, avg time %.2f ms", m.Name, best.Objective, avgTimeMs)
 
 		// Plots for the best solutions
 		title := fmt.Sprintf("Best %s Solution for Instance %s", m.Name, instanceName)
@@ -78,7 +77,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 	// Print results to console
 	fmt.Println("Objective value: av (min, max)")
 	for _, r := range rows {
-		fmt.Printf("%-34s  %.2f (%d, %d)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
+		fmt.Printf("%-34s  %.2f (%v, %v)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
 		fmt.Printf("Best path: %v\n", r.BestPath)
 	}
 	fmt.Println()
@@ -97,6 +96,19 @@ func processInstance(instanceName string, nodes []data.Node) {
 }
 
 func main() {
+	rounding := flag.String("rounding", "round", "distance and cost rounding: round, ceil, floor or exact")
+	scale := flag.Float64("scale", 1, "fixed-point factor applied to distances and costs before rounding")
+	flag.Parse()
+
+	policy, err := data.ParseDistancePolicy(*rounding, *scale)
+	if err != nil {
+		log.Fatalf("Invalid distance policy: %v", err)
+	}
+	run := processInstance[int]
+	if policy.Rounding == data.RoundExact {
+		run = processInstance[float64]
+	}
+
 	rand.Seed(time.Now().UnixNano())
 
 	log.Println("Starting evolutionary computation local search program")
@@ -110,9 +122,9 @@ func main() {
 		log.Fatalf("Error reading TSPB.csv: %v", err)
 	}
 
-	processInstance("A", nodesA)
+	run("A", nodesA, policy)
 	fmt.Println()
-	processInstance("B", nodesB)
+	run("B", nodesB, policy)
 
 	log.Println("Program execution completed")
 }
@@ -0,0 +1,63 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randomInstance builds a random Euclidean instance with dim nodes placed on
+// a 1000x1000 grid, with rounded distances and node costs in [0, 500).
+func randomInstance(dim int, rng *rand.Rand) ([][]int, []int) {
+	xs := make([]int, dim)
+	ys := make([]int, dim)
+	costs := make([]int, dim)
+	for i := 0; i < dim; i++ {
+		xs[i], ys[i], costs[i] = rng.Intn(1000), rng.Intn(1000), rng.Intn(500)
+	}
+	D := make([][]int, dim)
+	for i := range D {
+		D[i] = make([]int, dim)
+		for j := range D[i] {
+			if i != j {
+				D[i][j] = int(math.Round(math.Hypot(float64(xs[i]-xs[j]), float64(ys[i]-ys[j]))))
+			}
+		}
+	}
+	return D, costs
+}
+
+// randomTour selects between minLen and len(D) nodes at random and returns
+// them in random order.
+func randomTour(D [][]int, minLen int, rng *rand.Rand) []int {
+	dim := len(D)
+	k := minLen
+	if dim > minLen {
+		k += rng.Intn(dim - minLen + 1)
+	}
+	return rng.Perm(dim)[:k]
+}
+
+// TestSwapDelta checks the delta of the node swap, the only move of this lab
+// not shared with lab 05, against a full recomputation of the objective. Tours
+// of every length from 3 nodes are tried so that swaps of adjacent positions
+// and around the end of the path are covered.
+func TestSwapDelta(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, dim := range []int{3, 4, 5, 7, 10, 25, 100} {
+		t.Run(fmt.Sprintf("random-%d", dim), func(t *testing.T) {
+			D, costs := randomInstance(dim, rng)
+			for trial := 0; trial < 2000; trial++ {
+				path := randomTour(D, 3, rng)
+				i, j := rng.Intn(len(path)), rng.Intn(len(path))
+				delta := deltaSwap(D, path, i, j)
+				moved := append([]int(nil), path...)
+				applySwap(moved, i, j)
+				if got := objective(D, costs, moved) - objective(D, costs, path); got != delta {
+					t.Fatalf("swap(%d, %d) on %v: delta %d, objective changed by %d", i, j, path, delta, got)
+				}
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"math"
 	"math/rand"
 	"time"
+
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
 )
 
 type LSType int
@@ -33,11 +35,11 @@ type MethodSpec struct {
 	Name  string
 }
 
-func objective(D [][]int, costs []int, path []int) int {
+func objective[W Weight](D [][]W, costs []W, path []int) W {
 	if len(path) == 0 {
 		return math.MaxInt32 / 4
 	}
-	sum := 0
+	var sum W
 	n := len(path)
 	for i := 0; i < n; i++ {
 		a := path[i]
@@ -66,7 +68,7 @@ func nextIdx(i, n int) int {
 func selectCount(n int) int { return (n + 1) / 2 }
 
 // random selection of K nodes and random permutation (cycle)
-func startRandom(D [][]int, costs []int, rng *rand.Rand) Solution {
+func startRandom[W Weight](D [][]W, costs []W, rng *rand.Rand) Solution[W] {
 	n := len(D)
 	k := selectCount(n)
 
@@ -78,11 +80,11 @@ func startRandom(D [][]int, costs []int, rng *rand.Rand) Solution {
 	path := append([]int(nil), idx[:k]...)
 	rng.Shuffle(k, func(i, j int) { path[i], path[j] = path[j], path[i] })
 
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
 // make a greedy path (using NN Any Position algortihm) - from a chosen node - as a start for LS
-func startGreedy(distanceMatrix [][]int, nodeCosts []int, startNodeIndex, k int) Solution {
+func startGreedy[W Weight](distanceMatrix [][]W, nodeCosts []W, startNodeIndex, k int) Solution[W] {
 	n := len(nodeCosts)
 
 	path := []int{startNodeIndex}
@@ -94,12 +96,12 @@ func startGreedy(distanceMatrix [][]int, nodeCosts []int, startNodeIndex, k int)
 	}
 
 	for len(path) < k {
-		minIncrease := math.MaxInt32
+		minIncrease := deltas.MaxWeight[W]()
 		bestNodeIndex := -1
 		bestPosition := -1
 
 		for nodeIndex := range unvisited {
-			localMinIncrease := math.MaxInt32
+			localMinIncrease := deltas.MaxWeight[W]()
 			localBestPos := -1
 
 			if len(path) == 1 {
@@ -159,13 +161,13 @@ func startGreedy(distanceMatrix [][]int, nodeCosts []int, startNodeIndex, k int)
 		}
 
 	}
-	return Solution{Path: path, Objective: objective(distanceMatrix, nodeCosts, path)}
+	return Solution[W]{Path: path, Objective: objective(distanceMatrix, nodeCosts, path)}
 }
 
 // DELTAS FOR DIFFERENT MOVES
 
 // intra-route move - two-nodes exchange: change path[i] with path[j]
-func deltaSwap(D [][]int, path []int, i, j int) int {
+func deltaSwap[W Weight](D [][]W, path []int, i, j int) W {
 	if i == j {
 		return 0
 	}
@@ -196,58 +198,16 @@ func deltaSwap(D [][]int, path []int, i, j int) int {
 	return after - before
 }
 
-// intra-route move - two edges exchange: 2-opt between path[i] and path[j]
-func deltaTwoOpt(D [][]int, path []int, i, j int) int {
-	if i == j {
-		return 0
-	}
-	n := len(path)
-	// Adjacent edges -> degenerate 2-opt (no change)
-	if nextIdx(i, n) == j || nextIdx(j, n) == i {
-		return 0
-	}
-	a := path[i]
-	b := path[nextIdx(i, n)]
-	c := path[j]
-	d := path[nextIdx(j, n)]
-	before := D[a][b] + D[c][d]
-	after := D[a][c] + D[b][d]
-	return after - before
-}
-
-// inter-route move - two-nodes exchange - path[i] with u (u outside the current path)
-func deltaExchangeSelected(distanceMatrix [][]int, nodeCosts []int, path []int, i int, u int) int {
-	n := len(path)
-	a := path[prevIdx(i, n)]
-	v := path[i]
-	b := path[nextIdx(i, n)]
-
-	before := distanceMatrix[a][v] + distanceMatrix[v][b] + nodeCosts[v]
-	after := distanceMatrix[a][u] + distanceMatrix[u][b] + nodeCosts[u]
-	return after - before
-}
-
 func applySwap(path []int, i, j int) { path[i], path[j] = path[j], path[i] }
 
-func applyTwoOpt(path []int, i, j int) {
-	if i > j {
-		i, j = j, i
-	}
-	for l, r := i+1, j; l < r; l, r = l+1, r-1 {
-		path[l], path[r] = path[r], path[l]
-	}
-}
-
-func applyExchangeSelected(path []int, i int, u int) { path[i] = u }
-
 // LS TYPES - Steepest / Greedy
 
-func localSearchSteepest(distanceMatrix [][]int, nodeCosts []int, init Solution, intra IntraType) Solution {
+func localSearchSteepest[W Weight](distanceMatrix [][]W, nodeCosts []W, init Solution[W], intra IntraType) Solution[W] {
 	path := append([]int(nil), init.Path...)
 	n := len(path)
 
 	for {
-		bestDelta := 0
+		var bestDelta W
 		bestMove := func() {}
 
 		// INTRA
@@ -266,11 +226,11 @@ func localSearchSteepest(distanceMatrix [][]int, nodeCosts []int, init Solution,
 		case Intra2Opt:
 			for i := 0; i < n; i++ {
 				for j := i + 1; j < n; j++ {
-					dl := deltaTwoOpt(distanceMatrix, path, i, j)
+					dl := deltas.DeltaTwoOpt(distanceMatrix, path, i, j)
 					if dl < bestDelta {
 						ii, jj := i, j
 						bestDelta = dl
-						bestMove = func() { applyTwoOpt(path, ii, jj) }
+						bestMove = func() { deltas.ApplyTwoOpt(path, ii, jj) }
 					}
 				}
 			}
@@ -289,24 +249,24 @@ func localSearchSteepest(distanceMatrix [][]int, nodeCosts []int, init Solution,
 		}
 		for i := 0; i < n; i++ {
 			for _, u := range nonSel {
-				dl := deltaExchangeSelected(distanceMatrix, nodeCosts, path, i, u)
+				dl := deltas.DeltaExchangeSelected(distanceMatrix, nodeCosts, path, i, u)
 				if dl < bestDelta {
 					ii, uu := i, u
 					bestDelta = dl
-					bestMove = func() { applyExchangeSelected(path, ii, uu) }
+					bestMove = func() { deltas.ApplyExchangeSelected(path, ii, uu) }
 				}
 			}
 		}
-		if bestDelta < 0 {
+		if deltas.Improves(bestDelta) {
 			bestMove()
 		} else {
 			break
 		}
 	}
-	return Solution{Path: path, Objective: objective(distanceMatrix, nodeCosts, path)}
+	return Solution[W]{Path: path, Objective: objective(distanceMatrix, nodeCosts, path)}
 }
 
-func localSearchGreedy(distanceMatrix [][]int, nodeCosts []int, init Solution, intra IntraType, rng *rand.Rand) Solution {
+func localSearchGreedy[W Weight](distanceMatrix [][]W, nodeCosts []W, init Solution[W], intra IntraType, rng *rand.Rand) Solution[W] {
 	path := append([]int(nil), init.Path...)
 	n := len(path)
 
@@ -324,7 +284,7 @@ func localSearchGreedy(distanceMatrix [][]int, nodeCosts []int, init Solution, i
 				for _, i := range pi {
 					pj := randPermFrom(rng, i+1, n)
 					for _, j := range pj {
-						if deltaSwap(distanceMatrix, path, i, j) < 0 {
+						if deltas.Improves(deltaSwap(distanceMatrix, path, i, j)) {
 							applySwap(path, i, j)
 							return true
 						}
@@ -335,8 +295,8 @@ func localSearchGreedy(distanceMatrix [][]int, nodeCosts []int, init Solution, i
 				for _, i := range pi {
 					pj := randPermFrom(rng, i+1, n)
 					for _, j := range pj {
-						if deltaTwoOpt(distanceMatrix, path, i, j) < 0 {
-							applyTwoOpt(path, i, j)
+						if deltas.Improves(deltas.DeltaTwoOpt(distanceMatrix, path, i, j)) {
+							deltas.ApplyTwoOpt(path, i, j)
 							return true
 						}
 					}
@@ -360,8 +320,8 @@ func localSearchGreedy(distanceMatrix [][]int, nodeCosts []int, init Solution, i
 			pi := randPerm(rng, n)
 			for _, i := range pi {
 				for _, u := range nonSel {
-					if deltaExchangeSelected(distanceMatrix, nodeCosts, path, i, u) < 0 {
-						applyExchangeSelected(path, i, u)
+					if deltas.Improves(deltas.DeltaExchangeSelected(distanceMatrix, nodeCosts, path, i, u)) {
+						deltas.ApplyExchangeSelected(path, i, u)
 						return true
 					}
 				}
@@ -386,7 +346,7 @@ func localSearchGreedy(distanceMatrix [][]int, nodeCosts []int, init Solution, i
 			break
 		}
 	}
-	return Solution{Path: path, Objective: objective(distanceMatrix, nodeCosts, path)}
+	return Solution[W]{Path: path, Objective: objective(distanceMatrix, nodeCosts, path)}
 }
 
 func randPerm(r *rand.Rand, n int) []int {
@@ -415,13 +375,13 @@ func randPermFrom(r *rand.Rand, start, n int) []int {
 // startNodeIndices:
 //   - for StartGreedy we will use consecutive indices as starting nodes for greedy construction
 //   - for StartRandom we ignore this list (generating numSolutions randomly)
-func RunLocalSearchBatch(
-	distanceMatrix [][]int,
-	nodeCosts []int,
+func RunLocalSearchBatch[W Weight](
+	distanceMatrix [][]W,
+	nodeCosts []W,
 	startNodeIndices []int,
 	m MethodSpec,
 	numSolutions int,
-) []Solution {
+) []Solution[W] {
 	if numSolutions <= 0 {
 		return nil
 	}
@@ -429,13 +389,13 @@ func RunLocalSearchBatch(
 	n := len(distanceMatrix)
 	k := selectCount(n)
 
-	results := make([]Solution, 0, numSolutions)
+	results := make([]Solution[W], 0, numSolutions)
 
 	switch m.Start {
 	case StartRandom:
 		for r := 0; r < numSolutions; r++ {
 			init := startRandom(distanceMatrix, nodeCosts, rng)
-			var sol Solution
+			var sol Solution[W]
 			switch m.LS {
 			case LS_Steepest:
 				sol = localSearchSteepest(distanceMatrix, nodeCosts, init, m.Intra)
@@ -453,7 +413,7 @@ func RunLocalSearchBatch(
 				start = r % n
 			}
 			init := startGreedy(distanceMatrix, nodeCosts, start, k)
-			var sol Solution
+			var sol Solution[W]
 			switch m.LS {
 			case LS_Steepest:
 				sol = localSearchSteepest(distanceMatrix, nodeCosts, init, m.Intra)
@@ -463,14 +423,14 @@ func RunLocalSearchBatch(
 			results = append(results, sol)
 
 			if sol.Objective > init.Objective {
-				log.Fatalf("LS worsened solution: init=%d, after=%d", init.Objective, sol.Objective)
+				log.Fatalf("LS worsened solution: init=%v, after=%v", init.Objective, sol.Objective)
 			}
 
 		}
 	default:
 		for r := 0; r < numSolutions; r++ {
 			init := startRandom(distanceMatrix, nodeCosts, rng)
-			var sol Solution
+			var sol Solution[W]
 			switch m.LS {
 			case LS_Steepest:
 				sol = localSearchSteepest(distanceMatrix, nodeCosts, init, m.Intra)
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
 	"github.com/czajkowskis/evolutionary_computation/09_labs/hybrid_evolutionary_algorithm/pkg/algorithms"
-	"github.com/czajkowskis/evolutionary_computation/09_labs/hybrid_evolutionary_algorithm/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/09_labs/hybrid_evolutionary_algorithm/pkg/utils"
 	"github.com/czajkowskis/evolutionary_computation/09_labs/hybrid_evolutionary_algorithm/pkg/visualisation"
 )
@@ -19,8 +21,52 @@ const (
 	populationSize = 20   // Elite population size as per requirements
 )
 
+// hybridRun is a named configuration of the hybrid algorithm.
+type hybridRun struct {
+	name   string
+	config algorithms.HybridConfig
+}
+
+// baselineConfigs are the configurations run by default: the first two
+// recombination operators with and without local search.
+var baselineConfigs = []hybridRun{
+	{"op_1_with_LS", algorithms.HybridConfig{Operator: 1, UseLocalSearch: true}},
+	{"op_1_without_LS", algorithms.HybridConfig{Operator: 1}},
+	{"op_2_with_LS", algorithms.HybridConfig{Operator: 2, UseLocalSearch: true}},
+	{"op_2_without_LS", algorithms.HybridConfig{Operator: 2}},
+}
+
+// sweepConfigs are the further operators, replacement policies, selections,
+// mutations, restarts and improvement steps, run with -sweeps (about 50
+// minutes more for both instances).
+var sweepConfigs = []hybridRun{
+	{"op_3_with_LS", algorithms.HybridConfig{Operator: 3, UseLocalSearch: true}},
+	{"op_3_without_LS", algorithms.HybridConfig{Operator: 3}},
+	{"op_4_with_LS", algorithms.HybridConfig{Operator: 4, UseLocalSearch: true}},
+	{"op_4_without_LS", algorithms.HybridConfig{Operator: 4}},
+	{"op_2_with_LS_most_similar_worse", algorithms.HybridConfig{Operator: 2, UseLocalSearch: true, Replacement: algorithms.ReplaceMostSimilarWorse}},
+	{"op_2_with_LS_distance_quality", algorithms.HybridConfig{Operator: 2, UseLocalSearch: true, Replacement: algorithms.ReplaceDistanceQuality}},
+	{"op_4_with_LS_most_similar_worse", algorithms.HybridConfig{Operator: 4, UseLocalSearch: true, Replacement: algorithms.ReplaceMostSimilarWorse}},
+	{"op_4_with_LS_distance_quality", algorithms.HybridConfig{Operator: 4, UseLocalSearch: true, Replacement: algorithms.ReplaceDistanceQuality}},
+	{"op_2_without_LS_tournament", algorithms.HybridConfig{Operator: 2, Selection: algorithms.SelectTournament}},
+	{"op_2_without_LS_rank", algorithms.HybridConfig{Operator: 2, Selection: algorithms.SelectRank}},
+	{"op_2_without_LS_fitness_proportional", algorithms.HybridConfig{Operator: 2, Selection: algorithms.SelectFitnessProportional}},
+	{"op_2_without_LS_random_exchange", algorithms.HybridConfig{Operator: 2, Mutation: algorithms.MutationRandomExchange}},
+	{"op_2_without_LS_double_bridge", algorithms.HybridConfig{Operator: 2, Mutation: algorithms.MutationDoubleBridge}},
+	{"op_2_without_LS_segment_scramble", algorithms.HybridConfig{Operator: 2, Mutation: algorithms.MutationSegmentScramble}},
+	{"op_2_with_LS_tournament_double_bridge", algorithms.HybridConfig{Operator: 2, UseLocalSearch: true, Selection: algorithms.SelectTournament, Mutation: algorithms.MutationDoubleBridge}},
+	{"op_2_with_LS_restart", algorithms.HybridConfig{Operator: 2, UseLocalSearch: true, StagnationLimit: 500}},
+	{"op_2_with_LS_restart_resize", algorithms.HybridConfig{Operator: 2, UseLocalSearch: true, StagnationLimit: 500, MaxPopulationSize: 40}},
+	{"op_2_with_LS_generational", algorithms.HybridConfig{Operator: 2, UseLocalSearch: true, Model: algorithms.Generational}},
+	{"op_4_with_LS_generational_restart", algorithms.HybridConfig{Operator: 4, UseLocalSearch: true, Model: algorithms.Generational, StagnationLimit: 500}},
+	{"op_2_with_candidate_LS", algorithms.HybridConfig{Operator: 2, UseLocalSearch: true, Improvement: algorithms.ImproveCandidates}},
+	{"op_2_with_LNS", algorithms.HybridConfig{Operator: 2, UseLocalSearch: true, Improvement: algorithms.ImproveLNS}},
+	{"op_2_with_ILS", algorithms.HybridConfig{Operator: 2, UseLocalSearch: true, Improvement: algorithms.ImproveILS}},
+	{"op_4_with_LNS", algorithms.HybridConfig{Operator: 4, UseLocalSearch: true, Improvement: algorithms.ImproveLNS}},
+}
+
 // processInstance runs the full experimental pipeline for a single instance
-func processInstance(instanceName string, nodes []data.Node) {
+func processInstance[W algorithms.Weight](instanceName string, nodes []data.Node, policy data.DistancePolicy, configs []hybridRun) {
 	log.Printf("Processing instance %s with %d nodes", instanceName, len(nodes))
 	fmt.Printf("\n========================================\n")
 	fmt.Printf("Instance %s Statistics:\n", instanceName)
@@ -34,51 +80,49 @@ func processInstance(instanceName string, nodes []data.Node) {
 	}
 	timeLimit := time.Duration(timeLimitMs) * time.Millisecond
 
-	D := data.CalculateDistanceMatrix(nodes)
-	costs := make([]int, len(nodes))
-	for i, node := range nodes {
-		costs[i] = node.Cost
+	D, costs, err := data.Weights[W](nodes, policy)
+	if err != nil {
+		log.Fatalf("Error computing distances: %v", err)
 	}
 
-	var rows []utils.Row
-
-	// Define hybrid algorithm configurations to test
-	configs := []struct {
-		name           string
-		operator       int
-		useLocalSearch bool
-	}{
-		{"op_1_with_LS", 1, true},
-		{"op_1_without_LS", 1, false},
-		{"op_2_with_LS", 2, true},
-		{"op_2_without_LS", 2, false},
-	}
+	lb := deltas.LagrangianLowerBound(D, costs, deltas.LowerBoundConfig{Seed: time.Now().UnixNano()})
+	log.Printf("Lagrangian lower bound for instance %s: %v (%d iterations, %v)", instanceName, lb.Bound, lb.Iterations, lb.Duration)
+
+	var rows []utils.Row[W]
 
 	// Run experiments for each configuration
 	for _, cfg := range configs {
 		log.Printf("Starting %s for instance %s", cfg.name, instanceName)
 		start := time.Now()
 
-		var solutions []algorithms.Solution
+		var solutions []algorithms.Solution[W]
 		totalIterations := 0
+		totalDiversity, totalParentRank := 0.0, 0.0
+		totalMutations, totalAcceptedMutations, totalRestarts := 0, 0, 0
+		var bestRun algorithms.HybridResult[W]
 
 		for run := 0; run < numRuns; run++ {
 			seed := time.Now().UnixNano() + int64(run)
 
-			hybridConfig := algorithms.HybridConfig{
-				PopulationSize: populationSize,
-				TimeLimit:      timeLimit,
-				UseLocalSearch: cfg.useLocalSearch,
-				Operator:       cfg.operator,
-				Seed:           seed,
-			}
+			hybridConfig := cfg.config
+			hybridConfig.PopulationSize = populationSize
+			hybridConfig.TimeLimit = timeLimit
+			hybridConfig.Seed = seed
 
 			result := algorithms.HybridEvolutionary(D, costs, hybridConfig)
 			solutions = append(solutions, result.Solution)
 			totalIterations += result.Iterations
+			totalDiversity += result.Telemetry[len(result.Telemetry)-1].Diversity
+			totalParentRank += result.MeanParentRank
+			totalMutations += result.Mutations
+			totalAcceptedMutations += result.AcceptedMutations
+			totalRestarts += result.Restarts
+			if run == 0 || result.Solution.Objective < bestRun.Solution.Objective {
+				bestRun = result
+			}
 
 			if run%5 == 0 {
-				log.Printf("  Run %d/%d completed: objective = %d, iterations = %d",
+				log.Printf("  Run %d/%d completed: objective = %v, iterations = %d",
 					run+1, numRuns, result.Solution.Objective, result.Iterations)
 			}
 		}
@@ -91,7 +135,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 		avgIterations := float64(totalIterations) / float64(numRuns)
 		bestSolution := algorithms.FindBestSolution(solutions)
 
-		rows = append(rows, utils.Row{
+		rows = append(rows, utils.Row[W]{
 			Name:        cfg.name,
 			AvgV:        avgV,
 			MinV:        minV,
@@ -100,10 +144,30 @@ func processInstance(instanceName string, nodes []data.Node) {
 			AvgLNSIters: avgIterations,
 			BestPath:    bestSolution.Path,
 			BestValue:   bestSolution.Objective,
+			LowerBound:  lb.Bound,
 		})
 
-		log.Printf("Completed %s: best=%d, avg=%.2f, min=%d, max=%d, avg_time=%.2f ms, avg_iterations=%.1f",
-			cfg.name, bestSolution.Objective, avgV, minV, maxV, avgTimeMs, avgIterations)
+		log.Printf("Completed %s: best=%v, avg=%.2f, min=%v, max=%v, avg_time=%.2f ms, avg_iterations=%.1f, final_diversity=%.3f",
+			cfg.name, bestSolution.Objective, avgV, minV, maxV, avgTimeMs, avgIterations, totalDiversity/float64(numRuns))
+		if cfg.config.Selection != algorithms.SelectUniform || cfg.config.Mutation != algorithms.MutationNone {
+			log.Printf("  %s: selection=%s, mean_parent_rank=%.3f, mutation=%s, avg_mutations=%.1f, avg_accepted_mutations=%.1f",
+				cfg.name, cfg.config.Selection, totalParentRank/float64(numRuns), cfg.config.Mutation,
+				float64(totalMutations)/float64(numRuns), float64(totalAcceptedMutations)/float64(numRuns))
+		}
+
+		if cfg.config.StagnationLimit > 0 || cfg.config.Model != algorithms.SteadyState {
+			log.Printf("  %s: model=%s, avg_restarts=%.1f", cfg.name, cfg.config.Model, float64(totalRestarts)/float64(numRuns))
+		}
+
+		// Save the time series of the best run
+		telemetryName := utils.SanitizeFileName(fmt.Sprintf("hybrid_telemetry_%s_%s.csv", instanceName, cfg.name))
+		if err := utils.WriteTelemetryCSV(telemetryName, bestRun.Telemetry); err != nil {
+			log.Printf("Telemetry CSV write error for %s/%s: %v", instanceName, cfg.name, err)
+		}
+		improvementsName := utils.SanitizeFileName(fmt.Sprintf("hybrid_improvements_%s_%s.csv", instanceName, cfg.name))
+		if err := utils.WriteImprovementsCSV(improvementsName, bestRun.Improvements); err != nil {
+			log.Printf("Improvements CSV write error for %s/%s: %v", instanceName, cfg.name, err)
+		}
 	}
 
 	// Print detailed console output
@@ -111,7 +175,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 	fmt.Println("Configuration                           Avg (Min, Max)")
 	fmt.Println("----------------------------------------------------------------")
 	for _, r := range rows {
-		fmt.Printf("%-38s  %.2f (%d, %d)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
+		fmt.Printf("%-38s  %.2f (%v, %v)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
 	}
 
 	fmt.Println("\n--- Average Time per Run ---")
@@ -132,7 +196,14 @@ func processInstance(instanceName string, nodes []data.Node) {
 	fmt.Println("Configuration                           Best Value")
 	fmt.Println("----------------------------------------------------------------")
 	for _, r := range rows {
-		fmt.Printf("%-38s  %d\n", r.Name, r.BestValue)
+		fmt.Printf("%-38s  %v\n", r.Name, r.BestValue)
+	}
+
+	fmt.Printf("\n--- Gap to the Lower Bound %v ---\n", lb.Bound)
+	fmt.Println("Configuration                           Best [%], Avg [%]")
+	fmt.Println("----------------------------------------------------------------")
+	for _, r := range rows {
+		fmt.Printf("%-38s  %.2f, %.2f\n", r.Name, utils.GapPercent(float64(r.BestValue), lb.Bound), utils.GapPercent(r.AvgV, lb.Bound))
 	}
 	fmt.Println()
 
@@ -146,7 +217,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 
 	// Plot best solutions for each method
 	for i, r := range rows {
-		title := fmt.Sprintf("%s - Instance %s (Value: %d)", r.Name, instanceName, r.BestValue)
+		title := fmt.Sprintf("%s - Instance %s (Value: %v)", r.Name, instanceName, r.BestValue)
 		fileName := utils.SanitizeFileName(fmt.Sprintf("hybrid_%s_instance_%s_%d",
 			r.Name, instanceName, i))
 
@@ -159,6 +230,24 @@ func processInstance(instanceName string, nodes []data.Node) {
 }
 
 func main() {
+	sweeps := flag.Bool("sweeps", false, "also run the operator, replacement, selection, mutation, restart and improvement sweeps")
+	rounding := flag.String("rounding", "round", "distance and cost rounding: round, ceil, floor or exact")
+	scale := flag.Float64("scale", 1, "fixed-point factor applied to distances and costs before rounding")
+	flag.Parse()
+
+	policy, err := data.ParseDistancePolicy(*rounding, *scale)
+	if err != nil {
+		log.Fatalf("Invalid distance policy: %v", err)
+	}
+	run := processInstance[int]
+	if policy.Rounding == data.RoundExact {
+		run = processInstance[float64]
+	}
+	configs := baselineConfigs
+	if *sweeps {
+		configs = append(append([]hybridRun(nil), baselineConfigs...), sweepConfigs...)
+	}
+
 	log.Println("========================================")
 	log.Println("Starting Hybrid Evolutionary Algorithm Experiments")
 	log.Println("========================================")
@@ -176,8 +265,8 @@ func main() {
 	log.Printf("Loaded %d nodes from instance A", len(nodesA))
 	log.Printf("Loaded %d nodes from instance B", len(nodesB))
 
-	processInstance("A", nodesA)
-	processInstance("B", nodesB)
+	run("A", nodesA, policy, configs)
+	run("B", nodesB, policy, configs)
 
 	log.Println("\n========================================")
 	log.Println("All experiments completed successfully")
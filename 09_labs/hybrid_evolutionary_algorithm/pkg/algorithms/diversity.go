@@ -0,0 +1,187 @@
+package algorithms
+
+import (
+	"hash/fnv"
+	"sort"
+)
+
+// ReplacementPolicy selects the individual replaced by an accepted offspring
+// in the steady state hybrid evolutionary algorithm.
+type ReplacementPolicy int
+
+const (
+	// ReplaceWorst replaces the worst individual if the offspring is better.
+	ReplaceWorst ReplacementPolicy = iota
+	// ReplaceMostSimilarWorse replaces, among the individuals worse than the
+	// offspring, the one closest to it.
+	ReplaceMostSimilarWorse
+	// ReplaceDistanceQuality adds the offspring to the population and removes
+	// the individual with the worst biased fitness, which combines the rank
+	// of its objective with the rank of its contribution to diversity.
+	ReplaceDistanceQuality
+)
+
+func (p ReplacementPolicy) String() string {
+	switch p {
+	case ReplaceMostSimilarWorse:
+		return "most_similar_worse"
+	case ReplaceDistanceQuality:
+		return "distance_quality"
+	default:
+		return "worst"
+	}
+}
+
+// canonicalTour returns the path rotated to start at its smallest node and
+// oriented towards the smaller of its two neighbours, so that all rotations
+// and reflections of a cycle have the same canonical form.
+func canonicalTour(path []int) []int {
+	n := len(path)
+	if n == 0 {
+		return nil
+	}
+	start := 0
+	for i, v := range path {
+		if v < path[start] {
+			start = i
+		}
+	}
+	step := 1
+	if path[prevIdx(start, n)] < path[nextIdx(start, n)] {
+		step = n - 1
+	}
+	canon := make([]int, n)
+	for i := range canon {
+		canon[i] = path[(start+i*step)%n]
+	}
+	return canon
+}
+
+// tourHash returns a hash of the cycle that is invariant to rotation and
+// reflection of the path.
+func tourHash(path []int) uint64 {
+	h := fnv.New64a()
+	var buf [4]byte
+	for _, v := range canonicalTour(path) {
+		buf[0], buf[1], buf[2], buf[3] = byte(v), byte(v>>8), byte(v>>16), byte(v>>24)
+		h.Write(buf[:])
+	}
+	return h.Sum64()
+}
+
+// sameTour reports whether two paths describe the same cycle.
+func sameTour(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	ca, cb := canonicalTour(a), canonicalTour(b)
+	for i := range ca {
+		if ca[i] != cb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// solutionDistance returns the number of nodes of a missing from b plus the
+// number of edges of a missing from b. For solutions of equal size it is
+// symmetric and zero only for the same cycle.
+func solutionDistance(dim int, a, b []int) int {
+	inB := make([]bool, dim)
+	next := make([]int, dim)
+	prev := make([]int, dim)
+	for i, v := range b {
+		inB[v] = true
+		next[v] = b[nextIdx(i, len(b))]
+		prev[v] = b[prevIdx(i, len(b))]
+	}
+	dist := 0
+	for i, v := range a {
+		if !inB[v] {
+			dist++
+		}
+		if w := a[nextIdx(i, len(a))]; !inB[v] || (next[v] != w && prev[v] != w) {
+			dist++
+		}
+	}
+	return dist
+}
+
+// populationDiversity returns the mean distance between all pairs of
+// individuals divided by the largest possible distance, 2 * len(Path).
+func populationDiversity[W Weight](dim int, population []Solution[W]) float64 {
+	if len(population) < 2 || len(population[0].Path) == 0 {
+		return 0
+	}
+	total, pairs := 0, 0
+	for i := range population {
+		for j := i + 1; j < len(population); j++ {
+			total += solutionDistance(dim, population[i].Path, population[j].Path)
+			pairs++
+		}
+	}
+	return float64(total) / float64(pairs) / float64(2*len(population[0].Path))
+}
+
+// replaceMostSimilarWorse returns the index of the individual worse than
+// offspring that is closest to it, or -1 if no individual is worse.
+func replaceMostSimilarWorse[W Weight](dim int, offspring Solution[W], population []Solution[W]) int {
+	bestIdx, bestDist := -1, 0
+	for i, sol := range population {
+		if sol.Objective <= offspring.Objective {
+			continue
+		}
+		if d := solutionDistance(dim, offspring.Path, sol.Path); bestIdx < 0 || d < bestDist {
+			bestIdx, bestDist = i, d
+		}
+	}
+	return bestIdx
+}
+
+// biasedFitness returns the biased fitness of every individual (lower is
+// better): rank(objective) + (1 - eliteCount/size) * rank(diversity), where
+// diversity is the mean distance to the closest individuals and larger
+// distances rank better. Ranks are normalised to [0, 1].
+func biasedFitness[W Weight](dim int, population []Solution[W], eliteCount, closest int) []float64 {
+	size := len(population)
+	fitness := make([]float64, size)
+	if size < 2 {
+		return fitness
+	}
+
+	contribution := make([]float64, size)
+	dists := make([]int, 0, size-1)
+	for i := range population {
+		dists = dists[:0]
+		for j := range population {
+			if i != j {
+				dists = append(dists, solutionDistance(dim, population[i].Path, population[j].Path))
+			}
+		}
+		sort.Ints(dists)
+		k := min(closest, len(dists))
+		for _, d := range dists[:k] {
+			contribution[i] += float64(d)
+		}
+		contribution[i] /= float64(k)
+	}
+
+	byObjective := make([]int, size)
+	byContribution := make([]int, size)
+	for i := range population {
+		byObjective[i], byContribution[i] = i, i
+	}
+	sort.SliceStable(byObjective, func(a, b int) bool {
+		return population[byObjective[a]].Objective < population[byObjective[b]].Objective
+	})
+	sort.SliceStable(byContribution, func(a, b int) bool {
+		return contribution[byContribution[a]] > contribution[byContribution[b]]
+	})
+
+	weight := 1 - float64(min(eliteCount, size))/float64(size)
+	for r := 0; r < size; r++ {
+		fitness[byObjective[r]] += float64(r) / float64(size-1)
+		fitness[byContribution[r]] += weight * float64(r) / float64(size-1)
+	}
+	return fitness
+}
@@ -0,0 +1,285 @@
+package algorithms
+
+import (
+	"math/rand"
+
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+)
+
+// eaxMaxChildren is the number of AB-cycles tried by recombineEAX; the best
+// resulting child is returned.
+const eaxMaxChildren = 10
+
+// eaxGraph stores a 2-regular graph over the common nodes of both parents as
+// two neighbour slots per node; -1 marks an empty slot.
+type eaxGraph [][2]int
+
+func newEAXGraph(dim int) eaxGraph {
+	g := make(eaxGraph, dim)
+	for v := range g {
+		g[v] = [2]int{-1, -1}
+	}
+	return g
+}
+
+func (g eaxGraph) addEdge(u, v int) {
+	for _, e := range [2][2]int{{u, v}, {v, u}} {
+		if g[e[0]][0] < 0 {
+			g[e[0]][0] = e[1]
+		} else {
+			g[e[0]][1] = e[1]
+		}
+	}
+}
+
+func (g eaxGraph) removeEdge(u, v int) {
+	for _, e := range [2][2]int{{u, v}, {v, u}} {
+		if g[e[0]][0] == e[1] {
+			g[e[0]][0] = -1
+		} else if g[e[0]][1] == e[1] {
+			g[e[0]][1] = -1
+		}
+	}
+}
+
+func (g eaxGraph) hasEdge(u, v int) bool {
+	return g[u][0] == v || g[u][1] == v
+}
+
+// restrictedCycle returns the edges of path with the nodes outside keep
+// shortcut, i.e. the cycle visiting the kept nodes in the order of path.
+func restrictedCycle(dim int, path []int, keep []bool) (eaxGraph, []int) {
+	order := make([]int, 0, len(path))
+	for _, v := range path {
+		if keep[v] {
+			order = append(order, v)
+		}
+	}
+	g := newEAXGraph(dim)
+	if len(order) >= 3 {
+		for i, v := range order {
+			g.addEdge(v, order[nextIdx(i, len(order))])
+		}
+	}
+	return g, order
+}
+
+// abCycles decomposes the edges of a and b that are not shared by both into
+// AB-cycles, cycles alternating between an edge of a and an edge of b. Every
+// cycle is returned as its node sequence v0, v1, ..., vk-1, where the edge
+// (v_i, v_i+1) belongs to a for even i and to b for odd i.
+func abCycles(nodes []int, a, b eaxGraph, rng *rand.Rand) [][]int {
+	dim := len(a)
+	// remaining edges per node, without the edges common to both parents
+	remA, remB := newEAXGraph(dim), newEAXGraph(dim)
+	for _, v := range nodes {
+		for _, w := range a[v] {
+			if w > v && !b.hasEdge(v, w) {
+				remA.addEdge(v, w)
+			}
+		}
+		for _, w := range b[v] {
+			if w > v && !a.hasEdge(v, w) {
+				remB.addEdge(v, w)
+			}
+		}
+	}
+
+	// pick removes and returns a random remaining edge at v, or -1
+	pick := func(g eaxGraph, v int) int {
+		slots := g[v]
+		k := rng.Intn(2)
+		w := slots[k]
+		if w < 0 {
+			w = slots[1-k]
+		}
+		if w >= 0 {
+			g.removeEdge(v, w)
+		}
+		return w
+	}
+
+	var cycles [][]int
+	order := append([]int(nil), nodes...)
+	rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+	for _, start := range order {
+		if remA[start][0] < 0 && remA[start][1] < 0 {
+			continue
+		}
+		walk := []int{start}
+		for len(walk) > 0 {
+			v := walk[len(walk)-1]
+			g := remA
+			if (len(walk)-1)%2 == 1 {
+				g = remB
+			}
+			w := pick(g, v)
+			if w < 0 {
+				break // cannot happen while edges are balanced
+			}
+			walk = append(walk, w)
+			// close the latest alternating cycle ending at w
+			k := len(walk) - 1
+			for j := k - 2; j >= 0; j -= 2 {
+				if walk[j] == w {
+					cycle := append([]int(nil), walk[j:k]...)
+					if j%2 == 1 {
+						// rotate so that the cycle starts with an edge of a
+						cycle = append(cycle[1:], cycle[0])
+					}
+					cycles = append(cycles, cycle)
+					walk = walk[:j+1]
+					break
+				}
+			}
+			if len(walk) == 1 && remA[walk[0]][0] < 0 && remA[walk[0]][1] < 0 {
+				break
+			}
+		}
+	}
+	return cycles
+}
+
+// subtours returns the cycles of the 2-regular graph g over nodes.
+func subtours(nodes []int, g eaxGraph) [][]int {
+	visited := make(map[int]bool, len(nodes))
+	var tours [][]int
+	for _, start := range nodes {
+		if visited[start] {
+			continue
+		}
+		tour := []int{start}
+		visited[start] = true
+		prev, v := start, g[start][0]
+		for v != start && v >= 0 {
+			tour = append(tour, v)
+			visited[v] = true
+			next := g[v][0]
+			if next == prev {
+				next = g[v][1]
+			}
+			prev, v = v, next
+		}
+		tours = append(tours, tour)
+	}
+	return tours
+}
+
+// mergeSubtours joins the subtours into a single cycle: the smallest subtour
+// is repeatedly merged with another one by exchanging one edge of each for
+// the cheapest pair of connecting edges.
+func mergeSubtours[W Weight](D [][]W, tours [][]int) []int {
+	for len(tours) > 1 {
+		s := 0
+		for i := range tours {
+			if len(tours[i]) < len(tours[s]) {
+				s = i
+			}
+		}
+		S := tours[s]
+
+		bestDelta := deltas.MaxWeight[W]()
+		bestT, bestI, bestJ, bestCrossed := -1, -1, -1, false
+		for t, T := range tours {
+			if t == s {
+				continue
+			}
+			for i, u := range S {
+				u2 := S[nextIdx(i, len(S))]
+				for j, v := range T {
+					v2 := T[nextIdx(j, len(T))]
+					removed := D[u][u2] + D[v][v2]
+					if dl := D[u][v] + D[u2][v2] - removed; dl < bestDelta {
+						bestDelta, bestT, bestI, bestJ, bestCrossed = dl, t, i, j, false
+					}
+					if dl := D[u][v2] + D[u2][v] - removed; dl < bestDelta {
+						bestDelta, bestT, bestI, bestJ, bestCrossed = dl, t, i, j, true
+					}
+				}
+			}
+		}
+
+		// S from S[i+1] around to S[i], then T entered at T[j] (edge (u, v))
+		// and walked backward to T[j+1], or entered at T[j+1] (edge (u, v2))
+		// and walked forward to T[j]
+		T := tours[bestT]
+		merged := make([]int, 0, len(S)+len(T))
+		for k := 1; k <= len(S); k++ {
+			merged = append(merged, S[(bestI+k)%len(S)])
+		}
+		for k := 0; k < len(T); k++ {
+			if bestCrossed {
+				merged = append(merged, T[(bestJ+1+k)%len(T)])
+			} else {
+				merged = append(merged, T[(bestJ-k+len(T))%len(T)])
+			}
+		}
+
+		rest := make([][]int, 0, len(tours)-1)
+		for t := range tours {
+			if t != s && t != bestT {
+				rest = append(rest, tours[t])
+			}
+		}
+		tours = append(rest, merged)
+	}
+	if len(tours) == 0 {
+		return nil
+	}
+	return tours[0]
+}
+
+// recombineEAX implements Edge Assembly Crossover adapted to partial node
+// selection. Both parents are restricted to their common nodes (the nodes
+// missing from the other parent are shortcut) and the edges of the
+// restricted tours are decomposed into AB-cycles. For each of up to
+// eaxMaxChildren AB-cycles, its edges of parent1 in the restricted tour of
+// parent1 are replaced by its edges of parent2, the resulting subtours are
+// merged and the node set is completed with the greedy repair heuristic; the
+// best child is returned.
+func recombineEAX[W Weight](parent1, parent2 Solution[W], D [][]W, costs []W, targetSize int, rng *rand.Rand) Solution[W] {
+	dim := len(D)
+	keep := make([]bool, dim)
+	inP1 := make([]bool, dim)
+	for _, v := range parent1.Path {
+		inP1[v] = true
+	}
+	for _, v := range parent2.Path {
+		keep[v] = inP1[v]
+	}
+
+	a, nodes := restrictedCycle(dim, parent1.Path, keep)
+	b, _ := restrictedCycle(dim, parent2.Path, keep)
+	if len(nodes) < 4 {
+		return repair(nodes, D, costs, targetSize, rng)
+	}
+
+	cycles := abCycles(nodes, a, b, rng)
+	if len(cycles) == 0 {
+		// identical restricted tours
+		return repair(nodes, D, costs, targetSize, rng)
+	}
+	rng.Shuffle(len(cycles), func(i, j int) { cycles[i], cycles[j] = cycles[j], cycles[i] })
+
+	var best Solution[W]
+	for c := 0; c < len(cycles) && c < eaxMaxChildren; c++ {
+		cycle := cycles[c]
+		child := newEAXGraph(dim)
+		for _, v := range nodes {
+			child[v] = a[v]
+		}
+		for i := 0; i < len(cycle); i += 2 {
+			child.removeEdge(cycle[i], cycle[nextIdx(i, len(cycle))])
+		}
+		for i := 1; i < len(cycle); i += 2 {
+			child.addEdge(cycle[i], cycle[nextIdx(i, len(cycle))])
+		}
+
+		path := mergeSubtours(D, subtours(nodes, child))
+		sol := repair(path, D, costs, targetSize, rng)
+		if best.Path == nil || sol.Objective < best.Objective {
+			best = sol
+		}
+	}
+	return best
+}
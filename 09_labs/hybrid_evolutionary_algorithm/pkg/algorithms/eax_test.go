@@ -0,0 +1,106 @@
+package algorithms
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestRecombineEAX checks that EAX offspring of random and locally optimal
+// parents are valid solutions of selectCount nodes with a correct objective.
+func TestRecombineEAX(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	D, costs := randomInstance(60, rng)
+	dim, k := len(D), (len(D)+1)/2
+	ws := newLSWorkspace[int]()
+	for trial := 0; trial < 30; trial++ {
+		p1 := randomConstruction(D, costs, dim, k, rng)
+		p2 := randomConstruction(D, costs, dim, k, rng)
+		if trial%2 == 1 {
+			p1, p2 = ws.localSearchSteepest(D, costs, p1), ws.localSearchSteepest(D, costs, p2)
+		}
+		checkSolution(t, D, costs, recombineEAX(p1, p2, D, costs, k, rng), k)
+		// identical parents have no AB-cycle
+		checkSolution(t, D, costs, recombineEAX(p1, p1, D, costs, k, rng), k)
+	}
+}
+
+// TestABCycles checks that the AB-cycles of two tours over the same nodes
+// alternate between edges of both tours and together cover every edge that
+// is not shared by them exactly once.
+func TestABCycles(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const dim = 30
+	keep := make([]bool, dim)
+	for v := range keep {
+		keep[v] = true
+	}
+	for trial := 0; trial < 50; trial++ {
+		a, nodes := restrictedCycle(dim, rng.Perm(dim), keep)
+		b, _ := restrictedCycle(dim, rng.Perm(dim), keep)
+
+		type edge struct{ u, v int }
+		key := func(u, v int) edge { return edge{min(u, v), max(u, v)} }
+		want := make(map[edge]int)
+		for _, v := range nodes {
+			for _, w := range a[v] {
+				if w > v && !b.hasEdge(v, w) {
+					want[key(v, w)]++
+				}
+			}
+			for _, w := range b[v] {
+				if w > v && !a.hasEdge(v, w) {
+					want[key(v, w)]++
+				}
+			}
+		}
+
+		got := make(map[edge]int)
+		for _, cycle := range abCycles(nodes, a, b, rng) {
+			if len(cycle)%2 != 0 {
+				t.Fatalf("AB-cycle %v of odd length", cycle)
+			}
+			for i, u := range cycle {
+				v := cycle[nextIdx(i, len(cycle))]
+				if g := [2]eaxGraph{a, b}[i%2]; !g.hasEdge(u, v) {
+					t.Fatalf("edge %d of AB-cycle %v is not an edge of parent %d", i, cycle, i%2+1)
+				}
+				got[key(u, v)]++
+			}
+		}
+		if len(got) != len(want) {
+			t.Fatalf("AB-cycles cover %d edges, want %d", len(got), len(want))
+		}
+		for e, n := range want {
+			if got[e] != n {
+				t.Fatalf("edge %v covered %d times, want %d", e, got[e], n)
+			}
+		}
+	}
+}
+
+// TestMergeSubtours checks that subtours are merged into one cycle over all
+// of their nodes.
+func TestMergeSubtours(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	D, _ := randomInstance(40, rng)
+	for trial := 0; trial < 20; trial++ {
+		perm := rng.Perm(len(D))
+		var tours [][]int
+		for len(perm) > 0 {
+			size := min(3+rng.Intn(6), len(perm))
+			tours = append(tours, perm[:size])
+			perm = perm[size:]
+		}
+		merged := mergeSubtours(D, tours)
+		if len(merged) != len(D) {
+			t.Fatalf("merged tour of %d nodes, want %d", len(merged), len(D))
+		}
+		seen := make([]bool, len(D))
+		for _, v := range merged {
+			if seen[v] {
+				t.Fatalf("node %d visited twice in %v", v, merged)
+			}
+			seen[v] = true
+		}
+	}
+}
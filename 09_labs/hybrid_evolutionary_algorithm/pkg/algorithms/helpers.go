@@ -3,14 +3,16 @@ package algorithms
 import (
 	"math"
 	"math/rand"
+
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
 )
 
 // Calculate objective function value
-func objective(D [][]int, costs []int, path []int) int {
+func objective[W Weight](D [][]W, costs []W, path []int) W {
 	if len(path) == 0 {
 		return math.MaxInt32 / 4
 	}
-	sum := 0
+	var sum W
 	n := len(path)
 	for i := 0; i < n; i++ {
 		a := path[i]
@@ -24,7 +26,7 @@ func objective(D [][]int, costs []int, path []int) int {
 }
 
 // randomConstruction builds a random solution by selecting random nodes
-func randomConstruction(D [][]int, costs []int, n, targetSize int, rng *rand.Rand) Solution {
+func randomConstruction[W Weight](D [][]W, costs []W, n, targetSize int, rng *rand.Rand) Solution[W] {
 	// Create list of all nodes
 	allNodes := make([]int, n)
 	for i := 0; i < n; i++ {
@@ -39,11 +41,11 @@ func randomConstruction(D [][]int, costs []int, n, targetSize int, rng *rand.Ran
 	path := make([]int, targetSize)
 	copy(path, allNodes[:targetSize])
 
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
 // repair rebuilds the solution using nearest neighbor any position heuristic
-func repair(partialPath []int, D [][]int, costs []int, targetSize int, rng *rand.Rand) Solution {
+func repair[W Weight](partialPath []int, D [][]W, costs []W, targetSize int, rng *rand.Rand) Solution[W] {
 	n := len(costs)
 
 	// Create set of nodes already in solution
@@ -65,12 +67,12 @@ func repair(partialPath []int, D [][]int, costs []int, targetSize int, rng *rand
 
 	// Add nodes using greedy nearest neighbor any position
 	for len(path) < targetSize && len(unvisited) > 0 {
-		minIncrease := math.MaxInt32
+		minIncrease := deltas.MaxWeight[W]()
 		bestNode := -1
 		bestPosition := -1
 
 		for node := range unvisited {
-			localMinIncrease := math.MaxInt32
+			localMinIncrease := deltas.MaxWeight[W]()
 			localBestPos := -1
 
 			if len(path) == 0 {
@@ -91,7 +93,7 @@ func repair(partialPath []int, D [][]int, costs []int, targetSize int, rng *rand
 			} else {
 				// Try all positions
 				for pos := 0; pos <= len(path); pos++ {
-					var inc int
+					var inc W
 					if pos == 0 {
 						// Insert at beginning
 						inc = D[node][path[0]] + costs[node]
@@ -133,14 +135,15 @@ func repair(partialPath []int, D [][]int, costs []int, targetSize int, rng *rand
 		}
 	}
 
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
-// isDuplicate checks if solution already exists in population
-func isDuplicate(sol Solution, population []Solution) bool {
-	for _, existing := range population {
-		if sol.Objective == existing.Objective {
-			// Could also compare paths for exact duplicate
+// isDuplicate checks if the same cycle (up to rotation and reflection)
+// already exists in population; hashes holds the tourHash of every
+// individual and hash that of sol.
+func isDuplicate[W Weight](sol Solution[W], hash uint64, population []Solution[W], hashes []uint64) bool {
+	for i, existing := range population {
+		if hashes[i] == hash && sol.Objective == existing.Objective && sameTour(sol.Path, existing.Path) {
 			return true
 		}
 	}
@@ -148,7 +151,7 @@ func isDuplicate(sol Solution, population []Solution) bool {
 }
 
 // findWorstIndex returns index of worst solution in population
-func findWorstIndex(population []Solution) int {
+func findWorstIndex[W Weight](population []Solution[W]) int {
 	worstIdx := 0
 	worstObj := population[0].Objective
 
@@ -180,104 +183,3 @@ func prevIdx(i, n int) int {
 func nextIdx(i, n int) int {
 	return (i + 1) % n
 }
-
-// Calculate delta for 2-opt move (intra-route)
-func deltaTwoOpt(D [][]int, path []int, i, j int) int {
-	if i == j {
-		return 0
-	}
-	n := len(path)
-	// Adjacent edges -> degenerate 2-opt (no change)
-	if nextIdx(i, n) == j || nextIdx(j, n) == i {
-		return 0
-	}
-	a := path[i]
-	b := path[nextIdx(i, n)]
-	c := path[j]
-	d := path[nextIdx(j, n)]
-	before := D[a][b] + D[c][d]
-	after := D[a][c] + D[b][d]
-	return after - before
-}
-
-// Calculate delta for node exchange (inter-route)
-func deltaExchangeSelected(D [][]int, costs []int, path []int, i int, u int) int {
-	n := len(path)
-	a := path[prevIdx(i, n)]
-	v := path[i]
-	b := path[nextIdx(i, n)]
-	before := D[a][v] + D[v][b] + costs[v]
-	after := D[a][u] + D[u][b] + costs[u]
-	return after - before
-}
-
-// Apply 2-opt move
-func applyTwoOpt(path []int, i, j int) {
-	n := len(path)
-	if i == j || nextIdx(i, n) == j || nextIdx(j, n) == i {
-		return
-	}
-	if i > j {
-		i, j = j, i
-	}
-	for l, r := i+1, j; l < r; l, r = l+1, r-1 {
-		path[l], path[r] = path[r], path[l]
-	}
-}
-
-// Apply node exchange
-func applyExchangeSelected(path []int, i int, u int) {
-	path[i] = u
-}
-
-// Steepest local search
-func localSearchSteepest(D [][]int, costs []int, init Solution) Solution {
-	path := append([]int(nil), init.Path...)
-	n := len(path)
-
-	for {
-		bestDelta := 0
-		var bestMove func()
-
-		// Intra-route moves: 2-opt
-		for i := 0; i < n; i++ {
-			for j := i + 1; j < n; j++ {
-				dl := deltaTwoOpt(D, path, i, j)
-				if dl < bestDelta {
-					ii, jj := i, j
-					bestDelta = dl
-					bestMove = func() { applyTwoOpt(path, ii, jj) }
-				}
-			}
-		}
-
-		// Inter-route moves: node exchange with non-selected nodes
-		inSel := make([]bool, len(D))
-		for _, v := range path {
-			inSel[v] = true
-		}
-		nonSel := make([]int, 0, len(D)-n)
-		for u := range D {
-			if !inSel[u] {
-				nonSel = append(nonSel, u)
-			}
-		}
-		for i := 0; i < n; i++ {
-			for _, u := range nonSel {
-				dl := deltaExchangeSelected(D, costs, path, i, u)
-				if dl < bestDelta {
-					ii, uu := i, u
-					bestDelta = dl
-					bestMove = func() { applyExchangeSelected(path, ii, uu) }
-				}
-			}
-		}
-
-		if bestDelta < 0 {
-			bestMove()
-		} else {
-			break
-		}
-	}
-	return Solution{Path: path, Objective: objective(D, costs, path)}
-}
@@ -0,0 +1,66 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randomInstance builds a random Euclidean instance with dim nodes placed on
+// a 1000x1000 grid, with rounded distances and node costs in [0, 500).
+func randomInstance(dim int, rng *rand.Rand) ([][]int, []int) {
+	xs := make([]int, dim)
+	ys := make([]int, dim)
+	costs := make([]int, dim)
+	for i := 0; i < dim; i++ {
+		xs[i], ys[i], costs[i] = rng.Intn(1000), rng.Intn(1000), rng.Intn(500)
+	}
+	D := make([][]int, dim)
+	for i := range D {
+		D[i] = make([]int, dim)
+		for j := range D[i] {
+			if i != j {
+				D[i][j] = int(math.Round(math.Hypot(float64(xs[i]-xs[j]), float64(ys[i]-ys[j]))))
+			}
+		}
+	}
+	return D, costs
+}
+
+// exactInstance builds a random Euclidean instance like randomInstance, but
+// with unrounded float64 distances.
+func exactInstance(dim int, rng *rand.Rand) ([][]float64, []float64) {
+	xs := make([]float64, dim)
+	ys := make([]float64, dim)
+	costs := make([]float64, dim)
+	for i := 0; i < dim; i++ {
+		xs[i], ys[i], costs[i] = rng.Float64()*1000, rng.Float64()*1000, float64(rng.Intn(500))
+	}
+	D := make([][]float64, dim)
+	for i := range D {
+		D[i] = make([]float64, dim)
+		for j := range D[i] {
+			D[i][j] = math.Hypot(xs[i]-xs[j], ys[i]-ys[j])
+		}
+	}
+	return D, costs
+}
+
+// checkSolution fails the test unless sol visits k distinct nodes of the
+// instance and carries its correct objective.
+func checkSolution(t *testing.T, D [][]int, costs []int, sol Solution[int], k int) {
+	t.Helper()
+	if len(sol.Path) != k {
+		t.Fatalf("%d nodes selected, want %d", len(sol.Path), k)
+	}
+	seen := make([]bool, len(D))
+	for _, v := range sol.Path {
+		if v < 0 || v >= len(D) || seen[v] {
+			t.Fatalf("invalid or repeated node %d in %v", v, sol.Path)
+		}
+		seen[v] = true
+	}
+	if got := objective(D, costs, sol.Path); sol.Objective != got {
+		t.Fatalf("objective %d, recomputed %d", sol.Objective, got)
+	}
+}
@@ -3,6 +3,8 @@ package algorithms
 import (
 	"math/rand"
 	"time"
+
+	ils "github.com/czajkowskis/evolutionary_computation/06_labs/local_search_extensions/pkg/algorithms"
 )
 
 // HybridConfig contains configuration for the hybrid algorithm
@@ -10,26 +12,85 @@ type HybridConfig struct {
 	PopulationSize int
 	TimeLimit      time.Duration
 	UseLocalSearch bool
-	Operator       int // 1 or 2
+	Operator       int          // 1, 2, 3 (path relinking) or 4 (EAX)
+	Relinking      ils.PRConfig // Path relinking parameters of operator 3; LocalSearchTop is unused, offspring go through the improvement step
 	Seed           int64
+
+	Replacement  ReplacementPolicy // Which individual an offspring replaces (default ReplaceWorst)
+	EliteCount   int               // Distance-quality replacement: individuals protected by quality (default PopulationSize/4)
+	ClosestCount int               // Distance-quality replacement: neighbours in the diversity contribution (default 3)
+
+	Selection      SelectionType // How parents are drawn (default SelectUniform)
+	TournamentSize int           // Tournament selection size (default 2)
+	RankPressure   float64       // Rank selection pressure in (1, 2] (default 1.5)
+	Mutation       MutationType  // Mutation applied to offspring before local search (default MutationNone)
+	MutationRate   float64       // Probability of mutating an offspring (default 0.1 when Mutation is set)
+
+	Model             EvolutionModel // Steady state (default) or generational replacement; Replacement applies to steady state only
+	StagnationLimit   int            // Offspring without one entering the population before a partial restart (0 = never)
+	RestartElites     int            // Best individuals kept on restart (default PopulationSize/5)
+	MaxPopulationSize int            // Restarts grow the population up to this size, new best solutions shrink it back (0 = fixed size)
+	ResizeStep        int            // Individuals added or removed per resize (default 5)
+
+	// Offspring improvement, applied when UseLocalSearch is set
+	Improvement           ImprovementMethod // Improvement step (default ImproveSteepest)
+	ImprovementIterations int               // LNS and ILS iterations per offspring (default 20)
+	DestroyFraction       float64           // Fraction of nodes removed by an LNS iteration (default 0.3)
+	CandidateK            int               // Candidate list length of ImproveCandidates (default 10)
+	Improver              any               // Custom improvement step, an Improver[W] for the weights of the run; overrides Improvement
 }
 
 // HybridResult contains the result of the hybrid algorithm
-type HybridResult struct {
-	Solution   Solution
-	Iterations int
+type HybridResult[W Weight] struct {
+	Solution     Solution[W]
+	Iterations   int
+	Telemetry    []GenerationStats[W] // one entry per generation
+	Improvements []Improvement[W]     // every new best solution
+
+	MeanParentRank    float64 // mean rank of the selected parents, 0 = best, 1 = worst
+	Mutations         int     // offspring mutated
+	AcceptedMutations int     // mutated offspring accepted into the population
+	Restarts          int     // partial restarts on stagnation
 }
 
 // HybridEvolutionary runs the hybrid evolutionary algorithm
-func HybridEvolutionary(D [][]int, costs []int, config HybridConfig) HybridResult {
+func HybridEvolutionary[W Weight](D [][]W, costs []W, config HybridConfig) HybridResult[W] {
 	rng := rand.New(rand.NewSource(config.Seed))
 	n := len(costs)
 	targetSize := (n + 1) / 2
 
 	startTime := time.Now()
 
+	// One local search workspace is reused by all descents
+	ws := newLSWorkspace[W]()
+
+	if config.EliteCount <= 0 {
+		config.EliteCount = max(config.PopulationSize/4, 1)
+	}
+	if config.ClosestCount <= 0 {
+		config.ClosestCount = 3
+	}
+	if config.TournamentSize <= 0 {
+		config.TournamentSize = 2
+	}
+	if config.RankPressure <= 1 || config.RankPressure > 2 {
+		config.RankPressure = 1.5
+	}
+	if config.Mutation != MutationNone && config.MutationRate <= 0 {
+		config.MutationRate = 0.1
+	}
+
+	if config.MaxPopulationSize > config.PopulationSize && config.ResizeStep <= 0 {
+		config.ResizeStep = 5
+	}
+	if config.RestartElites <= 0 {
+		config.RestartElites = max(config.PopulationSize/5, 1)
+	}
+
+	improver := newOffspringImprover(D, costs, config, ws)
+
 	// Initialize population
-	population := initializePopulation(D, costs, targetSize, config.PopulationSize, rng)
+	population, hashes := initializePopulation(D, costs, targetSize, config.PopulationSize, rng, ws)
 
 	bestSolution := population[0]
 	for _, sol := range population {
@@ -38,49 +99,238 @@ func HybridEvolutionary(D [][]int, costs []int, config HybridConfig) HybridResul
 		}
 	}
 
-	iterations := 0
+	selector := newParentSelector[W](config)
+	selector.update(population)
+
+	telemetry := newHybridTelemetry(len(D), startTime, population)
+	iterations, mutations, acceptedMutations, restarts := 0, 0, 0, 0
+	sinceAccepted, sinceGeneration := 0, 0
+
+	// record updates the best solution and the telemetry for an offspring
+	// that entered the population
+	record := func(offspring Solution[W], steps offspringSteps[W]) bool {
+		telemetry.current.Accepted++
+		if steps.mutated {
+			acceptedMutations++
+		}
+		if offspring.Objective >= bestSolution.Objective {
+			return false
+		}
+		telemetry.improvement(iterations, offspring, steps.producer(bestSolution.Objective, config))
+		bestSolution = offspring
+		return true
+	}
+
 	for time.Since(startTime) < config.TimeLimit {
-		// Select two parents uniformly at random
-		parent1 := population[rng.Intn(len(population))]
-		parent2 := population[rng.Intn(len(population))]
-
-		// Apply recombination
-		var offspring Solution
-		if config.Operator == 1 {
-			offspring = recombineOperator1(parent1, parent2, D, costs, targetSize, rng)
+		newBest := false
+
+		if config.Model == Generational {
+			// Create a whole generation from the current population
+			var offspring []Solution[W]
+			var offspringHashes []uint64
+			var childSteps []offspringSteps[W]
+			for k := 0; k < len(population) && time.Since(startTime) < config.TimeLimit; k++ {
+				child, steps := createOffspring(D, costs, population, selector, improver, config, targetSize, rng)
+				telemetry.offspring(steps.beforeImprovement - child.Objective)
+				iterations++
+				if steps.mutated {
+					mutations++
+				}
+				hash := tourHash(child.Path)
+				if isDuplicate(child, hash, population, hashes) || isDuplicate(child, hash, offspring, offspringHashes) {
+					telemetry.current.Duplicates++
+					continue
+				}
+				offspring = append(offspring, child)
+				offspringHashes = append(offspringHashes, hash)
+				childSteps = append(childSteps, steps)
+			}
+
+			var entered []bool
+			population, hashes, entered = nextGeneration(len(population), population, hashes, offspring, offspringHashes)
+			sinceAccepted += len(offspring)
+			for k, ok := range entered {
+				if ok {
+					newBest = record(offspring[k], childSteps[k]) || newBest
+					sinceAccepted = 0
+				} else {
+					telemetry.current.Rejected++
+				}
+			}
+			selector.update(population)
+			telemetry.endGeneration(iterations, population)
 		} else {
-			offspring = recombineOperator2(parent1, parent2, D, costs, targetSize, rng)
-		}
+			offspring, steps := createOffspring(D, costs, population, selector, improver, config, targetSize, rng)
+			telemetry.offspring(steps.beforeImprovement - offspring.Objective)
+			if steps.mutated {
+				mutations++
+			}
 
-		// Apply local search if enabled
-		if config.UseLocalSearch {
-			offspring = localSearchSteepest(D, costs, offspring)
+			// Update population if offspring is not a duplicate and the
+			// replacement policy accepts it
+			sinceAccepted++
+			hash := tourHash(offspring.Path)
+			if isDuplicate(offspring, hash, population, hashes) {
+				telemetry.current.Duplicates++
+			} else if idx := replacementIndex(len(D), offspring, population, config); idx >= 0 {
+				population[idx], hashes[idx] = offspring, hash
+				selector.update(population)
+				newBest = record(offspring, steps)
+				sinceAccepted = 0
+			} else {
+				telemetry.current.Rejected++
+			}
+
+			iterations++
+			if sinceGeneration++; sinceGeneration >= len(population) {
+				telemetry.endGeneration(iterations, population)
+				sinceGeneration = 0
+			}
 		}
 
-		// Update population if offspring is better and not duplicate
-		if !isDuplicate(offspring, population) {
-			worstIdx := findWorstIndex(population)
-			if offspring.Objective < population[worstIdx].Objective {
-				population[worstIdx] = offspring
+		// A new best solution shrinks a grown population back by ResizeStep
+		if newBest && len(population) > config.PopulationSize {
+			population, hashes = shrinkPopulation(max(len(population)-config.ResizeStep, config.PopulationSize), population, hashes)
+			selector.update(population)
+		}
 
-				if offspring.Objective < bestSolution.Objective {
-					bestSolution = offspring
+		// Partial restart on stagnation, growing the population if enabled
+		if config.StagnationLimit > 0 && sinceAccepted >= config.StagnationLimit && time.Since(startTime) < config.TimeLimit {
+			size := len(population)
+			if config.MaxPopulationSize > size {
+				size = min(size+config.ResizeStep, config.MaxPopulationSize)
+			}
+			population, hashes = restartPopulation(D, costs, targetSize, size, config.RestartElites, population, hashes, rng, ws)
+			previousBest := bestSolution.Objective
+			for _, sol := range population {
+				if sol.Objective < bestSolution.Objective {
+					bestSolution = sol
 				}
 			}
+			if bestSolution.Objective < previousBest {
+				telemetry.improvement(iterations, bestSolution, "restart")
+			}
+			selector.update(population)
+			telemetry.current.Restarts++
+			restarts++
+			sinceAccepted = 0
 		}
+	}
+	if telemetry.current.Offspring > 0 {
+		telemetry.endGeneration(iterations, population)
+	}
+
+	return HybridResult[W]{
+		Solution:     bestSolution,
+		Iterations:   iterations,
+		Telemetry:    telemetry.series,
+		Improvements: telemetry.improvements,
+
+		MeanParentRank:    selector.meanParentRank(),
+		Mutations:         mutations,
+		AcceptedMutations: acceptedMutations,
+		Restarts:          restarts,
+	}
+}
+
+// offspringSteps records the objective of an offspring after the steps of
+// createOffspring, so that a new best solution can be attributed to the step
+// that produced it.
+type offspringSteps[W Weight] struct {
+	recombined        W    // objective after recombination
+	mutated           bool // whether the offspring was mutated
+	beforeImprovement W    // objective after recombination and mutation
+}
+
+// producer returns the first step that brought the offspring below best: the
+// recombination operator, the mutation or the improvement step.
+func (s offspringSteps[W]) producer(best W, config HybridConfig) string {
+	switch {
+	case s.recombined < best:
+		return operatorName(config.Operator)
+	case s.beforeImprovement < best:
+		return "mutation"
+	default:
+		return improvementName(config)
+	}
+}
+
+// createOffspring selects two parents, recombines them, mutates the offspring
+// with probability MutationRate and applies the improvement step if local
+// search is enabled. It returns the offspring and its objective after every
+// step.
+func createOffspring[W Weight](D [][]W, costs []W, population []Solution[W], selector *parentSelector[W], improver *offspringImprover[W], config HybridConfig, targetSize int, rng *rand.Rand) (Solution[W], offspringSteps[W]) {
+	// Select two parents
+	parent1 := population[selector.selectParent(population, rng)]
+	parent2 := population[selector.selectParent(population, rng)]
+
+	// Apply recombination
+	var offspring Solution[W]
+	switch config.Operator {
+	case 1:
+		offspring = recombineOperator1(parent1, parent2, D, costs, targetSize, rng)
+	case 3:
+		offspring = recombinePathRelinking(parent1, parent2, D, costs, config)
+	case 4:
+		offspring = recombineEAX(parent1, parent2, D, costs, targetSize, rng)
+	default:
+		offspring = recombineOperator2(parent1, parent2, D, costs, targetSize, rng)
+	}
 
-		iterations++
+	steps := offspringSteps[W]{recombined: offspring.Objective}
+	steps.mutated = config.Mutation != MutationNone && rng.Float64() < config.MutationRate
+	if steps.mutated {
+		offspring = mutate(D, costs, offspring, config.Mutation, rng)
 	}
+	steps.beforeImprovement = offspring.Objective
 
-	return HybridResult{
-		Solution:   bestSolution,
-		Iterations: iterations,
+	// Apply the improvement step if enabled
+	if config.UseLocalSearch {
+		offspring = improver.improve(D, costs, offspring, rng)
+	}
+	return offspring, steps
+}
+
+// replacementIndex returns the index of the individual replaced by offspring
+// according to the replacement policy, or -1 if offspring is rejected.
+func replacementIndex[W Weight](dim int, offspring Solution[W], population []Solution[W], config HybridConfig) int {
+	switch config.Replacement {
+	case ReplaceMostSimilarWorse:
+		return replaceMostSimilarWorse(dim, offspring, population)
+	case ReplaceDistanceQuality:
+		candidates := append(append(make([]Solution[W], 0, len(population)+1), population...), offspring)
+		fitness := biasedFitness(dim, candidates, config.EliteCount, config.ClosestCount)
+		best := 0
+		for i, sol := range candidates {
+			if sol.Objective < candidates[best].Objective {
+				best = i
+			}
+		}
+		// remove the worst biased fitness, never the best solution
+		worst := -1
+		for i := range candidates {
+			if i != best && (worst < 0 || fitness[i] > fitness[worst]) {
+				worst = i
+			}
+		}
+		if worst == len(population) {
+			return -1
+		}
+		return worst
+	default:
+		worstIdx := findWorstIndex(population)
+		if offspring.Objective < population[worstIdx].Objective {
+			return worstIdx
+		}
+		return -1
 	}
 }
 
 // initializePopulation creates initial population using random start + local search
-func initializePopulation(D [][]int, costs []int, targetSize, popSize int, rng *rand.Rand) []Solution {
-	population := make([]Solution, 0, popSize)
+// and returns it with the tourHash of every individual
+func initializePopulation[W Weight](D [][]W, costs []W, targetSize, popSize int, rng *rand.Rand, ws *lsWorkspace[W]) ([]Solution[W], []uint64) {
+	population := make([]Solution[W], 0, popSize)
+	hashes := make([]uint64, 0, popSize)
 	n := len(costs)
 
 	for len(population) < popSize {
@@ -88,19 +338,21 @@ func initializePopulation(D [][]int, costs []int, targetSize, popSize int, rng *
 		sol := randomConstruction(D, costs, n, targetSize, rng)
 
 		// Apply local search
-		sol = localSearchSteepest(D, costs, sol)
+		sol = ws.localSearchSteepest(D, costs, sol)
 
 		// Add if not duplicate
-		if !isDuplicate(sol, population) {
+		hash := tourHash(sol.Path)
+		if !isDuplicate(sol, hash, population, hashes) {
 			population = append(population, sol)
+			hashes = append(hashes, hash)
 		}
 	}
 
-	return population
+	return population, hashes
 }
 
 // recombineOperator1 implements the common edges/nodes operator
-func recombineOperator1(parent1, parent2 Solution, D [][]int, costs []int, targetSize int, rng *rand.Rand) Solution {
+func recombineOperator1[W Weight](parent1, parent2 Solution[W], D [][]W, costs []W, targetSize int, rng *rand.Rand) Solution[W] {
 	n := len(costs)
 
 	// Find common nodes
@@ -187,11 +439,11 @@ func recombineOperator1(parent1, parent2 Solution, D [][]int, costs []int, targe
 		}
 	}
 
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
 // findCommonSubpaths identifies common subpaths between two parents
-func findCommonSubpaths(parent1, parent2 Solution, commonNodes []int) [][]int {
+func findCommonSubpaths[W Weight](parent1, parent2 Solution[W], commonNodes []int) [][]int {
 	if len(commonNodes) == 0 {
 		return [][]int{}
 	}
@@ -244,9 +496,9 @@ func findCommonSubpaths(parent1, parent2 Solution, commonNodes []int) [][]int {
 }
 
 // recombineOperator2 implements the parent-based repair operator
-func recombineOperator2(parent1, parent2 Solution, D [][]int, costs []int, targetSize int, rng *rand.Rand) Solution {
+func recombineOperator2[W Weight](parent1, parent2 Solution[W], D [][]W, costs []W, targetSize int, rng *rand.Rand) Solution[W] {
 	// Choose one parent as base
-	var baseParent Solution
+	var baseParent Solution[W]
 	if rng.Float64() < 0.5 {
 		baseParent = parent1
 	} else {
@@ -275,3 +527,23 @@ func recombineOperator2(parent1, parent2 Solution, D [][]int, costs []int, targe
 	// Repair using greedy heuristic
 	return repair(partialPath, D, costs, targetSize, rng)
 }
+
+// recombinePathRelinking relinks the parents and returns the best solution on
+// the path between them that differs from both parents, even if it is worse
+// than the better parent, since returning a parent would only produce a
+// duplicate. The offspring is improved afterwards by the improvement step,
+// like the offspring of the other operators. The better parent is returned
+// only when the parents are too close to have a distinct solution between
+// them.
+func recombinePathRelinking[W Weight](parent1, parent2 Solution[W], D [][]W, costs []W, config HybridConfig) Solution[W] {
+	dim := len(D)
+	for _, sol := range relinkCandidates(D, costs, parent1, parent2, config.Relinking) {
+		if solutionDistance(dim, sol.Path, parent1.Path) > 0 && solutionDistance(dim, sol.Path, parent2.Path) > 0 {
+			return sol
+		}
+	}
+	if parent2.Objective < parent1.Objective {
+		return parent2
+	}
+	return parent1
+}
@@ -0,0 +1,128 @@
+package algorithms
+
+import (
+	"fmt"
+	"math/rand"
+
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+	lns "github.com/czajkowskis/evolutionary_computation/07_labs/large_neighborhood_search/pkg/algorithms"
+)
+
+// ImprovementMethod selects how offspring are improved when
+// HybridConfig.UseLocalSearch is set.
+type ImprovementMethod int
+
+const (
+	// ImproveSteepest applies steepest local search over the full 2-opt and
+	// exchange neighbourhood.
+	ImproveSteepest ImprovementMethod = iota
+	// ImproveCandidates applies the steepest local search of lab 05
+	// restricted to moves introducing a candidate edge (CandidateK nearest
+	// neighbours).
+	ImproveCandidates
+	// ImproveLNS runs a short LNS: ImprovementIterations times, a fraction
+	// DestroyFraction of the nodes is removed by the worst-edge or Shaw
+	// removal of lab 07, the solution is repaired greedily and improved by
+	// steepest local search; improving solutions are accepted.
+	ImproveLNS
+	// ImproveILS runs a short ILS: ImprovementIterations times, the solution
+	// is perturbed by a double bridge move and a random exchange and improved
+	// by steepest local search; improving solutions are accepted.
+	ImproveILS
+)
+
+func (m ImprovementMethod) String() string {
+	switch m {
+	case ImproveCandidates:
+		return "candidates"
+	case ImproveLNS:
+		return "lns"
+	case ImproveILS:
+		return "ils"
+	default:
+		return "steepest"
+	}
+}
+
+// Improver improves an offspring. It is given the random source of the
+// algorithm and must return a solution with a correct objective.
+type Improver[W Weight] func(D [][]W, costs []W, sol Solution[W], rng *rand.Rand) Solution[W]
+
+// offspringImprover applies the configured improvement step.
+type offspringImprover[W Weight] struct {
+	method          ImprovementMethod
+	iterations      int
+	destroyFraction float64
+	cand            *deltas.LocalSearcher[W]
+	custom          Improver[W]
+	ws              *lsWorkspace[W]
+}
+
+func newOffspringImprover[W Weight](D [][]W, costs []W, config HybridConfig, ws *lsWorkspace[W]) *offspringImprover[W] {
+	im := &offspringImprover[W]{
+		method:          config.Improvement,
+		iterations:      config.ImprovementIterations,
+		destroyFraction: config.DestroyFraction,
+		ws:              ws,
+	}
+	if config.Improver != nil {
+		custom, ok := config.Improver.(Improver[W])
+		if !ok {
+			panic(fmt.Sprintf("HybridConfig.Improver is %T, want %T", config.Improver, custom))
+		}
+		im.custom = custom
+	}
+	if im.iterations <= 0 {
+		im.iterations = 20
+	}
+	if im.destroyFraction <= 0 || im.destroyFraction >= 1 {
+		im.destroyFraction = 0.3
+	}
+	if im.custom == nil && im.method == ImproveCandidates {
+		m := deltas.MethodSpec{Name: "candidates", UseCand: true, CandK: config.CandidateK}
+		im.cand = deltas.NewLocalSearcher(m, D, costs, nil)
+	}
+	return im
+}
+
+// improve returns the improved offspring.
+func (im *offspringImprover[W]) improve(D [][]W, costs []W, sol Solution[W], rng *rand.Rand) Solution[W] {
+	if im.custom != nil {
+		return im.custom(D, costs, sol, rng)
+	}
+
+	switch im.method {
+	case ImproveCandidates:
+		path := append([]int(nil), sol.Path...)
+		im.cand.Improve(D, costs, path)
+		return Solution[W]{Path: path, Objective: objective(D, costs, path)}
+	case ImproveLNS:
+		targetSize := len(sol.Path)
+		best := im.ws.localSearchSteepest(D, costs, sol)
+		for it := 0; it < im.iterations; it++ {
+			var partial []int
+			if rng.Intn(2) == 0 {
+				partial = lns.DestroyWorstEdges(best.Path, im.destroyFraction, D, costs, rng)
+			} else {
+				partial = lns.DestroyShaw(best.Path, im.destroyFraction, D, costs, rng)
+			}
+			candidate := im.ws.localSearchSteepest(D, costs, repair(partial, D, costs, targetSize, rng))
+			if candidate.Objective < best.Objective {
+				best = candidate
+			}
+		}
+		return best
+	case ImproveILS:
+		best := im.ws.localSearchSteepest(D, costs, sol)
+		for it := 0; it < im.iterations; it++ {
+			perturbed := mutate(D, costs, best, MutationDoubleBridge, rng)
+			perturbed = mutate(D, costs, perturbed, MutationRandomExchange, rng)
+			if candidate := im.ws.localSearchSteepest(D, costs, perturbed); candidate.Objective < best.Objective {
+				best = candidate
+			}
+		}
+		return best
+	default:
+		return im.ws.localSearchSteepest(D, costs, sol)
+	}
+}
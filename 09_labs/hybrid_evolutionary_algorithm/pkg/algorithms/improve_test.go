@@ -0,0 +1,72 @@
+package algorithms
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// TestOffspringImprover checks that every improvement method, including the
+// ones imported from labs 05 and 07, returns a tour of the same size with a
+// correct objective that is not worse than the offspring.
+func TestOffspringImprover(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	D, costs := randomInstance(60, rng)
+	dim, k := len(D), (len(D)+1)/2
+	for _, method := range []ImprovementMethod{ImproveSteepest, ImproveCandidates, ImproveLNS, ImproveILS} {
+		t.Run(method.String(), func(t *testing.T) {
+			config := HybridConfig{UseLocalSearch: true, Improvement: method, ImprovementIterations: 5}
+			im := newOffspringImprover(D, costs, config, newLSWorkspace[int]())
+			for trial := 0; trial < 10; trial++ {
+				sol := randomConstruction(D, costs, dim, k, rng)
+				improved := im.improve(D, costs, sol, rng)
+				if len(improved.Path) != k || improved.Objective != objective(D, costs, improved.Path) {
+					t.Fatalf("invalid solution %v with objective %d", improved.Path, improved.Objective)
+				}
+				seen := make([]bool, dim)
+				for _, v := range improved.Path {
+					if seen[v] {
+						t.Fatalf("node %d visited twice in %v", v, improved.Path)
+					}
+					seen[v] = true
+				}
+				if improved.Objective > sol.Objective {
+					t.Fatalf("worsened the offspring from %d to %d", sol.Objective, improved.Objective)
+				}
+			}
+		})
+	}
+}
+
+// TestHybridExactWeights runs every recombination operator and a custom
+// improver on float64 distances and checks the returned solution.
+func TestHybridExactWeights(t *testing.T) {
+	D, costs := exactInstance(40, rand.New(rand.NewSource(1)))
+	k := (len(D) + 1) / 2
+	steepest := Improver[float64](func(D [][]float64, costs []float64, sol Solution[float64], rng *rand.Rand) Solution[float64] {
+		return newLSWorkspace[float64]().localSearchSteepest(D, costs, sol)
+	})
+	for operator := 1; operator <= 4; operator++ {
+		for _, improver := range []any{nil, steepest} {
+			t.Run(fmt.Sprintf("op%d/custom=%t", operator, improver != nil), func(t *testing.T) {
+				config := HybridConfig{
+					PopulationSize: 10,
+					TimeLimit:      30 * time.Millisecond,
+					UseLocalSearch: true,
+					Operator:       operator,
+					Seed:           1,
+					Improver:       improver,
+				}
+				sol := HybridEvolutionary(D, costs, config).Solution
+				if len(sol.Path) != k {
+					t.Fatalf("%d nodes selected, want %d", len(sol.Path), k)
+				}
+				if got := objective(D, costs, sol.Path); math.Abs(got-sol.Objective) > 1e-6 {
+					t.Fatalf("objective %f, tour evaluates to %f", sol.Objective, got)
+				}
+			})
+		}
+	}
+}
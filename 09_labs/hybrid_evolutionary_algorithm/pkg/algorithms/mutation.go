@@ -0,0 +1,86 @@
+package algorithms
+
+import "math/rand"
+
+// MutationType selects the mutation applied to offspring before local search.
+type MutationType int
+
+const (
+	// MutationNone leaves offspring unchanged.
+	MutationNone MutationType = iota
+	// MutationRandomExchange replaces a random selected node with a random
+	// unselected one.
+	MutationRandomExchange
+	// MutationDoubleBridge cuts the cycle into four segments A B C D and
+	// reconnects them as A C B D.
+	MutationDoubleBridge
+	// MutationSegmentScramble shuffles the nodes of a random segment of a
+	// tenth of the path (at least 3 nodes).
+	MutationSegmentScramble
+)
+
+func (m MutationType) String() string {
+	switch m {
+	case MutationRandomExchange:
+		return "random_exchange"
+	case MutationDoubleBridge:
+		return "double_bridge"
+	case MutationSegmentScramble:
+		return "segment_scramble"
+	default:
+		return "none"
+	}
+}
+
+// mutate returns a mutated copy of sol.
+func mutate[W Weight](D [][]W, costs []W, sol Solution[W], mutation MutationType, rng *rand.Rand) Solution[W] {
+	path := append([]int(nil), sol.Path...)
+	n := len(path)
+
+	switch mutation {
+	case MutationRandomExchange:
+		inSel := make([]bool, len(D))
+		for _, v := range path {
+			inSel[v] = true
+		}
+		free := make([]int, 0, len(D)-n)
+		for u := range inSel {
+			if !inSel[u] {
+				free = append(free, u)
+			}
+		}
+		if n > 0 && len(free) > 0 {
+			path[rng.Intn(n)] = free[rng.Intn(len(free))]
+		}
+	case MutationDoubleBridge:
+		if n >= 8 {
+			// three distinct cut points splitting path into A B C D
+			cuts := rng.Perm(n - 1)[:3]
+			a, b, c := cuts[0]+1, cuts[1]+1, cuts[2]+1
+			if a > b {
+				a, b = b, a
+			}
+			if b > c {
+				b, c = c, b
+			}
+			if a > b {
+				a, b = b, a
+			}
+			mutated := make([]int, 0, n)
+			mutated = append(mutated, path[:a]...)
+			mutated = append(mutated, path[b:c]...)
+			mutated = append(mutated, path[a:b]...)
+			mutated = append(mutated, path[c:]...)
+			path = mutated
+		}
+	case MutationSegmentScramble:
+		length := max(n/10, 3)
+		if n > length {
+			start := rng.Intn(n - length + 1)
+			segment := path[start : start+length]
+			rng.Shuffle(len(segment), func(i, j int) { segment[i], segment[j] = segment[j], segment[i] })
+		}
+	}
+
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
+}
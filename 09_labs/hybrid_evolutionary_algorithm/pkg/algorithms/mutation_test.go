@@ -0,0 +1,51 @@
+package algorithms
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// TestMutate checks that every mutation returns a valid solution with a
+// correct objective, leaves the original untouched, and that only the random
+// exchange changes the selected nodes, by exactly one unselected node.
+func TestMutate(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	D, costs := randomInstance(60, rng)
+	dim, k := len(D), (len(D)+1)/2
+	for _, mutation := range []MutationType{MutationNone, MutationRandomExchange, MutationDoubleBridge, MutationSegmentScramble} {
+		t.Run(mutation.String(), func(t *testing.T) {
+			for trial := 0; trial < 100; trial++ {
+				sol := randomConstruction(D, costs, dim, k, rng)
+				orig := append([]int(nil), sol.Path...)
+				mutated := mutate(D, costs, sol, mutation, rng)
+				checkSolution(t, D, costs, mutated, k)
+				if !slices.Equal(sol.Path, orig) {
+					t.Fatalf("mutation changed the original solution")
+				}
+
+				before, after := slices.Sorted(slices.Values(orig)), slices.Sorted(slices.Values(mutated.Path))
+				changed := 0
+				for i := range orig {
+					if orig[i] != mutated.Path[i] {
+						changed++
+					}
+				}
+				switch mutation {
+				case MutationNone:
+					if changed != 0 {
+						t.Fatalf("%v changed to %v", orig, mutated.Path)
+					}
+				case MutationRandomExchange:
+					if changed != 1 || slices.Equal(before, after) {
+						t.Fatalf("%v changed to %v, want exactly one node exchanged", orig, mutated.Path)
+					}
+				default:
+					if !slices.Equal(before, after) {
+						t.Fatalf("%v changed the selected nodes to %v", orig, mutated.Path)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package algorithms
+
+import (
+	ils "github.com/czajkowskis/evolutionary_computation/06_labs/local_search_extensions/pkg/algorithms"
+)
+
+// relinkCandidates walks between the parents with the path relinking of lab
+// 06 and returns the intermediate solutions found on the way, best first,
+// without local search. The parents themselves are not included.
+func relinkCandidates[W Weight](D [][]W, costs []W, parent1, parent2 Solution[W], config ils.PRConfig) []Solution[W] {
+	walk := ils.RelinkPath(D, costs, ils.Solution[W](parent1), ils.Solution[W](parent2), config)
+	candidates := make([]Solution[W], len(walk))
+	for i, sol := range walk {
+		candidates[i] = Solution[W](sol)
+	}
+	return candidates
+}
@@ -0,0 +1,45 @@
+package algorithms
+
+import (
+	"math/rand"
+	"testing"
+
+	ils "github.com/czajkowskis/evolutionary_computation/06_labs/local_search_extensions/pkg/algorithms"
+)
+
+// TestRecombinePathRelinkingAvoidsParents checks that the path relinking
+// operator returns a valid solution on the path between two distinct local
+// optima that is neither of them, for every relinking strategy.
+func TestRecombinePathRelinkingAvoidsParents(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	D, costs := randomInstance(60, rng)
+	dim, k := len(D), (len(D)+1)/2
+	ws := newLSWorkspace[int]()
+
+	for _, strategy := range []ils.PRStrategy{ils.PRForward, ils.PRBackward, ils.PRMixed, ils.PRTruncated} {
+		t.Run(strategy.String(), func(t *testing.T) {
+			config := HybridConfig{Relinking: ils.PRConfig{Strategy: strategy}}
+			for trial := 0; trial < 20; trial++ {
+				p1 := ws.localSearchSteepest(D, costs, randomConstruction(D, costs, dim, k, rng))
+				p2 := ws.localSearchSteepest(D, costs, randomConstruction(D, costs, dim, k, rng))
+				if solutionDistance(dim, p1.Path, p2.Path) < 4 {
+					continue
+				}
+				child := recombinePathRelinking(p1, p2, D, costs, config)
+				if len(child.Path) != k || child.Objective != objective(D, costs, child.Path) {
+					t.Fatalf("invalid offspring %v with objective %d", child.Path, child.Objective)
+				}
+				seen := make([]bool, dim)
+				for _, v := range child.Path {
+					if seen[v] {
+						t.Fatalf("offspring %v visits %d twice", child.Path, v)
+					}
+					seen[v] = true
+				}
+				if solutionDistance(dim, child.Path, p1.Path) == 0 || solutionDistance(dim, child.Path, p2.Path) == 0 {
+					t.Fatalf("offspring duplicates a parent")
+				}
+			}
+		})
+	}
+}
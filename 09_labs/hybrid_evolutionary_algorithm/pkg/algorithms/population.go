@@ -0,0 +1,105 @@
+package algorithms
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// EvolutionModel selects how offspring enter the population.
+type EvolutionModel int
+
+const (
+	// SteadyState inserts every accepted offspring immediately, replacing the
+	// individual chosen by the replacement policy.
+	SteadyState EvolutionModel = iota
+	// Generational creates a whole generation of offspring from the current
+	// population; the next population consists of the best distinct
+	// individuals among the parents and offspring (elitist mu + lambda).
+	Generational
+)
+
+func (m EvolutionModel) String() string {
+	if m == Generational {
+		return "generational"
+	}
+	return "steady_state"
+}
+
+// sortPopulation sorts the population and its hashes by objective.
+func sortPopulation[W Weight](population []Solution[W], hashes []uint64) {
+	order := make([]int, len(population))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return population[order[a]].Objective < population[order[b]].Objective })
+	sortedPop := make([]Solution[W], len(population))
+	sortedHashes := make([]uint64, len(hashes))
+	for i, idx := range order {
+		sortedPop[i], sortedHashes[i] = population[idx], hashes[idx]
+	}
+	copy(population, sortedPop)
+	copy(hashes, sortedHashes)
+}
+
+// restartPopulation keeps the elites best individuals and fills the
+// population up to size with new random local optima. A size above the
+// current one grows the population.
+func restartPopulation[W Weight](D [][]W, costs []W, targetSize, size, elites int, population []Solution[W], hashes []uint64, rng *rand.Rand, ws *lsWorkspace[W]) ([]Solution[W], []uint64) {
+	sortPopulation(population, hashes)
+	elites = min(elites, len(population), size)
+	kept := append([]Solution[W](nil), population[:elites]...)
+	keptHashes := append([]uint64(nil), hashes[:elites]...)
+
+	for len(kept) < size {
+		fresh, freshHashes := initializePopulation(D, costs, targetSize, size-len(kept), rng, ws)
+		for i, sol := range fresh {
+			if !isDuplicate(sol, freshHashes[i], kept, keptHashes) {
+				kept = append(kept, sol)
+				keptHashes = append(keptHashes, freshHashes[i])
+			}
+		}
+	}
+	return kept, keptHashes
+}
+
+// shrinkPopulation removes the worst individuals until the population has
+// size individuals.
+func shrinkPopulation[W Weight](size int, population []Solution[W], hashes []uint64) ([]Solution[W], []uint64) {
+	if len(population) <= size {
+		return population, hashes
+	}
+	sortPopulation(population, hashes)
+	return population[:size], hashes[:size]
+}
+
+// nextGeneration returns the size best distinct individuals among parents
+// and offspring, and which offspring entered it.
+func nextGeneration[W Weight](size int, parents []Solution[W], parentHashes []uint64, offspring []Solution[W], offspringHashes []uint64) ([]Solution[W], []uint64, []bool) {
+	candidates := append(append([]Solution[W](nil), parents...), offspring...)
+	candidateHashes := append(append([]uint64(nil), parentHashes...), offspringHashes...)
+	order := make([]int, len(candidates))
+	for i := range order {
+		order[i] = i
+	}
+	// parents first among equal objectives, so that an offspring equal to a
+	// parent does not count as entering the population
+	sort.SliceStable(order, func(a, b int) bool { return candidates[order[a]].Objective < candidates[order[b]].Objective })
+
+	next := make([]Solution[W], 0, size)
+	nextHashes := make([]uint64, 0, size)
+	entered := make([]bool, len(offspring))
+	for _, idx := range order {
+		if len(next) == size {
+			break
+		}
+		if isDuplicate(candidates[idx], candidateHashes[idx], next, nextHashes) {
+			continue
+		}
+		next = append(next, candidates[idx])
+		nextHashes = append(nextHashes, candidateHashes[idx])
+		if idx >= len(parents) {
+			entered[idx-len(parents)] = true
+		}
+	}
+	return next, nextHashes, entered
+}
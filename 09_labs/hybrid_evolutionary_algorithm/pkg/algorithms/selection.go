@@ -0,0 +1,139 @@
+package algorithms
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// SelectionType selects how parents are drawn from the population.
+type SelectionType int
+
+const (
+	// SelectUniform draws every individual with the same probability.
+	SelectUniform SelectionType = iota
+	// SelectTournament draws TournamentSize individuals uniformly and returns
+	// the best of them.
+	SelectTournament
+	// SelectRank draws individuals with linear ranking: the probability of the
+	// individual of rank r (0 = best) out of P is (2 - s + 2(s-1)(P-1-r)/(P-1))/P
+	// with selection pressure s = RankPressure.
+	SelectRank
+	// SelectFitnessProportional draws individuals by roulette wheel over the
+	// fitness worst - objective + 1.
+	SelectFitnessProportional
+)
+
+func (s SelectionType) String() string {
+	switch s {
+	case SelectTournament:
+		return "tournament"
+	case SelectRank:
+		return "rank"
+	case SelectFitnessProportional:
+		return "fitness_proportional"
+	default:
+		return "uniform"
+	}
+}
+
+// parentSelector draws parents from the population and records the
+// normalised rank of every drawn parent (0 = best, 1 = worst).
+type parentSelector[W Weight] struct {
+	selection      SelectionType
+	tournamentSize int
+	rankPressure   float64
+
+	order   []int     // population indices sorted by objective
+	rankOf  []int     // rank of every population index
+	weights []float64 // roulette wheel weights of the rank and fitness selections
+
+	rankSum float64
+	drawn   int
+}
+
+func newParentSelector[W Weight](config HybridConfig) *parentSelector[W] {
+	return &parentSelector[W]{
+		selection:      config.Selection,
+		tournamentSize: config.TournamentSize,
+		rankPressure:   config.RankPressure,
+	}
+}
+
+// update recomputes the ranks and wheel weights of the population; it must
+// be called whenever the population changes.
+func (ps *parentSelector[W]) update(population []Solution[W]) {
+	size := len(population)
+	ps.order = ps.order[:0]
+	for i := 0; i < size; i++ {
+		ps.order = append(ps.order, i)
+	}
+	sort.SliceStable(ps.order, func(a, b int) bool {
+		return population[ps.order[a]].Objective < population[ps.order[b]].Objective
+	})
+	ps.rankOf = append(ps.rankOf[:0], make([]int, size)...)
+	for r, i := range ps.order {
+		ps.rankOf[i] = r
+	}
+
+	ps.weights = append(ps.weights[:0], make([]float64, size)...)
+	switch ps.selection {
+	case SelectRank:
+		s := ps.rankPressure
+		for r, i := range ps.order {
+			if size == 1 {
+				ps.weights[i] = 1
+				continue
+			}
+			ps.weights[i] = 2 - s + 2*(s-1)*float64(size-1-r)/float64(size-1)
+		}
+	case SelectFitnessProportional:
+		worst := population[ps.order[size-1]].Objective
+		for i, sol := range population {
+			ps.weights[i] = float64(worst - sol.Objective + 1)
+		}
+	}
+}
+
+// selectParent returns the index of a parent.
+func (ps *parentSelector[W]) selectParent(population []Solution[W], rng *rand.Rand) int {
+	var idx int
+	switch ps.selection {
+	case SelectTournament:
+		idx = rng.Intn(len(population))
+		for k := 1; k < ps.tournamentSize; k++ {
+			if c := rng.Intn(len(population)); population[c].Objective < population[idx].Objective {
+				idx = c
+			}
+		}
+	case SelectRank, SelectFitnessProportional:
+		total := 0.0
+		for _, w := range ps.weights {
+			total += w
+		}
+		r := rng.Float64() * total
+		idx = len(ps.weights) - 1
+		for i, w := range ps.weights {
+			if r < w {
+				idx = i
+				break
+			}
+			r -= w
+		}
+	default:
+		idx = rng.Intn(len(population))
+	}
+
+	if len(population) > 1 {
+		ps.rankSum += float64(ps.rankOf[idx]) / float64(len(population)-1)
+	}
+	ps.drawn++
+	return idx
+}
+
+// meanParentRank returns the mean normalised rank of the drawn parents.
+func (ps *parentSelector[W]) meanParentRank() float64 {
+	if ps.drawn == 0 {
+		return 0
+	}
+	return ps.rankSum / float64(ps.drawn)
+}
@@ -0,0 +1,53 @@
+package algorithms
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestParentSelection draws parents from a population with distinct
+// objectives and checks that every selection returns valid indices, that
+// uniform selection has a mean normalised parent rank of about 0.5 while the
+// others prefer better individuals, and that linear ranking draws the best
+// and the worst individual with probabilities s/P and (2-s)/P.
+func TestParentSelection(t *testing.T) {
+	const size, draws, pressure = 10, 50000, 1.5
+	rng := rand.New(rand.NewSource(1))
+	population := make([]Solution[int], size)
+	for i, obj := range rng.Perm(size) {
+		population[i] = Solution[int]{Objective: 1000 + 100*obj}
+	}
+
+	for _, selection := range []SelectionType{SelectUniform, SelectTournament, SelectRank, SelectFitnessProportional} {
+		t.Run(selection.String(), func(t *testing.T) {
+			ps := newParentSelector[int](HybridConfig{Selection: selection, TournamentSize: 2, RankPressure: pressure})
+			ps.update(population)
+			counts := make([]int, size)
+			for d := 0; d < draws; d++ {
+				idx := ps.selectParent(population, rng)
+				if idx < 0 || idx >= size {
+					t.Fatalf("parent index %d out of range", idx)
+				}
+				counts[ps.rankOf[idx]]++
+			}
+
+			mean := ps.meanParentRank()
+			if selection == SelectUniform {
+				if math.Abs(mean-0.5) > 0.02 {
+					t.Errorf("mean parent rank %.3f, want about 0.5", mean)
+				}
+			} else if mean > 0.45 {
+				t.Errorf("mean parent rank %.3f, want a preference for better individuals", mean)
+			}
+
+			if selection == SelectRank {
+				best, worst := float64(counts[0])/draws, float64(counts[size-1])/draws
+				if math.Abs(best-pressure/size) > 0.01 || math.Abs(worst-(2-pressure)/size) > 0.01 {
+					t.Errorf("best drawn with frequency %.3f, worst with %.3f, want %.3f and %.3f",
+						best, worst, pressure/size, (2-pressure)/size)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package algorithms
+
+import "time"
+
+// GenerationStats summarises one generation of the hybrid evolutionary
+// algorithm, as many iterations as there are individuals. Generation 0
+// describes the initial population.
+type GenerationStats[W Weight] struct {
+	Generation        int
+	Iterations        int           // iterations completed so far
+	Elapsed           time.Duration // time since the start of the run
+	Offspring         int           // offspring created in this generation
+	Accepted          int           // offspring accepted into the population
+	Duplicates        int           // offspring rejected as duplicates
+	Rejected          int           // offspring rejected by the replacement policy
+	Restarts          int           // partial restarts of the population
+	PopulationSize    int           // population size at the end of the generation
+	MeanLSImprovement float64       // mean objective decrease of an offspring by local search
+	Best              W             // population objectives at the end of the generation
+	Mean              float64
+	Worst             W
+	Diversity         float64 // mean pairwise distance relative to its maximum
+}
+
+// Improvement records a new best solution and the step that produced it.
+type Improvement[W Weight] struct {
+	Iteration int
+	Elapsed   time.Duration
+	Objective W
+	Step      string // recombination operator, "mutation", improvement method or "restart"
+}
+
+// operatorName returns the name of the recombination operator.
+func operatorName(operator int) string {
+	switch operator {
+	case 1:
+		return "common_subpaths"
+	case 3:
+		return "path_relinking"
+	case 4:
+		return "eax"
+	default:
+		return "greedy_repair"
+	}
+}
+
+// improvementName returns the name of the improvement step.
+func improvementName(config HybridConfig) string {
+	if config.Improver != nil {
+		return "improver"
+	}
+	return "ls_" + config.Improvement.String()
+}
+
+// hybridTelemetry collects the statistics of the current generation and the
+// time series of the finished ones.
+type hybridTelemetry[W Weight] struct {
+	dim          int
+	startTime    time.Time
+	current      GenerationStats[W]
+	lsSum        W
+	series       []GenerationStats[W]
+	improvements []Improvement[W]
+}
+
+func newHybridTelemetry[W Weight](dim int, startTime time.Time, population []Solution[W]) *hybridTelemetry[W] {
+	t := &hybridTelemetry[W]{dim: dim, startTime: startTime}
+	t.endGeneration(0, population)
+	return t
+}
+
+// offspring records an offspring and its objective decrease by local search.
+func (t *hybridTelemetry[W]) offspring(lsImprovement W) {
+	t.current.Offspring++
+	t.lsSum += lsImprovement
+}
+
+// improvement records a new best solution produced by step.
+func (t *hybridTelemetry[W]) improvement(iteration int, sol Solution[W], step string) {
+	t.improvements = append(t.improvements, Improvement[W]{
+		Iteration: iteration,
+		Elapsed:   time.Since(t.startTime),
+		Objective: sol.Objective,
+		Step:      step,
+	})
+}
+
+// endGeneration closes the current generation with the state of population
+// and starts the next one.
+func (t *hybridTelemetry[W]) endGeneration(iterations int, population []Solution[W]) {
+	g := t.current
+	g.Generation = len(t.series)
+	g.Iterations = iterations
+	g.Elapsed = time.Since(t.startTime)
+	g.PopulationSize = len(population)
+	if g.Offspring > 0 {
+		g.MeanLSImprovement = float64(t.lsSum) / float64(g.Offspring)
+	}
+	var sum W
+	g.Best, g.Worst = population[0].Objective, population[0].Objective
+	for _, sol := range population {
+		g.Best = min(g.Best, sol.Objective)
+		g.Worst = max(g.Worst, sol.Objective)
+		sum += sol.Objective
+	}
+	g.Mean = float64(sum) / float64(len(population))
+	g.Diversity = populationDiversity(t.dim, population)
+
+	t.series = append(t.series, g)
+	t.current = GenerationStats[W]{}
+	t.lsSum = 0
+}
@@ -0,0 +1,58 @@
+package algorithms
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// TestOffspringStepsProducer checks that a new best solution is attributed to
+// the first step of createOffspring that brought the offspring below the best
+// objective.
+func TestOffspringStepsProducer(t *testing.T) {
+	config := HybridConfig{Operator: 4, UseLocalSearch: true, Improvement: ImproveLNS}
+	for _, tc := range []struct {
+		steps offspringSteps[int]
+		want  string
+	}{
+		{offspringSteps[int]{recombined: 90, beforeImprovement: 90}, "eax"},
+		{offspringSteps[int]{recombined: 90, mutated: true, beforeImprovement: 120}, "eax"},
+		{offspringSteps[int]{recombined: 120, mutated: true, beforeImprovement: 90}, "mutation"},
+		{offspringSteps[int]{recombined: 120, mutated: true, beforeImprovement: 110}, "ls_lns"},
+		{offspringSteps[int]{recombined: 100, beforeImprovement: 100}, "ls_lns"},
+	} {
+		if got := tc.steps.producer(100, config); got != tc.want {
+			t.Errorf("%+v: attributed to %q, want %q", tc.steps, got, tc.want)
+		}
+	}
+}
+
+// TestImprovementsTrace runs the algorithm with mutation, local search and
+// restarts and checks that the recorded improvements are strictly decreasing,
+// end at the returned solution and name one of the steps of the run.
+func TestImprovementsTrace(t *testing.T) {
+	D, costs := randomInstance(60, rand.New(rand.NewSource(1)))
+	config := HybridConfig{
+		PopulationSize:  10,
+		TimeLimit:       200 * time.Millisecond,
+		UseLocalSearch:  true,
+		Operator:        2,
+		Seed:            1,
+		Mutation:        MutationDoubleBridge,
+		MutationRate:    0.5,
+		StagnationLimit: 20,
+	}
+	result := HybridEvolutionary(D, costs, config)
+	steps := map[string]bool{"greedy_repair": true, "mutation": true, "ls_steepest": true, "restart": true}
+	for i, imp := range result.Improvements {
+		if !steps[imp.Step] {
+			t.Fatalf("improvement %d attributed to unknown step %q", i, imp.Step)
+		}
+		if i > 0 && imp.Objective >= result.Improvements[i-1].Objective {
+			t.Fatalf("improvement %d to %d does not improve on %d", i, imp.Objective, result.Improvements[i-1].Objective)
+		}
+	}
+	if n := len(result.Improvements); n > 0 && result.Improvements[n-1].Objective != result.Solution.Objective {
+		t.Fatalf("last improvement %d, returned solution %d", result.Improvements[n-1].Objective, result.Solution.Objective)
+	}
+}
@@ -0,0 +1,29 @@
+package algorithms
+
+import (
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+)
+
+// lsWorkspace holds the buffers of the steepest local search of lab 05,
+// reused by every offspring, so that a warmed-up workspace performs no heap
+// allocations inside the local search loop.
+//
+// A workspace is bound to a single path at a time and is not safe for
+// concurrent use.
+type lsWorkspace[W Weight] struct {
+	ls deltas.LocalSearcher[W] // baseline steepest local search over 2-opt and exchange moves
+}
+
+// newLSWorkspace creates a workspace; its buffers grow with the first
+// instance it is used with.
+func newLSWorkspace[W Weight]() *lsWorkspace[W] {
+	return &lsWorkspace[W]{}
+}
+
+// localSearchSteepest performs steepest local search from init using the
+// workspace buffers. The returned solution owns a fresh copy of the path.
+func (ws *lsWorkspace[W]) localSearchSteepest(D [][]W, costs []W, init Solution[W]) Solution[W] {
+	path := append([]int(nil), init.Path...)
+	ws.ls.Improve(D, costs, path)
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
+}
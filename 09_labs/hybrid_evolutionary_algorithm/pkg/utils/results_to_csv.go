@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+
+	"github.com/czajkowskis/evolutionary_computation/09_labs/hybrid_evolutionary_algorithm/pkg/algorithms"
 )
 
 const outputDir = "output/results"
@@ -28,7 +30,7 @@ func intsToDashString(nums []int) string {
 	return sb.String()
 }
 
-func WriteResultsCSV(instanceName string, rows []Row) error {
+func WriteResultsCSV[W algorithms.Weight](instanceName string, rows []Row[W]) error {
 
 	if err := os.MkdirAll(outputDir, 0o755); err != nil {
 		return fmt.Errorf("make dir %s: %w", outputDir, err)
@@ -57,6 +59,9 @@ func WriteResultsCSV(instanceName string, rows []Row) error {
 		"max_objective",
 		"avg_time_ms",
 		"best_objective",
+		"lower_bound",
+		"best_gap_%",
+		"avg_gap_%",
 		"best_path",
 	}); err != nil {
 		return fmt.Errorf("write header: %w", err)
@@ -64,17 +69,26 @@ func WriteResultsCSV(instanceName string, rows []Row) error {
 
 	for _, r := range rows {
 		avg4 := fmt.Sprintf("%.4f", r.AvgV)
-		avgSummary := fmt.Sprintf("%.4f (%d, %d)", r.AvgV, r.MinV, r.MaxV)
+		avgSummary := fmt.Sprintf("%.4f (%v, %v)", r.AvgV, r.MinV, r.MaxV)
+		var bound, bestGap, avgGap string
+		if r.LowerBound > 0 {
+			bound = fmt.Sprint(r.LowerBound)
+			bestGap = fmt.Sprintf("%.4f", GapPercent(float64(r.BestValue), r.LowerBound))
+			avgGap = fmt.Sprintf("%.4f", GapPercent(r.AvgV, r.LowerBound))
+		}
 
 		rec := []string{
 			instanceName,
 			r.Name,
 			avg4,       // avg_objective
 			avgSummary, // av(min,max)
-			strconv.Itoa(r.MinV),
-			strconv.Itoa(r.MaxV),
+			fmt.Sprint(r.MinV),
+			fmt.Sprint(r.MaxV),
 			fmt.Sprintf("%.2f", r.AvgTms),
-			strconv.Itoa(r.BestValue),
+			fmt.Sprint(r.BestValue),
+			bound,
+			bestGap,
+			avgGap,
 			intsToDashString(r.BestPath),
 		}
 		if err := w.Write(rec); err != nil {
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/czajkowskis/evolutionary_computation/09_labs/hybrid_evolutionary_algorithm/pkg/algorithms"
+)
+
+// writeCSV writes header and records to outputDir/fileName.
+func writeCSV(fileName string, header []string, records [][]string) error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("make dir %s: %w", outputDir, err)
+	}
+
+	filename := filepath.Join(outputDir, fileName)
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("create csv: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+	if err := w.WriteAll(records); err != nil {
+		return fmt.Errorf("write rows: %w", err)
+	}
+
+	log.Printf("CSV saved: %s", filename)
+	return nil
+}
+
+// WriteTelemetryCSV writes the per-generation statistics of a hybrid
+// evolutionary algorithm run as a time series.
+func WriteTelemetryCSV[W algorithms.Weight](fileName string, stats []algorithms.GenerationStats[W]) error {
+	records := make([][]string, len(stats))
+	for i, g := range stats {
+		records[i] = []string{
+			strconv.Itoa(g.Generation),
+			strconv.Itoa(g.Iterations),
+			fmt.Sprintf("%.2f", float64(g.Elapsed.Nanoseconds())/1e6),
+			strconv.Itoa(g.Offspring),
+			strconv.Itoa(g.Accepted),
+			strconv.Itoa(g.Duplicates),
+			strconv.Itoa(g.Rejected),
+			strconv.Itoa(g.Restarts),
+			strconv.Itoa(g.PopulationSize),
+			fmt.Sprintf("%.2f", g.MeanLSImprovement),
+			fmt.Sprint(g.Best),
+			fmt.Sprintf("%.2f", g.Mean),
+			fmt.Sprint(g.Worst),
+			fmt.Sprintf("%.4f", g.Diversity),
+		}
+	}
+	return writeCSV(fileName, []string{
+		"generation",
+		"iterations",
+		"elapsed_ms",
+		"offspring",
+		"accepted",
+		"duplicates",
+		"rejected",
+		"restarts",
+		"population_size",
+		"mean_ls_improvement",
+		"best_objective",
+		"mean_objective",
+		"worst_objective",
+		"diversity",
+	}, records)
+}
+
+// WriteImprovementsCSV writes every new best solution of a hybrid
+// evolutionary algorithm run with the step that produced it.
+func WriteImprovementsCSV[W algorithms.Weight](fileName string, improvements []algorithms.Improvement[W]) error {
+	records := make([][]string, len(improvements))
+	for i, imp := range improvements {
+		records[i] = []string{
+			strconv.Itoa(imp.Iteration),
+			fmt.Sprintf("%.2f", float64(imp.Elapsed.Nanoseconds())/1e6),
+			fmt.Sprint(imp.Objective),
+			imp.Step,
+		}
+	}
+	return writeCSV(fileName, []string{"iteration", "elapsed_ms", "objective", "step"}, records)
+}
@@ -1,13 +1,14 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
 	"time"
 
+	"github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/10_lab/variable_neighborhood_search/pkg/algorithms"
-	"github.com/czajkowskis/evolutionary_computation/10_lab/variable_neighborhood_search/pkg/data"
 	"github.com/czajkowskis/evolutionary_computation/10_lab/variable_neighborhood_search/pkg/utils"
 	"github.com/czajkowskis/evolutionary_computation/10_lab/variable_neighborhood_search/pkg/visualisation"
 )
@@ -20,7 +21,7 @@ const (
 )
 
 // processInstance runs the full experimental pipeline for a single instance
-func processInstance(instanceName string, nodes []data.Node) {
+func processInstance[W algorithms.Weight](instanceName string, nodes []data.Node, policy data.DistancePolicy) {
 	log.Printf("Processing instance %s with %d nodes", instanceName, len(nodes))
 	fmt.Printf("Instance %s Statistics:\n", instanceName)
 
@@ -31,13 +32,12 @@ func processInstance(instanceName string, nodes []data.Node) {
 		timeLimit = time.Duration(timeLimitB * float64(time.Millisecond))
 	}
 
-	D := data.CalculateDistanceMatrix(nodes)
-	costs := make([]int, len(nodes))
-	for i, node := range nodes {
-		costs[i] = node.Cost
+	D, costs, err := data.Weights[W](nodes, policy)
+	if err != nil {
+		log.Fatalf("Error computing distances: %v", err)
 	}
 
-	var rows []utils.Row
+	var rows []utils.Row[W]
 
 	// Define VNS configurations to test (only with local search)
 	configs := []struct {
@@ -56,7 +56,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 		log.Printf("Starting VNS with config: %s for instance %s", cfg.name, instanceName)
 		start := time.Now()
 
-		var vnsResults []algorithms.VNSResult
+		var vnsResults []algorithms.VNSResult[W]
 		totalVNSIterations := 0
 		for run := 0; run < numVNSRuns; run++ {
 			vnsResult := algorithms.VariableNeighborhoodSearch(D, costs, algorithms.VNSConfig{
@@ -74,7 +74,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 		avgVNSTime := totalVNSTime / time.Duration(numVNSRuns)
 
 		// Collect VNS solutions for statistics
-		vnsSolutions := make([]algorithms.Solution, len(vnsResults))
+		vnsSolutions := make([]algorithms.Solution[W], len(vnsResults))
 		for i, r := range vnsResults {
 			vnsSolutions[i] = r.BestSolution
 		}
@@ -84,7 +84,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 		avgVNSIterations := float64(totalVNSIterations) / float64(numVNSRuns)
 		bestVNS := algorithms.FindBestSolution(vnsSolutions)
 
-		rows = append(rows, utils.Row{
+		rows = append(rows, utils.Row[W]{
 			Name:        cfg.name,
 			AvgV:        vnsAvg,
 			MinV:        vnsMin,
@@ -95,13 +95,13 @@ func processInstance(instanceName string, nodes []data.Node) {
 			BestValue:   bestVNS.Objective,
 		})
 
-		log.Printf("Completed %s: best value %d, avg time %.2f ms, avg iterations %.1f", cfg.name, bestVNS.Objective, avgVNSTimeMs, avgVNSIterations)
+		log.Printf("Completed %s: best value %v, avg time %.2f ms, avg iterations %.1f", cfg.name, bestVNS.Objective, avgVNSTimeMs, avgVNSIterations)
 	}
 
 	// Print console output
 	fmt.Println("\nObjective value: av (min, max)")
 	for _, r := range rows {
-		fmt.Printf("%-34s  %.2f (%d, %d)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
+		fmt.Printf("%-34s  %.2f (%v, %v)\n", r.Name, r.AvgV, r.MinV, r.MaxV)
 	}
 	fmt.Println()
 
@@ -125,7 +125,7 @@ func processInstance(instanceName string, nodes []data.Node) {
 
 	// Plot best solutions for each method
 	for i, r := range rows {
-		title := fmt.Sprintf("%s for Instance %s (Value: %d)", r.Name, instanceName, r.BestValue)
+		title := fmt.Sprintf("%s for Instance %s (Value: %v)", r.Name, instanceName, r.BestValue)
 		fileName := utils.SanitizeFileName(fmt.Sprintf("%s_Instance_%s_%d", r.Name, instanceName, i))
 		if err := visualisation.PlotSolution(nodes, r.BestPath, title, fileName, 0, 4000, 0, 2000); err != nil {
 			log.Printf("plot error for %s/%s: %v", instanceName, r.Name, err)
@@ -134,6 +134,19 @@ func processInstance(instanceName string, nodes []data.Node) {
 }
 
 func main() {
+	rounding := flag.String("rounding", "round", "distance and cost rounding: round, ceil, floor or exact")
+	scale := flag.Float64("scale", 1, "fixed-point factor applied to distances and costs before rounding")
+	flag.Parse()
+
+	policy, err := data.ParseDistancePolicy(*rounding, *scale)
+	if err != nil {
+		log.Fatalf("Invalid distance policy: %v", err)
+	}
+	run := processInstance[int]
+	if policy.Rounding == data.RoundExact {
+		run = processInstance[float64]
+	}
+
 	rand.Seed(time.Now().UnixNano())
 	log.Println("Starting Variable Neighborhood Search experiments")
 
@@ -146,9 +159,9 @@ func main() {
 		log.Fatalf("Error reading TSPB.csv: %v", err)
 	}
 
-	processInstance("A", nodesA)
+	run("A", nodesA, policy)
 	fmt.Println()
-	processInstance("B", nodesB)
+	run("B", nodesB, policy)
 
 	log.Println("Program execution completed")
 }
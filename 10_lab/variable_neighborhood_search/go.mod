@@ -17,3 +17,12 @@ require (
 	golang.org/x/text v0.23.0 // indirect
 )
 
+require (
+	github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics v0.0.0
+	github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas v0.0.0
+)
+
+replace (
+	github.com/czajkowskis/evolutionary_computation/01_labs/greedy_heuristics => ../../01_labs/greedy_heuristics
+	github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas => ../../05_labs/local_search_deltas
+)
@@ -1,9 +1,9 @@
 package algorithms
 
 // FindBestSolution finds the solution with the minimum objective value
-func FindBestSolution(solutions []Solution) Solution {
+func FindBestSolution[W Weight](solutions []Solution[W]) Solution[W] {
 	if len(solutions) == 0 {
-		return Solution{}
+		return Solution[W]{}
 	}
 	best := solutions[0]
 	for _, s := range solutions {
@@ -3,14 +3,16 @@ package algorithms
 import (
 	"math"
 	"math/rand"
+
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
 )
 
 // Calculate objective function value
-func objective(D [][]int, costs []int, path []int) int {
+func objective[W Weight](D [][]W, costs []W, path []int) W {
 	if len(path) == 0 {
 		return math.MaxInt32 / 4
 	}
-	sum := 0
+	var sum W
 	n := len(path)
 	for i := 0; i < n; i++ {
 		a := path[i]
@@ -41,7 +43,7 @@ func selectCount(n int) int {
 }
 
 // Generate random starting solution
-func startRandom(D [][]int, costs []int, rng *rand.Rand) Solution {
+func startRandom[W Weight](D [][]W, costs []W, rng *rand.Rand) Solution[W] {
 	n := len(D)
 	k := selectCount(n)
 	idx := make([]int, n)
@@ -51,11 +53,11 @@ func startRandom(D [][]int, costs []int, rng *rand.Rand) Solution {
 	rng.Shuffle(n, func(i, j int) { idx[i], idx[j] = idx[j], idx[i] })
 	path := append([]int(nil), idx[:k]...)
 	rng.Shuffle(k, func(i, j int) { path[i], path[j] = path[j], path[i] })
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
 // Generate greedy starting solution using nearest neighbor any position
-func startGreedy(D [][]int, costs []int, rng *rand.Rand) Solution {
+func startGreedy[W Weight](D [][]W, costs []W, rng *rand.Rand) Solution[W] {
 	n := len(D)
 	k := selectCount(n)
 
@@ -70,12 +72,12 @@ func startGreedy(D [][]int, costs []int, rng *rand.Rand) Solution {
 	}
 
 	for len(path) < k {
-		minIncrease := math.MaxInt32
+		minIncrease := deltas.MaxWeight[W]()
 		bestNodeIndex := -1
 		bestPosition := -1
 
 		for nodeIndex := range unvisited {
-			localMinIncrease := math.MaxInt32
+			localMinIncrease := deltas.MaxWeight[W]()
 			localBestPos := -1
 
 			if len(path) == 1 {
@@ -135,75 +137,26 @@ func startGreedy(D [][]int, costs []int, rng *rand.Rand) Solution {
 		}
 	}
 
-	return Solution{Path: path, Objective: objective(D, costs, path)}
-}
-
-// Calculate delta for 2-opt move (intra-route)
-func deltaTwoOpt(D [][]int, path []int, i, j int) int {
-	if i == j {
-		return 0
-	}
-	n := len(path)
-	// Adjacent edges -> degenerate 2-opt (no change)
-	if nextIdx(i, n) == j || nextIdx(j, n) == i {
-		return 0
-	}
-	a := path[i]
-	b := path[nextIdx(i, n)]
-	c := path[j]
-	d := path[nextIdx(j, n)]
-	before := D[a][b] + D[c][d]
-	after := D[a][c] + D[b][d]
-	return after - before
-}
-
-// Calculate delta for node exchange (inter-route)
-func deltaExchangeSelected(D [][]int, costs []int, path []int, i int, u int) int {
-	n := len(path)
-	a := path[prevIdx(i, n)]
-	v := path[i]
-	b := path[nextIdx(i, n)]
-	before := D[a][v] + D[v][b] + costs[v]
-	after := D[a][u] + D[u][b] + costs[u]
-	return after - before
-}
-
-// Apply 2-opt move
-func applyTwoOpt(path []int, i, j int) {
-	n := len(path)
-	if i == j || nextIdx(i, n) == j || nextIdx(j, n) == i {
-		return
-	}
-	if i > j {
-		i, j = j, i
-	}
-	for l, r := i+1, j; l < r; l, r = l+1, r-1 {
-		path[l], path[r] = path[r], path[l]
-	}
-}
-
-// Apply node exchange
-func applyExchangeSelected(path []int, i int, u int) {
-	path[i] = u
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
 // Steepest local search
-func localSearchSteepest(D [][]int, costs []int, init Solution) Solution {
+func localSearchSteepest[W Weight](D [][]W, costs []W, init Solution[W]) Solution[W] {
 	path := append([]int(nil), init.Path...)
 	n := len(path)
 
 	for {
-		bestDelta := 0
+		var bestDelta W
 		var bestMove func()
 
 		// Intra-route moves: 2-opt
 		for i := 0; i < n; i++ {
 			for j := i + 1; j < n; j++ {
-				dl := deltaTwoOpt(D, path, i, j)
+				dl := deltas.DeltaTwoOpt(D, path, i, j)
 				if dl < bestDelta {
 					ii, jj := i, j
 					bestDelta = dl
-					bestMove = func() { applyTwoOpt(path, ii, jj) }
+					bestMove = func() { deltas.ApplyTwoOpt(path, ii, jj) }
 				}
 			}
 		}
@@ -221,20 +174,20 @@ func localSearchSteepest(D [][]int, costs []int, init Solution) Solution {
 		}
 		for i := 0; i < n; i++ {
 			for _, u := range nonSel {
-				dl := deltaExchangeSelected(D, costs, path, i, u)
+				dl := deltas.DeltaExchangeSelected(D, costs, path, i, u)
 				if dl < bestDelta {
 					ii, uu := i, u
 					bestDelta = dl
-					bestMove = func() { applyExchangeSelected(path, ii, uu) }
+					bestMove = func() { deltas.ApplyExchangeSelected(path, ii, uu) }
 				}
 			}
 		}
 
-		if bestDelta < 0 {
+		if deltas.Improves(bestDelta) {
 			bestMove()
 		} else {
 			break
 		}
 	}
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
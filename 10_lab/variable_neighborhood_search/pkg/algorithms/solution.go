@@ -1,8 +1,14 @@
 package algorithms
 
+import deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
+
+// Weight is the type of distances and node costs: integers, or exact float64
+// values.
+type Weight = deltas.Weight
+
 // Solution represents a solution to the problem, including the path and its objective value.
-type Solution struct {
+type Solution[W Weight] struct {
 	Path      []int
-	Objective int
+	Objective W
 }
 
@@ -4,6 +4,8 @@ import (
 	"math"
 	"math/rand"
 	"time"
+
+	deltas "github.com/czajkowskis/evolutionary_computation/05_labs/local_search_deltas/pkg/algorithms"
 )
 
 // VNSConfig holds configuration for Variable Neighborhood Search
@@ -22,8 +24,8 @@ type VNSConfig struct {
 }
 
 // VNSResult contains the result of VNS execution
-type VNSResult struct {
-	BestSolution               Solution
+type VNSResult[W Weight] struct {
+	BestSolution               Solution[W]
 	Iterations                 int
 	Duration                   time.Duration
 	NeighborhoodUsage          []int   // Count of each neighborhood used
@@ -42,7 +44,7 @@ const (
 )
 
 // VariableNeighborhoodSearch implements VNS algorithm
-func VariableNeighborhoodSearch(D [][]int, costs []int, config VNSConfig) VNSResult {
+func VariableNeighborhoodSearch[W Weight](D [][]W, costs []W, config VNSConfig) VNSResult[W] {
 	startTime := time.Now()
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 
@@ -61,7 +63,7 @@ func VariableNeighborhoodSearch(D [][]int, costs []int, config VNSConfig) VNSRes
 	}
 
 	// Generate initial solution
-	var currentSolution Solution
+	var currentSolution Solution[W]
 	if config.InitialSolutionStrategy == "greedy" {
 		currentSolution = startGreedy(D, costs, rng)
 		currentSolution = localSearchSteepest(D, costs, currentSolution)
@@ -107,7 +109,7 @@ func VariableNeighborhoodSearch(D [][]int, costs []int, config VNSConfig) VNSRes
 			shakenSolution := shake(D, costs, currentSolution, NeighborhoodType(neighborhoodIdx), shakingIntensity, rng)
 
 			// Local search intensification
-			var localOpt Solution
+			var localOpt Solution[W]
 			if config.UseLocalSearch {
 				localOpt = localSearchSteepest(D, costs, shakenSolution)
 			} else {
@@ -147,7 +149,7 @@ func VariableNeighborhoodSearch(D [][]int, costs []int, config VNSConfig) VNSRes
 	if intensityCount > 0 {
 		avgIntensity = totalIntensity / float64(intensityCount)
 	}
-	return VNSResult{
+	return VNSResult[W]{
 		BestSolution:               bestSolution,
 		Iterations:                 iterations,
 		Duration:                   elapsed,
@@ -171,7 +173,7 @@ func selectNeighborhood(k int, strategy string, rng *rand.Rand) int {
 }
 
 // shake applies a shaking operator to escape local optimum
-func shake(D [][]int, costs []int, sol Solution, nType NeighborhoodType, intensity int, rng *rand.Rand) Solution {
+func shake[W Weight](D [][]W, costs []W, sol Solution[W], nType NeighborhoodType, intensity int, rng *rand.Rand) Solution[W] {
 	switch nType {
 	case NeighborhoodNodeExchange:
 		return shakeNodeExchange(D, costs, sol, intensity, rng)
@@ -187,12 +189,12 @@ func shake(D [][]int, costs []int, sol Solution, nType NeighborhoodType, intensi
 }
 
 // N2: Random 2-opt moves
-func shakeTwoOpt(D [][]int, costs []int, sol Solution, numMoves int, rng *rand.Rand) Solution {
+func shakeTwoOpt[W Weight](D [][]W, costs []W, sol Solution[W], numMoves int, rng *rand.Rand) Solution[W] {
 	path := append([]int(nil), sol.Path...)
 	n := len(path)
 
 	if n < 4 {
-		return Solution{Path: path, Objective: objective(D, costs, path)}
+		return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 	}
 
 	numMoves = min(numMoves, n/2)
@@ -203,20 +205,20 @@ func shakeTwoOpt(D [][]int, costs []int, sol Solution, numMoves int, rng *rand.R
 			idx1, idx2 = idx2, idx1
 		}
 		if idx2-idx1 > 1 && idx2-idx1 < n-1 {
-			applyTwoOpt(path, idx1, idx2)
+			deltas.ApplyTwoOpt(path, idx1, idx2)
 		}
 	}
 
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
 // N1: Node exchange (exchange selected nodes with non-selected nodes)
-func shakeNodeExchange(D [][]int, costs []int, sol Solution, numSwaps int, rng *rand.Rand) Solution {
+func shakeNodeExchange[W Weight](D [][]W, costs []W, sol Solution[W], numSwaps int, rng *rand.Rand) Solution[W] {
 	path := append([]int(nil), sol.Path...)
 	n := len(path)
 
 	if n < 2 {
-		return Solution{Path: path, Objective: objective(D, costs, path)}
+		return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 	}
 
 	inSel := make([]bool, len(D))
@@ -231,7 +233,7 @@ func shakeNodeExchange(D [][]int, costs []int, sol Solution, numSwaps int, rng *
 	}
 
 	if len(nonSel) == 0 {
-		return Solution{Path: path, Objective: objective(D, costs, path)}
+		return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 	}
 
 	numSwaps = min(numSwaps, n, len(nonSel))
@@ -245,17 +247,17 @@ func shakeNodeExchange(D [][]int, costs []int, sol Solution, numSwaps int, rng *
 		nonSel[nonSelIdx] = oldNode
 	}
 
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
 // N3: Destroy-repair (remove nodes and rebuild with greedy)
-func shakeDestroyRepair(D [][]int, costs []int, sol Solution, intensity int, rng *rand.Rand) Solution {
+func shakeDestroyRepair[W Weight](D [][]W, costs []W, sol Solution[W], intensity int, rng *rand.Rand) Solution[W] {
 	path := append([]int(nil), sol.Path...)
 	n := len(path)
 	k := selectCount(len(D))
 
 	if n < 4 {
-		return Solution{Path: path, Objective: objective(D, costs, path)}
+		return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 	}
 
 	// Destroy: remove 20-30% of nodes
@@ -294,12 +296,12 @@ func shakeDestroyRepair(D [][]int, costs []int, sol Solution, intensity int, rng
 	}
 
 	for len(path) < k && len(nonSel) > 0 {
-		minIncrease := math.MaxInt32
+		minIncrease := deltas.MaxWeight[W]()
 		bestNode := -1
 		bestPosition := -1
 
 		for _, node := range nonSel {
-			localMinIncrease := math.MaxInt32
+			localMinIncrease := deltas.MaxWeight[W]()
 			localBestPos := -1
 
 			if len(path) == 0 {
@@ -318,7 +320,7 @@ func shakeDestroyRepair(D [][]int, costs []int, sol Solution, intensity int, rng
 				}
 			} else {
 				for pos := 0; pos <= len(path); pos++ {
-					var inc int
+					var inc W
 					if pos == 0 {
 						inc = D[node][path[0]] + costs[node]
 					} else if pos == len(path) {
@@ -363,11 +365,11 @@ func shakeDestroyRepair(D [][]int, costs []int, sol Solution, intensity int, rng
 		}
 	}
 
-	return Solution{Path: path, Objective: objective(D, costs, path)}
+	return Solution[W]{Path: path, Objective: objective(D, costs, path)}
 }
 
 // N4: Double-bridge move (4-opt variant)
-func shakeDoubleBridge(D [][]int, costs []int, sol Solution, intensity int, rng *rand.Rand) Solution {
+func shakeDoubleBridge[W Weight](D [][]W, costs []W, sol Solution[W], intensity int, rng *rand.Rand) Solution[W] {
 	path := append([]int(nil), sol.Path...)
 	n := len(path)
 
@@ -412,7 +414,7 @@ func shakeDoubleBridge(D [][]int, costs []int, sol Solution, intensity int, rng
 				newPath = append(newPath, path[positions[3]+1:]...)
 			}
 
-			return Solution{Path: newPath, Objective: objective(D, costs, newPath)}
+			return Solution[W]{Path: newPath, Objective: objective(D, costs, newPath)}
 		}
 	}
 
@@ -1,13 +1,15 @@
 package utils
 
-type Row struct {
+import "github.com/czajkowskis/evolutionary_computation/10_lab/variable_neighborhood_search/pkg/algorithms"
+
+type Row[W algorithms.Weight] struct {
 	Name        string
 	AvgV        float64
-	MinV        int
-	MaxV        int
+	MinV        W
+	MaxV        W
 	AvgTms      float64
 	AvgVNSIters float64
 	BestPath    []int
-	BestValue   int
+	BestValue   W
 }
 